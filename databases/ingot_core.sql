@@ -17,6 +17,26 @@
 SET NAMES utf8mb4;
 SET FOREIGN_KEY_CHECKS = 0;
 
+-- ----------------------------
+-- Table structure for sys_api_key
+-- ----------------------------
+DROP TABLE IF EXISTS `sys_api_key`;
+CREATE TABLE `sys_api_key` (
+  `id` bigint(20) NOT NULL COMMENT 'ID',
+  `name` varchar(64) NOT NULL DEFAULT '' COMMENT '名称',
+  `prefix` varchar(32) NOT NULL COMMENT 'key 前缀',
+  `hashed_key` varchar(128) NOT NULL COMMENT 'key 摘要',
+  `username` varchar(64) NOT NULL COMMENT '所属主体',
+  `scopes` varchar(256) DEFAULT '' COMMENT '授权范围，逗号分隔',
+  `status` char(1) DEFAULT '0' COMMENT '状态, 0:正常，9:禁用',
+  `expires_at` datetime DEFAULT NULL COMMENT '过期时间',
+  `last_used_at` datetime DEFAULT NULL COMMENT '最后使用时间',
+  `created_at` datetime DEFAULT NULL COMMENT '创建日期',
+  `updated_at` datetime DEFAULT NULL COMMENT '更新日期',
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE KEY `uk_prefix` (`prefix`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
 -- ----------------------------
 -- Table structure for sys_authority
 -- ----------------------------
@@ -134,9 +154,23 @@ CREATE TABLE `sys_oauth_client_details` (
 BEGIN;
 INSERT INTO `sys_oauth_client_details` VALUES (1, 0, 1, 'ingot-service-acs', '{noop}GwCwru42UrxQTUFLWOAsJqa3/+WSTlRMwiQDBq8KOgI=', 'ingot-resource-acs', '', NULL, 'password,refresh_token,client_credentials', 'http://localhost:9527/#/', NULL, 7200, 2592000, '{}', 'true', 'standard', 'Service', '0', '授权中心服务', '2020-11-20 15:56:24', '2020-11-20 15:56:26', NULL);
 INSERT INTO `sys_oauth_client_details` VALUES (2, 0, 1, 'ingot-service-pms', '{noop}GwCwru42UrxQTUFLWOAsJqa3/+WSTlRMwiQDBq8KOgI=', 'ingot-resource-pms', '', NULL, 'password,refresh_token,client_credentials', 'http://localhost:9527/#/', NULL, 7200, 2592000, '{}', 'true', 'standard', 'Service', '0', '权限管理系统', '2020-11-20 15:56:24', '2020-11-20 15:56:26', NULL);
-INSERT INTO `sys_oauth_client_details` VALUES (3, 0, 1, 'web-cloud', '{noop}web-cloud', 'web-cloud-resource', 'ingot-resource-pms', 'web', 'password,refresh_token,client_credentials', NULL, 'role_web', 10, 30, '{}', 'false', 'standard', 'Service', '0', '云后管', '2020-11-20 15:57:29', '2020-11-20 15:57:31', NULL);
+INSERT INTO `sys_oauth_client_details` VALUES (3, 0, 1, 'web-cloud', '{noop}web-cloud', 'web-cloud-resource', 'ingot-resource-pms', 'web', 'password,refresh_token,client_credentials,mfa', NULL, 'role_web', 10, 30, '{}', 'false', 'standard', 'Service', '0', '云后管', '2020-11-20 15:57:29', '2020-11-20 15:57:31', NULL);
 COMMIT;
 
+-- ----------------------------
+-- Table structure for sys_persistent_logins
+-- ----------------------------
+DROP TABLE IF EXISTS `sys_persistent_logins`;
+CREATE TABLE `sys_persistent_logins` (
+  `series` varchar(64) NOT NULL COMMENT '记住我序列号',
+  `username` varchar(64) NOT NULL COMMENT '用户名',
+  `tenant_id` int(11) NOT NULL DEFAULT '0' COMMENT '租户ID',
+  `token` varchar(64) NOT NULL COMMENT '令牌',
+  `last_used` datetime NOT NULL COMMENT '最后使用时间',
+  PRIMARY KEY (`series`),
+  KEY `idx_username` (`username`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
 -- ----------------------------
 -- Table structure for sys_role
 -- ----------------------------
@@ -246,6 +280,8 @@ CREATE TABLE `sys_social_details` (
   `app_id` varchar(64) NOT NULL COMMENT 'App ID',
   `app_secret` varchar(64) DEFAULT NULL COMMENT 'App Secret',
   `redirect_url` varchar(128) DEFAULT NULL COMMENT '重定向地址',
+  `token_url` varchar(256) DEFAULT NULL COMMENT '获取 access_token 地址',
+  `user_info_url` varchar(256) DEFAULT NULL COMMENT '获取用户信息地址',
   `name` varchar(20) DEFAULT NULL COMMENT '社交名称',
   `type` varchar(20) DEFAULT NULL COMMENT '类型',
   `status` char(1) CHARACTER SET utf8 DEFAULT '0' COMMENT '状态, 0:正常，9:禁用',
@@ -296,6 +332,10 @@ CREATE TABLE `sys_user` (
   `phone` varchar(30) CHARACTER SET utf8 DEFAULT '' COMMENT '手机号',
   `email` varchar(50) CHARACTER SET utf8 DEFAULT '' COMMENT '邮件地址',
   `status` char(1) CHARACTER SET utf8 DEFAULT '0' COMMENT '状态, 0:正常，9:禁用',
+  `mfa_enabled` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否开启多因素认证',
+  `mfa_secret` varchar(64) CHARACTER SET utf8 DEFAULT '' COMMENT 'TOTP 密钥',
+  `mfa_recovery_codes` varchar(1024) CHARACTER SET utf8 DEFAULT '' COMMENT '恢复码摘要，逗号分隔',
+  `mfa_last_step` bigint(20) NOT NULL DEFAULT '0' COMMENT '最后使用的动态码时间步长',
   `created_at` datetime DEFAULT NULL COMMENT '创建日期',
   `updated_at` datetime DEFAULT NULL COMMENT '更新日期',
   `deleted_at` datetime DEFAULT NULL COMMENT '删除日期',
@@ -309,7 +349,24 @@ CREATE TABLE `sys_user` (
 -- Records of sys_user
 -- ----------------------------
 BEGIN;
-INSERT INTO `sys_user` VALUES (1, 0, 1, 1, 'admin', '{noop}admin', '超级管理员', '18603243837', 'admin@ingot.com', '0', '2021-01-03 11:02:46', NULL, NULL);
+INSERT INTO `sys_user` (`id`, `version`, `tenant_id`, `dept_id`, `username`, `password`, `real_name`, `phone`, `email`, `status`, `mfa_enabled`, `mfa_secret`, `mfa_recovery_codes`, `mfa_last_step`, `created_at`, `updated_at`, `deleted_at`) VALUES (1, 0, 1, 1, 'admin', '{bcrypt}$2a$10$pPasiHA6oYfc/I9em0GFFOMMd1RVGPM46x9CDfbrSvoZWUoP1wYDC', '超级管理员', '18603243837', 'admin@ingot.com', '0', 0, '', '', 0, '2021-01-03 11:02:46', NULL, NULL);
 COMMIT;
 
+-- ----------------------------
+-- Table structure for sys_user_social
+-- ----------------------------
+DROP TABLE IF EXISTS `sys_user_social`;
+CREATE TABLE `sys_user_social` (
+  `id` bigint(20) NOT NULL COMMENT 'ID',
+  `tenant_id` int(11) NOT NULL COMMENT '租户ID',
+  `user_id` bigint(20) NOT NULL COMMENT '用户ID',
+  `type` varchar(20) NOT NULL COMMENT '第三方平台类型',
+  `unique_id` varchar(128) NOT NULL COMMENT '第三方平台用户唯一标识',
+  `name` varchar(64) DEFAULT '' COMMENT '第三方平台用户名称',
+  `created_at` datetime DEFAULT NULL COMMENT '绑定日期',
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE KEY `unique-type-tenant` (`unique_id`,`type`,`tenant_id`) USING BTREE COMMENT '第三方账号只能绑定一个用户',
+  UNIQUE KEY `user-type` (`user_id`,`type`) USING BTREE COMMENT '用户每种平台只能绑定一个账号'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
 SET FOREIGN_KEY_CHECKS = 1;
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ingot-cloud/ingot-go/internal/app/core/security/user"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/dto"
+	"github.com/ingot-cloud/ingot-go/internal/app/service"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+	coreIngot "github.com/ingot-cloud/ingot-go/pkg/framework/core/web/ingot"
+	ginwrapper "github.com/ingot-cloud/ingot-go/pkg/framework/core/wrapper/gin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+)
+
+// Mfa 当前用户多因素认证注册API
+type Mfa struct {
+	MfaService service.Mfa
+}
+
+// Apply api配置
+func (m *Mfa) Apply(app *coreIngot.Router) {
+	router := app.Group("/user/mfa")
+	router.POST("/secret", m.secret)
+	router.POST("/enable", m.enable)
+	router.POST("/disable", m.disable)
+	router.POST("/recovery-codes", m.recoveryCodes)
+}
+
+func (m *Mfa) secret(ctx *gin.Context) (interface{}, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.MfaService.GenerateSecret(userID)
+}
+
+func (m *Mfa) enable(ctx *gin.Context) (interface{}, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var params dto.MfaCode
+	if err := ginwrapper.ParseJSON(ctx, &params); err != nil {
+		return nil, err
+	}
+	return m.MfaService.Enable(userID, params.Code)
+}
+
+func (m *Mfa) disable(ctx *gin.Context) (interface{}, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var params dto.MfaCode
+	if err := ginwrapper.ParseJSON(ctx, &params); err != nil {
+		return nil, err
+	}
+	return nil, m.MfaService.Disable(userID, params.Code)
+}
+
+func (m *Mfa) recoveryCodes(ctx *gin.Context) (interface{}, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var params dto.MfaCode
+	if err := ginwrapper.ParseJSON(ctx, &params); err != nil {
+		return nil, err
+	}
+	return m.MfaService.RegenerateRecoveryCodes(userID, params.Code)
+}
+
+// 获取当前登录用户ID
+func currentUserID(ctx *gin.Context) (types.ID, error) {
+	auth := ingot.GetAuthentication(ctx)
+	if auth == nil {
+		return 0, errors.ErrUnauthorized
+	}
+	ingotUser, ok := auth.GetPrincipal().(*user.IngotUser)
+	if !ok {
+		return 0, errors.ErrUnauthorized
+	}
+	return ingotUser.ID, nil
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/dto"
+	"github.com/ingot-cloud/ingot-go/internal/app/service"
+	coreIngot "github.com/ingot-cloud/ingot-go/pkg/framework/core/web/ingot"
+	ginwrapper "github.com/ingot-cloud/ingot-go/pkg/framework/core/wrapper/gin"
+)
+
+// Social 当前用户绑定第三方账号API
+type Social struct {
+	SocialService service.Social
+}
+
+// Apply api配置
+func (s *Social) Apply(app *coreIngot.Router) {
+	router := app.Group("/user/social")
+	router.POST("/bind", s.bind)
+	router.POST("/unbind", s.unbind)
+}
+
+func (s *Social) bind(ctx *gin.Context) (interface{}, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var params dto.SocialBind
+	if err := ginwrapper.ParseJSON(ctx, &params); err != nil {
+		return nil, err
+	}
+	return nil, s.SocialService.Bind(userID, params.Type, params.Code)
+}
+
+func (s *Social) unbind(ctx *gin.Context) (interface{}, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var params dto.SocialUnbind
+	if err := ginwrapper.ParseJSON(ctx, &params); err != nil {
+		return nil, err
+	}
+	return nil, s.SocialService.Unbind(userID, params.Type)
+}
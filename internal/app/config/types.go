@@ -3,7 +3,22 @@ package config
 import (
 	"github.com/ingot-cloud/ingot-go/pkg/framework/boot/config"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/attempt"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/mfa"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/session"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/sms"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails/cache"
+	cryptoFactory "github.com/ingot-cloud/ingot-go/pkg/framework/security/crypto/factory"
 	oauth2Config "github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/config"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/apikey"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/cors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/csrf"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/formlogin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/headers"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/preauth"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/rememberme"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/filter"
 )
 
 // Config struct
@@ -58,6 +73,21 @@ type Redis struct {
 
 // Security config
 type Security struct {
-	PermitURLs []string            `yaml:"permitUrls"`
-	OAuth2     oauth2Config.OAuth2 `yaml:"oauth2"`
+	PermitURLs        []string             `yaml:"permitUrls"`
+	Cors              cors.Config          `yaml:"cors"`
+	Headers           headers.Config       `yaml:"headers"`
+	Debug             filter.DebugConfig   `yaml:"debug"`
+	FormLogin         formlogin.Config     `yaml:"formLogin"`
+	Csrf              csrf.Config          `yaml:"csrf"`
+	RememberMe        rememberme.Config    `yaml:"rememberMe"`
+	SessionManagement session.Config       `yaml:"sessionManagement"`
+	ApiKey            apikey.Config        `yaml:"apiKey"`
+	PreAuth           preauth.Config       `yaml:"preAuth"`
+	Mfa               mfa.Config           `yaml:"mfa"`
+	LoginAttempt      attempt.Config       `yaml:"loginAttempt"`
+	Sms               sms.Config           `yaml:"sms"`
+	PasswordEncoder   cryptoFactory.Config `yaml:"passwordEncoder"`
+	UserCache         cache.Config         `yaml:"userCache"`
+	ClientDetails     clientdetails.Config `yaml:"clientDetails"`
+	OAuth2            oauth2Config.OAuth2  `yaml:"oauth2"`
 }
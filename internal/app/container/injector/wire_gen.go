@@ -1,7 +1,8 @@
 // Code generated by Wire. DO NOT EDIT.
 
 //go:generate wire
-//+build !wireinject
+//go:build !wireinject
+// +build !wireinject
 
 package injector
 
@@ -78,29 +79,167 @@ func BuildContainer(config3 *config.Config, options *config.Options) (container.
 		return nil, nil, err
 	}
 	test := &api.Test{}
+	mfaConfig, err := factory.MfaConfig(config3)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	implMfa := &impl.Mfa{
+		UserDao:   user,
+		MfaConfig: mfaConfig,
+	}
+	apiMfa := &api.Mfa{
+		MfaService: implMfa,
+	}
+	socialDetails := &dao.SocialDetails{
+		DB: db,
+	}
+	userSocial := &dao.UserSocial{
+		DB: db,
+	}
+	generator := factory.NewIDGenerator()
+	social := &impl.Social{
+		SocialDetailsDao: socialDetails,
+		UserSocialDao:    userSocial,
+		UserDao:          user,
+		IDGenerator:      generator,
+	}
+	apiSocial := &api.Social{
+		SocialService: social,
+	}
 	apiConfig := &http.APIConfig{
 		CasbinEnforcer: syncedEnforcer,
 		SecurityConfig: security,
 		TestAPI:        test,
+		MfaAPI:         apiMfa,
+		SocialAPI:      apiSocial,
 	}
 	webSecurityConfigurersImpl := &provider2.WebSecurityConfigurersImpl{}
-	encoder := provider2.PasswordEncoder()
-	userCache := provider2.UserCache()
+	cryptoFactoryConfig, err := factory.PasswordEncoderConfig(config3)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	encoder := provider2.PasswordEncoder(cryptoFactoryConfig)
 	preChecker := provider2.PreChecker()
 	postChecker := provider2.PostChecker()
 	userdetailsService := provider2.UserDetailsService()
-	clientdetailsService := provider2.ClientDetailsService()
+	passwordService := provider2.UserDetailsPasswordService()
+	clientdetailsConfig, err := factory.ClientDetailsConfig(config3)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	clientdetailsService := provider2.ClientDetailsService(clientdetailsConfig)
+	apikeyService := provider2.ApiKeyService()
+	mfaService := provider2.MfaService()
+	corsConfig, err := factory.CorsConfig(config3)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	configurationSource := provider2.CorsSource(corsConfig)
+	headersConfig, err := factory.HeadersConfig(config3)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	debugConfig, err := factory.FilterChainDebugConfig(config3)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	redisClient, cleanup3, err := factory.NewRedis(config3)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	sessionConfig, err := factory.SessionManagementConfig(config3)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	registry := provider2.SessionRegistry(sessionConfig, redisClient)
+	concurrentControl := provider2.ConcurrentSessionControl(sessionConfig, registry)
+	mfaStore := provider2.MfaTokenStore(mfaConfig, redisClient)
+	attemptConfig, err := factory.LoginAttemptConfig(config3)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	tracker := provider2.LoginAttemptTracker(attemptConfig, redisClient)
+	cacheConfig, err := factory.UserCacheConfig(config3)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	userCache := provider2.UserCache(cacheConfig, redisClient)
+	evictor := provider2.UserCacheEvictor(userCache)
+	smsConfig, err := factory.SmsConfig(config3)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	sender := provider2.SmsSender()
+	phoneNormalizer := provider2.SmsPhoneNormalizer()
+	smsStore := provider2.SmsCodeStore(smsConfig, redisClient)
+	codeService := provider2.SmsCodeService(smsConfig, smsStore, sender, phoneNormalizer)
+	smsUserDetailsService := provider2.SmsUserDetailsService()
+	socialUserDetailsService := provider2.SocialUserDetailsService()
+	publisher := provider2.AuthenticationEventPublisher()
 	commonContainer := &container2.CommonContainer{
-		WebSecurityConfigurers: webSecurityConfigurersImpl,
-		PasswordEncoder:        encoder,
-		UserCache:              userCache,
-		PreChecker:             preChecker,
-		PostChecker:            postChecker,
-		UserDetailsService:     userdetailsService,
-		ClientDetailsService:   clientdetailsService,
+		WebSecurityConfigurers:       webSecurityConfigurersImpl,
+		PasswordEncoderConfig:        cryptoFactoryConfig,
+		PasswordEncoder:              encoder,
+		UserCacheConfig:              cacheConfig,
+		UserCache:                    userCache,
+		UserCacheEvictor:             evictor,
+		PreChecker:                   preChecker,
+		PostChecker:                  postChecker,
+		UserDetailsService:           userdetailsService,
+		UserDetailsPasswordService:   passwordService,
+		ClientDetailsConfig:          clientdetailsConfig,
+		ClientDetailsService:         clientdetailsService,
+		ApiKeyService:                apikeyService,
+		CorsSource:                   configurationSource,
+		HeadersConfig:                headersConfig,
+		FilterChainDebugConfig:       debugConfig,
+		RedisClient:                  redisClient,
+		SessionManagementConfig:      sessionConfig,
+		SessionRegistry:              registry,
+		ConcurrentSessionControl:     concurrentControl,
+		MfaConfig:                    mfaConfig,
+		MfaService:                   mfaService,
+		MfaTokenStore:                mfaStore,
+		LoginAttemptConfig:           attemptConfig,
+		LoginAttemptTracker:          tracker,
+		SmsConfig:                    smsConfig,
+		SmsSender:                    sender,
+		SmsPhoneNormalizer:           phoneNormalizer,
+		SmsCodeStore:                 smsStore,
+		SmsCodeService:               codeService,
+		SmsUserDetailsService:        smsUserDetailsService,
+		SocialUserDetailsService:     socialUserDetailsService,
+		AuthenticationEventPublisher: publisher,
 	}
 	oAuth2, err := factory.OAuth2Config(config3)
 	if err != nil {
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
@@ -116,36 +255,74 @@ func BuildContainer(config3 *config.Config, options *config.Options) (container.
 		AccessTokenConverter:        accessTokenConverter,
 		UserAuthenticationConverter: userAuthenticationConverter,
 	}
-	resourceServerTokenServices := provider2.ResourceServerTokenServices(store)
+	resourceServerTokenServices := provider2.ResourceServerTokenServices(store, concurrentControl)
 	resourceManager := provider2.ResourceAuthenticationManager(oAuth2, resourceServerTokenServices)
 	tokenExtractor := provider2.TokenExtractor()
-	resourceServerConfigurer := provider2.ResourceServerConfigurer(tokenExtractor, resourceManager)
-	resourceServerContainer := &container2.ResourceServerContainer{
-		AuthenticationManager:       resourceManager,
-		ResourceServerConfigurer:    resourceServerConfigurer,
-		ResourceServerTokenServices: resourceServerTokenServices,
-		TokenExtractor:              tokenExtractor,
+	apikeyConfig, err := factory.ApiKeyConfig(config3)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	preauthConfig, err := factory.PreAuthConfig(config3)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
 	}
 	authenticationProvider := provider2.BasicAuthenticationProvider(commonContainer)
 	daoAuthenticationProvider := provider2.DaoAuthenticationProvider(commonContainer)
+	apikeyAuthenticationProvider := provider2.ApiKeyAuthenticationProvider(commonContainer)
+	preauthAuthenticationProvider := provider2.PreAuthAuthenticationProvider(commonContainer)
+	smsAuthenticationProvider := provider2.SmsAuthenticationProvider(commonContainer)
+	socialAuthenticationProvider := provider2.SocialAuthenticationProvider(commonContainer)
 	providersImpl := &provider2.ProvidersImpl{
-		Basic: authenticationProvider,
-		Dao:   daoAuthenticationProvider,
+		Basic:   authenticationProvider,
+		Dao:     daoAuthenticationProvider,
+		ApiKey:  apikeyAuthenticationProvider,
+		PreAuth: preauthAuthenticationProvider,
+		Sms:     smsAuthenticationProvider,
+		Social:  socialAuthenticationProvider,
 	}
 	authProvidersContainer := &container2.AuthProvidersContainer{
 		Providers: providersImpl,
 		Basic:     authenticationProvider,
 		Dao:       daoAuthenticationProvider,
+		ApiKey:    apikeyAuthenticationProvider,
+		PreAuth:   preauthAuthenticationProvider,
+		Sms:       smsAuthenticationProvider,
+		Social:    socialAuthenticationProvider,
+	}
+	authorizationManager := provider2.AuthorizationAuthenticationManager(authProvidersContainer, commonContainer)
+	apikeySecurityConfigurer := provider2.ApiKeyConfigurer(apikeyConfig, authorizationManager)
+	preauthSecurityConfigurer := provider2.PreAuthConfigurer(preauthConfig, authorizationManager)
+	resourceServerConfigurer := provider2.ResourceServerConfigurer(tokenExtractor, resourceManager, configurationSource, headersConfig, apikeySecurityConfigurer, preauthSecurityConfigurer, commonContainer)
+	resourceServerContainer := &container2.ResourceServerContainer{
+		AuthenticationManager:       resourceManager,
+		ResourceServerConfigurer:    resourceServerConfigurer,
+		ResourceServerTokenServices: resourceServerTokenServices,
+		TokenExtractor:              tokenExtractor,
+		ApiKeyConfig:                apikeyConfig,
+		ApiKeyConfigurer:            apikeySecurityConfigurer,
+		PreAuthConfig:               preauthConfig,
+		PreAuthConfigurer:           preauthSecurityConfigurer,
 	}
-	authorizationManager := provider2.AuthorizationAuthenticationManager(authProvidersContainer)
-	authorizationServerConfigurer := provider2.AuthorizationServerConfigurer(authorizationManager)
-	enhancer := provider2.TokenEnhancer(oAuth2Container)
+	authorizationServerConfigurer := provider2.AuthorizationServerConfigurer(oAuth2, authorizationManager, configurationSource, headersConfig, commonContainer)
+	enhancer := provider2.TokenEnhancer(oAuth2, oAuth2Container)
 	authorizationServerTokenServices := provider2.AuthorizationServerTokenServices(oAuth2, store, commonContainer, enhancer, authorizationManager)
-	consumerTokenServices := provider2.ConsumerTokenServices(store)
-	passwordTokenGranter := provider2.PasswordTokenGranter(authorizationServerTokenServices, authorizationManager)
-	granter := provider2.TokenGranter(passwordTokenGranter)
+	consumerTokenServices := provider2.ConsumerTokenServices(store, commonContainer)
+	manager := provider2.MfaManager(mfaConfig, mfaStore, commonContainer)
+	passwordTokenGranter := provider2.PasswordTokenGranter(authorizationServerTokenServices, authorizationManager, manager)
+	mfaTokenGranter := provider2.MfaTokenGranter(authorizationServerTokenServices, authorizationManager, manager)
+	smsTokenGranter := provider2.SmsTokenGranter(authorizationServerTokenServices, authorizationManager)
+	socialTokenGranter := provider2.SocialTokenGranter(authorizationServerTokenServices, authorizationManager)
+	granter := provider2.TokenGranter(passwordTokenGranter, mfaTokenGranter, smsTokenGranter, socialTokenGranter)
 	tokenEndpoint := provider2.TokenEndpoint(granter, commonContainer)
-	oAuth2HTTPConfigurer := provider2.TokenEndpointHTTPConfigurer(tokenEndpoint)
+	smsCodeEndpoint := provider2.SmsCodeEndpoint(commonContainer)
+	clientRegistrationEndpoint := provider2.ClientRegistrationEndpoint(oAuth2, commonContainer)
+	oAuth2HTTPConfigurer := provider2.TokenEndpointHTTPConfigurer(tokenEndpoint, smsCodeEndpoint, clientRegistrationEndpoint)
 	authorizationServerContainer := &container2.AuthorizationServerContainer{
 		AuthenticationManager:            authorizationManager,
 		AuthorizationServerConfigurer:    authorizationServerConfigurer,
@@ -156,6 +333,52 @@ func BuildContainer(config3 *config.Config, options *config.Options) (container.
 		TokenEnhancer:                    enhancer,
 		TokenGranter:                     granter,
 		PasswordTokenGranter:             passwordTokenGranter,
+		MfaTokenGranter:                  mfaTokenGranter,
+		SmsTokenGranter:                  smsTokenGranter,
+		SocialTokenGranter:               socialTokenGranter,
+		SmsCodeEndpoint:                  smsCodeEndpoint,
+		ClientRegistrationEndpoint:       clientRegistrationEndpoint,
+		MfaManager:                       manager,
+	}
+	formloginConfig, err := factory.FormLoginConfig(config3)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	sessionStore := provider2.SessionStore(formloginConfig, redisClient)
+	csrfConfig, err := factory.CsrfConfig(config3)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	tokenRepository := provider2.CsrfTokenRepository(csrfConfig, sessionStore)
+	securityConfigurer := provider2.CsrfConfigurer(csrfConfig, tokenRepository)
+	remembermeConfig, err := factory.RememberMeConfig(config3)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	remembermeTokenRepository := provider2.RememberMeTokenRepository(remembermeConfig, redisClient)
+	services := provider2.RememberMeServices(remembermeConfig, remembermeTokenRepository, commonContainer)
+	remembermeSecurityConfigurer := provider2.RememberMeConfigurer(remembermeConfig, services, sessionStore, concurrentControl)
+	formLoginConfigurer := provider2.FormLoginConfigurer(formloginConfig, authorizationManager, sessionStore, securityConfigurer, remembermeSecurityConfigurer, configurationSource, headersConfig, concurrentControl, manager)
+	webContainer := &container2.WebContainer{
+		FormLoginConfig:           formloginConfig,
+		SessionStore:              sessionStore,
+		CsrfConfig:                csrfConfig,
+		CsrfTokenRepository:       tokenRepository,
+		CsrfConfigurer:            securityConfigurer,
+		RememberMeConfig:          remembermeConfig,
+		RememberMeTokenRepository: remembermeTokenRepository,
+		RememberMeServices:        services,
+		RememberMeConfigurer:      remembermeSecurityConfigurer,
+		FormLoginConfigurer:       formLoginConfigurer,
 	}
 	securityContainerImpl := &container2.SecurityContainerImpl{
 		CommonContainer:              commonContainer,
@@ -163,32 +386,78 @@ func BuildContainer(config3 *config.Config, options *config.Options) (container.
 		ResourceServerContainer:      resourceServerContainer,
 		AuthorizationServerContainer: authorizationServerContainer,
 		AuthProvidersContainer:       authProvidersContainer,
+		WebContainer:                 webContainer,
 	}
 	defaultContainerInjector := &container.DefaultContainerInjector{}
 	oauthClientDetails := &dao.OauthClientDetails{
 		DB: db,
 	}
-	userDetail := &impl.UserDetail{}
+	persistentLogins := &dao.PersistentLogins{
+		DB: db,
+	}
+	userDetail := &impl.UserDetail{
+		UserDao:               user,
+		RoleUserDao:           roleUser,
+		RoleDao:               role,
+		OauthClientDetailsDao: oauthClientDetails,
+	}
 	requestMatcher := provider.PermitURLMatcher(security)
+	gormService := provider.ClientDetailsStore(db, generator, clientdetailsConfig)
 	clientDetails := &service.ClientDetails{
-		OauthClientDetailsDao: oauthClientDetails,
+		GormService: gormService,
 	}
 	userDetails := &service.UserDetails{
+		UserDetailService:     userDetail,
+		OauthClientDetailsDao: oauthClientDetails,
+	}
+	resourceServerAdapter := provider.ResourceServerAdapter(tokenExtractor, resourceManager, configurationSource, headersConfig, apikeySecurityConfigurer, preauthSecurityConfigurer, publisher, requestMatcher)
+	rememberMeTokenRepository := &service.RememberMeTokenRepository{
+		PersistentLoginsDao: persistentLogins,
+	}
+	apiKey := &dao.ApiKey{
+		DB: db,
+	}
+	apiKeyService := &service.ApiKeyService{
+		ApiKeyDao: apiKey,
+	}
+	serviceMfaService := &service.MfaService{
+		UserDao: user,
+	}
+	userDetailsPasswordService := &service.UserDetailsPasswordService{
+		UserDao: user,
+	}
+	smsUserDetails := &service.SmsUserDetails{
 		UserDetailService: userDetail,
 	}
-	resourceServerAdapter := provider.ResourceServerAdapter(tokenExtractor, resourceManager, requestMatcher)
-	ingotEnhancerChain := provider.IngotEnhancerChain(jwtAccessTokenConverter)
+	smsPhoneNormalizer := &service.SmsPhoneNormalizer{}
+	socialUserDetails := &service.SocialUserDetails{
+		UserDetailService:     userDetail,
+		SocialService:         social,
+		OauthClientDetailsDao: oauthClientDetails,
+	}
+	ingotEnhancerChain := provider.IngotEnhancerChain(oAuth2, jwtAccessTokenConverter)
 	ingotUserAuthenticationConverter := &token.IngotUserAuthenticationConverter{}
 	ingotContainerInjector := &config2.IngotContainerInjector{
 		DefaultContainerInjector:         defaultContainerInjector,
 		OauthClientDetailsDao:            oauthClientDetails,
+		PersistentLoginsDao:              persistentLogins,
+		ApiKeyDao:                        apiKey,
 		UserDetailService:                userDetail,
+		ClientDetailsStore:               gormService,
+		SocialService:                    social,
 		Ignore:                           requestMatcher,
 		ClientDetailsService:             clientDetails,
 		UserDetailsService:               userDetails,
 		ResourceServerAdapter:            resourceServerAdapter,
 		IngotEnhancerChain:               ingotEnhancerChain,
 		IngotUserAuthenticationConverter: ingotUserAuthenticationConverter,
+		RememberMeTokenRepository:        rememberMeTokenRepository,
+		ApiKeyService:                    apiKeyService,
+		MfaService:                       serviceMfaService,
+		UserDetailsPasswordService:       userDetailsPasswordService,
+		SmsUserDetailsService:            smsUserDetails,
+		SmsPhoneNormalizer:               smsPhoneNormalizer,
+		SocialUserDetailsService:         socialUserDetails,
 	}
 	defaultContainerPre := &container.DefaultContainerPre{
 		HTTPConfig:        httpConfig,
@@ -200,6 +469,7 @@ func BuildContainer(config3 *config.Config, options *config.Options) (container.
 	containerPrint := provider3.BuildContainerProcess(defaultContainerPre, providerSet)
 	containerContainer := provider3.PrintInjectInstance(containerPrint)
 	return containerContainer, func() {
+		cleanup3()
 		cleanup2()
 		cleanup()
 	}, nil
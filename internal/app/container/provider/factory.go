@@ -10,5 +10,6 @@ var AllFactory = wire.NewSet(
 	factory.Config,
 	factory.NewCasbin,
 	factory.NewGorm,
+	factory.NewRedis,
 	factory.NewIDGenerator,
 )
@@ -4,7 +4,22 @@ import (
 	"github.com/google/wire"
 	"github.com/ingot-cloud/ingot-go/internal/app/config"
 	httpConfig "github.com/ingot-cloud/ingot-go/pkg/framework/boot/config"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/attempt"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/mfa"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/session"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/sms"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails/cache"
+	cryptoFactory "github.com/ingot-cloud/ingot-go/pkg/framework/security/crypto/factory"
 	oauth2Config "github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/config"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/apikey"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/cors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/csrf"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/formlogin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/headers"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/preauth"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/rememberme"
+	securityFilter "github.com/ingot-cloud/ingot-go/pkg/framework/security/web/filter"
 )
 
 // HTTPConfig 单独注入 http config
@@ -22,9 +37,99 @@ func OAuth2Config(config *config.Config) (oauth2Config.OAuth2, error) {
 	return config.Security.OAuth2, nil
 }
 
+// CorsConfig 单独注入跨域配置
+func CorsConfig(config *config.Config) (cors.Config, error) {
+	return config.Security.Cors, nil
+}
+
+// HeadersConfig 单独注入安全响应头配置
+func HeadersConfig(config *config.Config) (headers.Config, error) {
+	return config.Security.Headers, nil
+}
+
+// FormLoginConfig 单独注入表单登录配置
+func FormLoginConfig(config *config.Config) (formlogin.Config, error) {
+	return config.Security.FormLogin, nil
+}
+
+// CsrfConfig 单独注入 CSRF 配置
+func CsrfConfig(config *config.Config) (csrf.Config, error) {
+	return config.Security.Csrf, nil
+}
+
+// RememberMeConfig 单独注入记住我配置
+func RememberMeConfig(config *config.Config) (rememberme.Config, error) {
+	return config.Security.RememberMe, nil
+}
+
+// SessionManagementConfig 单独注入并发会话控制配置
+func SessionManagementConfig(config *config.Config) (session.Config, error) {
+	return config.Security.SessionManagement, nil
+}
+
+// ApiKeyConfig 单独注入 API key 配置
+func ApiKeyConfig(config *config.Config) (apikey.Config, error) {
+	return config.Security.ApiKey, nil
+}
+
+// PreAuthConfig 单独注入预身份验证配置
+func PreAuthConfig(config *config.Config) (preauth.Config, error) {
+	return config.Security.PreAuth, nil
+}
+
+// MfaConfig 单独注入多因素认证配置
+func MfaConfig(config *config.Config) (mfa.Config, error) {
+	return config.Security.Mfa, nil
+}
+
+// LoginAttemptConfig 单独注入登录失败锁定配置
+func LoginAttemptConfig(config *config.Config) (attempt.Config, error) {
+	return config.Security.LoginAttempt, nil
+}
+
+// SmsConfig 单独注入短信验证码登录配置
+func SmsConfig(config *config.Config) (sms.Config, error) {
+	return config.Security.Sms, nil
+}
+
+// PasswordEncoderConfig 单独注入密码编码配置
+func PasswordEncoderConfig(config *config.Config) (cryptoFactory.Config, error) {
+	return config.Security.PasswordEncoder, nil
+}
+
+// UserCacheConfig 单独注入用户缓存配置
+func UserCacheConfig(config *config.Config) (cache.Config, error) {
+	return config.Security.UserCache, nil
+}
+
+// ClientDetailsConfig 单独注入客户端详情配置
+func ClientDetailsConfig(config *config.Config) (clientdetails.Config, error) {
+	return config.Security.ClientDetails, nil
+}
+
+// FilterChainDebugConfig 单独注入过滤器链调试端点配置
+func FilterChainDebugConfig(config *config.Config) (securityFilter.DebugConfig, error) {
+	return config.Security.Debug, nil
+}
+
 // Config 需要单独注入的配置
 var Config = wire.NewSet(
 	HTTPConfig,
 	SecurityConfig,
 	OAuth2Config,
+	CorsConfig,
+	HeadersConfig,
+	FilterChainDebugConfig,
+	FormLoginConfig,
+	CsrfConfig,
+	RememberMeConfig,
+	SessionManagementConfig,
+	ApiKeyConfig,
+	PreAuthConfig,
+	MfaConfig,
+	LoginAttemptConfig,
+	SmsConfig,
+	PasswordEncoderConfig,
+	UserCacheConfig,
+	ClientDetailsConfig,
 )
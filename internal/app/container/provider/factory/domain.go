@@ -6,5 +6,7 @@ import "github.com/ingot-cloud/ingot-go/internal/app/model/domain"
 func getDomain() []interface{} {
 	return []interface{}{
 		new(domain.SysUser),
+		new(domain.SysPersistentLogins),
+		new(domain.SysApiKey),
 	}
 }
@@ -0,0 +1,27 @@
+package factory
+
+import (
+	"github.com/ingot-cloud/ingot-go/internal/app/config"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
+)
+
+// NewRedis redis 客户端
+func NewRedis(config *config.Config) (*store.RedisClient, func(), error) {
+	redisConfig := config.Redis
+	client := store.NewRedisClient(&store.RedisParams{
+		Address:   redisConfig.Address,
+		DB:        redisConfig.DB,
+		Password:  redisConfig.Password,
+		KeyPrefix: redisConfig.KeyPrefix,
+		SSL:       redisConfig.SSL,
+	})
+
+	cleanFunc := func() {
+		err := client.Cli.Close()
+		if err != nil {
+			log.Errorf("Redis client close error: %s", err.Error())
+		}
+	}
+	return client, cleanFunc, nil
+}
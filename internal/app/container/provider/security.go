@@ -6,19 +6,31 @@ import (
 	"github.com/ingot-cloud/ingot-go/internal/app/core/security/config"
 	"github.com/ingot-cloud/ingot-go/internal/app/core/security/service"
 	"github.com/ingot-cloud/ingot-go/internal/app/core/security/token"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/domain"
+	"github.com/ingot-cloud/ingot-go/pkg/component/id"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/container"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/container/di"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/container/security/provider"
-	"github.com/ingot-cloud/ingot-go/pkg/framework/core/utils/pathmatcher"
 	securityAuth "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
-	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	coreApiKey "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/apikey"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/event"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/mfa"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/sms"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/social"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/authentication"
+	oauth2Config "github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/config"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/configurer"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
 	oauthToken "github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token/store"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/apikey"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/cors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/headers"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/preauth"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/rememberme"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/utils"
+	"gorm.io/gorm"
 )
 
 // SecurityInjector 注入器
@@ -26,8 +38,16 @@ var SecurityInjector = wire.NewSet(
 	wire.Struct(new(config.IngotContainerInjector), "*"),
 	wire.Bind(new(container.ContainerInjector), new(*config.IngotContainerInjector)),
 
+	ClientDetailsStore,
 	SecurityClientDetailsService,
 	SecurityUserDetailsService,
+	SecurityRememberMeTokenRepository,
+	SecurityApiKeyService,
+	SecurityMfaService,
+	SecurityUserDetailsPasswordService,
+	SecuritySmsUserDetailsService,
+	SecuritySmsPhoneNormalizer,
+	SecuritySocialUserDetailsService,
 	ResourceServerAdapter,
 	PermitURLMatcher,
 	IngotEnhancerChain,
@@ -39,38 +59,52 @@ var SecurityInjector = wire.NewSet(
 // SecurityClientDetailsService 服务实现
 var SecurityClientDetailsService = wire.Struct(new(service.ClientDetails), "*")
 
+// ClientDetailsStore 从 sys_oauth_client_details 加载客户端，DI 容器重建的客户端服务共用该实例的缓存
+func ClientDetailsStore(db *gorm.DB, generator id.Generator, config clientdetails.Config) *clientdetails.GormService {
+	return clientdetails.NewGormService(db, new(domain.SysOauthClientDetails).TableName(), generator, config)
+}
+
 // SecurityUserDetailsService 服务实现
 var SecurityUserDetailsService = wire.Struct(new(service.UserDetails), "*")
 
+// SecurityRememberMeTokenRepository 记住我 token 保存在数据库中
+var SecurityRememberMeTokenRepository = wire.Struct(new(service.RememberMeTokenRepository), "*")
+
+// SecurityApiKeyService API key 保存在数据库中
+var SecurityApiKeyService = wire.Struct(new(service.ApiKeyService), "*")
+
+// SecurityMfaService 多因素认证信息保存在用户表中
+var SecurityMfaService = wire.Struct(new(service.MfaService), "*")
+
+// SecurityUserDetailsPasswordService 升级后的密码保存在用户表中
+var SecurityUserDetailsPasswordService = wire.Struct(new(service.UserDetailsPasswordService), "*")
+
+// SecuritySmsUserDetailsService 根据手机号从用户表加载用户
+var SecuritySmsUserDetailsService = wire.Struct(new(service.SmsUserDetails), "*")
+
+// SecuritySmsPhoneNormalizer 手机号规范化规则与用户查找一致
+var SecuritySmsPhoneNormalizer = wire.Struct(new(service.SmsPhoneNormalizer), "*")
+
+// SecuritySocialUserDetailsService 根据第三方账号绑定关系加载用户
+var SecuritySocialUserDetailsService = wire.Struct(new(service.SocialUserDetails), "*")
+
 // IngotUserAuthenticationConverter 自定义
 var IngotUserAuthenticationConverter = wire.Struct(new(token.IngotUserAuthenticationConverter), "*")
 
 // IngotEnhancerChain token 增强
-func IngotEnhancerChain(jwt *store.JwtAccessTokenConverter) *token.IngotEnhancerChain {
-	return token.NewIngotEnhancerChain(jwt)
+func IngotEnhancerChain(oauth2Config oauth2Config.OAuth2, jwt *store.JwtAccessTokenConverter) *token.IngotEnhancerChain {
+	return token.NewIngotEnhancerChain(oauth2Config, jwt)
 }
 
 // ResourceServerAdapter 自定义适配器
-func ResourceServerAdapter(tokenExtractor authentication.TokenExtractor, resourceManager securityAuth.ResourceManager, ignore utils.RequestMatcher) *config.ResourceServerAdapter {
-	parent := configurer.NewResourceServerConfigurer(tokenExtractor, resourceManager)
+func ResourceServerAdapter(tokenExtractor authentication.TokenExtractor, resourceManager securityAuth.ResourceManager, corsSource cors.ConfigurationSource, headersConfig headers.Config, apiKeyConfigurer *apikey.SecurityConfigurer, preAuthConfigurer *preauth.SecurityConfigurer, publisher event.Publisher, ignore utils.RequestMatcher) *config.ResourceServerAdapter {
+	parent := configurer.NewResourceServerConfigurer(tokenExtractor, resourceManager, corsSource, headersConfig, apiKeyConfigurer, preAuthConfigurer, publisher)
 	return config.NewResourceServerAdapter(parent, ignore)
 }
 
 // PermitURLMatcher 忽略请求匹配器
 func PermitURLMatcher(securityConfig appConfig.Security) utils.RequestMatcher {
-	pathMatcher := pathmatcher.NewAntPathMatcher()
-	return func(ctx *ingot.Context) bool {
-		permitURLs := securityConfig.PermitURLs
-		requestURL := ctx.Request.RequestURI
-
-		for _, pattern := range permitURLs {
-			if !pathMatcher.Match(pattern, requestURL) {
-				return false
-			}
-		}
-
-		return true
-	}
+	return utils.NewAntPathRequestMatchers(securityConfig.PermitURLs...)
 }
 
 func DIProviderSet() *di.ProviderSet {
@@ -81,6 +115,20 @@ func DIProviderSet() *di.ProviderSet {
 		di.Bind(new(userdetails.Service), new(service.UserDetails)),
 		di.Struct(new(service.ClientDetails)),
 		di.Bind(new(clientdetails.Service), new(service.ClientDetails)),
+		di.Struct(new(service.RememberMeTokenRepository)),
+		di.Bind(new(rememberme.TokenRepository), new(service.RememberMeTokenRepository)),
+		di.Struct(new(service.ApiKeyService)),
+		di.Bind(new(coreApiKey.Service), new(service.ApiKeyService)),
+		di.Struct(new(service.MfaService)),
+		di.Bind(new(mfa.Service), new(service.MfaService)),
+		di.Struct(new(service.UserDetailsPasswordService)),
+		di.Bind(new(userdetails.PasswordService), new(service.UserDetailsPasswordService)),
+		di.Struct(new(service.SmsUserDetails)),
+		di.Bind(new(sms.UserDetailsService), new(service.SmsUserDetails)),
+		di.Struct(new(service.SmsPhoneNormalizer)),
+		di.Bind(new(sms.PhoneNormalizer), new(service.SmsPhoneNormalizer)),
+		di.Struct(new(service.SocialUserDetails)),
+		di.Bind(new(social.UserDetailsService), new(service.SocialUserDetails)),
 		di.Struct(new(token.IngotUserAuthenticationConverter)),
 		di.Bind(new(oauthToken.UserAuthenticationConverter), new(token.IngotUserAuthenticationConverter)),
 		di.Func(ResourceServerAdapter),
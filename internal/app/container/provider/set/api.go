@@ -18,4 +18,6 @@ var APISet = wire.NewSet(
 	apiConfigSet,
 
 	wire.Struct(new(api.Test), "*"),
+	wire.Struct(new(api.Mfa), "*"),
+	wire.Struct(new(api.Social), "*"),
 )
@@ -14,4 +14,8 @@ var DaoSet = wire.NewSet(
 	wire.Struct(new(dao.RoleUser), "*"),
 	wire.Struct(new(dao.RoleAuthority), "*"),
 	wire.Struct(new(dao.OauthClientDetails), "*"),
+	wire.Struct(new(dao.PersistentLogins), "*"),
+	wire.Struct(new(dao.ApiKey), "*"),
+	wire.Struct(new(dao.SocialDetails), "*"),
+	wire.Struct(new(dao.UserSocial), "*"),
 )
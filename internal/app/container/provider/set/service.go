@@ -24,4 +24,10 @@ var ServiceSet = wire.NewSet(
 
 	wire.Struct(new(impl.OAuth2Client), "*"),
 	wire.Bind(new(service.OAuth2Client), new(*impl.OAuth2Client)),
+
+	wire.Struct(new(impl.Mfa), "*"),
+	wire.Bind(new(service.Mfa), new(*impl.Mfa)),
+
+	wire.Struct(new(impl.Social), "*"),
+	wire.Bind(new(service.Social), new(*impl.Social)),
 )
@@ -14,7 +14,9 @@ type APIConfig struct {
 	CasbinEnforcer *casbin.SyncedEnforcer
 	SecurityConfig config.Security
 
-	TestAPI *api.Test
+	TestAPI   *api.Test
+	MfaAPI    *api.Mfa
+	SocialAPI *api.Social
 }
 
 // Configure 应用配置
@@ -26,5 +28,5 @@ func (c *APIConfig) Configure(app *ingot.Router) {
 
 // GetAPI 获取API
 func (c *APIConfig) GetAPI() coreApi.Configurers {
-	return coreApi.Configurers{c.TestAPI}
+	return coreApi.Configurers{c.TestAPI, c.MfaAPI, c.SocialAPI}
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/ingot-cloud/ingot-go/internal/app/model/dao"
 	"github.com/ingot-cloud/ingot-go/internal/app/service"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/container"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/utils"
 )
 
@@ -15,13 +16,24 @@ type IngotContainerInjector struct {
 
 	// 此处注入的实例可以通过GetValue方法获取
 	OauthClientDetailsDao *dao.OauthClientDetails
+	PersistentLoginsDao   *dao.PersistentLogins
+	ApiKeyDao             *dao.ApiKey
 	UserDetailService     service.UserDetail
+	ClientDetailsStore    *clientdetails.GormService
+	SocialService         service.Social
 	Ignore                utils.RequestMatcher
 
 	// inject 代表自定义替换默认容器中的实例
-	ClientDetailsService             *securityService.ClientDetails             `inject:"true"`
-	UserDetailsService               *securityService.UserDetails               `inject:"true"`
-	ResourceServerAdapter            *ResourceServerAdapter                     `inject:"true"`
-	IngotEnhancerChain               *appToken.IngotEnhancerChain               `inject:"true"`
-	IngotUserAuthenticationConverter *appToken.IngotUserAuthenticationConverter `inject:"true"`
+	ClientDetailsService             *securityService.ClientDetails              `inject:"true"`
+	UserDetailsService               *securityService.UserDetails                `inject:"true"`
+	ResourceServerAdapter            *ResourceServerAdapter                      `inject:"true"`
+	IngotEnhancerChain               *appToken.IngotEnhancerChain                `inject:"true"`
+	IngotUserAuthenticationConverter *appToken.IngotUserAuthenticationConverter  `inject:"true"`
+	RememberMeTokenRepository        *securityService.RememberMeTokenRepository  `inject:"true"`
+	ApiKeyService                    *securityService.ApiKeyService              `inject:"true"`
+	MfaService                       *securityService.MfaService                 `inject:"true"`
+	UserDetailsPasswordService       *securityService.UserDetailsPasswordService `inject:"true"`
+	SmsUserDetailsService            *securityService.SmsUserDetails             `inject:"true"`
+	SmsPhoneNormalizer               *securityService.SmsPhoneNormalizer         `inject:"true"`
+	SocialUserDetailsService         *securityService.SocialUserDetails          `inject:"true"`
 }
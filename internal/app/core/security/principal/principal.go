@@ -0,0 +1,71 @@
+package principal
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ingot-cloud/ingot-go/internal/app/model/enums"
+)
+
+// ParameterType 登录请求中指定用户标识类型的参数，不指定时自动识别
+const ParameterType = "principal_type"
+
+var (
+	// 手机号中常见的分隔符
+	phoneSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+	// 大陆手机号或带国际区号的手机号
+	phonePattern = regexp.MustCompile(`^(1\d{10}|\+\d{6,15})$`)
+)
+
+// Resolve 确定用户标识类型，指定的类型无效时自动识别，第二个返回值表示是否为自动识别
+func Resolve(code string, requested string) (enums.PrincipalTypeEnum, bool) {
+	switch t := enums.PrincipalTypeEnum(requested); t {
+	case enums.PrincipalUsername, enums.PrincipalPhone, enums.PrincipalEmail:
+		return t, false
+	}
+	return Detect(code), true
+}
+
+// Detect 根据用户标识的格式识别类型
+func Detect(code string) enums.PrincipalTypeEnum {
+	if strings.Contains(code, "@") {
+		return enums.PrincipalEmail
+	}
+	if phonePattern.MatchString(NormalizePhone(code)) {
+		return enums.PrincipalPhone
+	}
+	return enums.PrincipalUsername
+}
+
+// Normalize 按类型规范化用户标识，用户名保持不变
+func Normalize(principalType enums.PrincipalTypeEnum, code string) string {
+	switch principalType {
+	case enums.PrincipalPhone:
+		return NormalizePhone(code)
+	case enums.PrincipalEmail:
+		return NormalizeEmail(code)
+	}
+	return code
+}
+
+// NormalizePhone 去除分隔符，大陆手机号去除 +86、0086、86 前缀
+func NormalizePhone(phone string) string {
+	phone = phoneSeparators.Replace(strings.TrimSpace(phone))
+	switch {
+	case strings.HasPrefix(phone, "+86"):
+		phone = phone[3:]
+	case strings.HasPrefix(phone, "0086"):
+		phone = phone[4:]
+	case len(phone) == 13 && strings.HasPrefix(phone, "86"):
+		phone = phone[2:]
+	}
+	if strings.HasPrefix(phone, "00") {
+		phone = "+" + phone[2:]
+	}
+	return phone
+}
+
+// NormalizeEmail 邮箱不区分大小写
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
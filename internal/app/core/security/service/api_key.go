@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/internal/app/model/dao"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/enums"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/apikey"
+)
+
+// ApiKeyService API key 保存在数据库中
+type ApiKeyService struct {
+	ApiKeyDao *dao.ApiKey
+}
+
+// LoadByPrefix 根据前缀加载 key
+func (s *ApiKeyService) LoadByPrefix(prefix string) (*apikey.Details, error) {
+	key, err := s.ApiKeyDao.GetByPrefix(context.TODO(), prefix)
+	if err != nil || key == nil {
+		return nil, err
+	}
+
+	details := &apikey.Details{
+		ID:        key.ID.String(),
+		Prefix:    key.Prefix,
+		HashedKey: key.HashedKey,
+		Principal: key.Username,
+		Enabled:   key.Status == string(enums.ApiKeyStatusEnable),
+	}
+	if key.Scopes != "" {
+		details.Scopes = strings.Split(key.Scopes, ",")
+	}
+	if key.ExpiresAt != nil {
+		details.ExpiresAt = *key.ExpiresAt
+	}
+	return details, nil
+}
+
+// UpdateLastUsed 更新最后使用时间
+func (s *ApiKeyService) UpdateLastUsed(id string, lastUsed time.Time) error {
+	return s.ApiKeyDao.UpdateLastUsed(context.TODO(), id, lastUsed)
+}
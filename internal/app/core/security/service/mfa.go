@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/internal/app/core/security/user"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/dao"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/domain"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/mfa"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+
+	"gorm.io/gorm"
+)
+
+// MfaService 多因素认证信息保存在用户表中，使用用户ID标识用户
+type MfaService struct {
+	UserDao *dao.User
+}
+
+// IsRequired 用户是否开启了多因素认证
+func (s *MfaService) IsRequired(details userdetails.UserDetails) (string, bool, error) {
+	ingotUser, ok := details.(*user.IngotUser)
+	if !ok {
+		return "", false, nil
+	}
+	subject := ingotUser.ID.String()
+	current, err := s.getUser(subject)
+	if err != nil || current == nil {
+		return "", false, err
+	}
+	return subject, current.MfaEnabled && current.MfaSecret != "", nil
+}
+
+// Verify 校验动态码，动态码错误时尝试恢复码，动态码和恢复码使用后失效
+func (s *MfaService) Verify(subject string, code string) (bool, error) {
+	current, err := s.getUser(subject)
+	if err != nil || current == nil {
+		return false, err
+	}
+	if !current.MfaEnabled {
+		return false, nil
+	}
+	if step, ok := mfa.MatchCode(current.MfaSecret, code, time.Now()); ok {
+		return s.UserDao.UseMfaStep(context.TODO(), subject, step)
+	}
+
+	var hashed []string
+	if current.MfaRecoveryCodes != "" {
+		hashed = strings.Split(current.MfaRecoveryCodes, ",")
+	}
+	index := mfa.MatchRecoveryCode(code, hashed)
+	if index < 0 {
+		return false, nil
+	}
+	hashed = append(hashed[:index], hashed[index+1:]...)
+	err = s.UserDao.UpdateMfa(context.TODO(), subject, true, current.MfaSecret, strings.Join(hashed, ","))
+	return err == nil, err
+}
+
+func (s *MfaService) getUser(id string) (*domain.SysUser, error) {
+	current, err := s.UserDao.GetByID(context.TODO(), id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return current, err
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ingot-cloud/ingot-go/internal/app/core/security/user"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/dao"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+)
+
+// UserDetailsPasswordService 升级后的密码保存在用户表中
+type UserDetailsPasswordService struct {
+	UserDao *dao.User
+}
+
+// UpdatePassword 根据用户ID保存新编码的密码，返回更新后的用户，
+// 无法确定用户ID时不更新
+func (s *UserDetailsPasswordService) UpdatePassword(details userdetails.UserDetails, newPassword string) (userdetails.UserDetails, error) {
+	ingotUser, ok := details.(*user.IngotUser)
+	if !ok || ingotUser.User == nil {
+		return details, nil
+	}
+	err := s.UserDao.UpdatePassword(context.TODO(), ingotUser.ID.String(), newPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	coreUser := *ingotUser.User
+	coreUser.Password = newPassword
+	return user.NewIngotUser(ingotUser.ID, ingotUser.DeptID, ingotUser.TenantID, ingotUser.AuthType, &coreUser), nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/internal/app/model/dao"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/domain"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/rememberme"
+)
+
+// RememberMeTokenRepository 记住我 token 保存在数据库中
+type RememberMeTokenRepository struct {
+	PersistentLoginsDao *dao.PersistentLogins
+}
+
+// CreateNewToken 保存新的 token
+func (r *RememberMeTokenRepository) CreateNewToken(token *rememberme.PersistentToken) error {
+	return r.PersistentLoginsDao.Create(context.TODO(), &domain.SysPersistentLogins{
+		Series:   token.Series,
+		Username: token.Username,
+		TenantID: int(types.NewIDFromString(token.TenantID)),
+		Token:    token.Token,
+		LastUsed: token.LastUsed,
+	})
+}
+
+// UpdateToken 更新 token
+func (r *RememberMeTokenRepository) UpdateToken(series, tokenValue string, lastUsed time.Time) error {
+	return r.PersistentLoginsDao.UpdateToken(context.TODO(), series, tokenValue, lastUsed)
+}
+
+// GetTokenForSeries 获取 token
+func (r *RememberMeTokenRepository) GetTokenForSeries(series string) (*rememberme.PersistentToken, error) {
+	token, err := r.PersistentLoginsDao.GetBySeries(context.TODO(), series)
+	if err != nil || token == nil {
+		return nil, err
+	}
+	var tenantID string
+	if token.TenantID != 0 {
+		tenantID = types.NewIDFrom(token.TenantID).String()
+	}
+	return &rememberme.PersistentToken{
+		Series:   token.Series,
+		Username: token.Username,
+		TenantID: tenantID,
+		Token:    token.Token,
+		LastUsed: token.LastUsed,
+	}, nil
+}
+
+// RemoveSeries 删除 series
+func (r *RememberMeTokenRepository) RemoveSeries(series string) error {
+	return r.PersistentLoginsDao.DeleteBySeries(context.TODO(), series)
+}
+
+// RemoveUserTokens 删除指定租户中用户的所有 token
+func (r *RememberMeTokenRepository) RemoveUserTokens(username, tenantID string) error {
+	return r.PersistentLoginsDao.DeleteByUsername(context.TODO(), int(types.NewIDFromString(tenantID)), username)
+}
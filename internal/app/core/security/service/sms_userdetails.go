@@ -0,0 +1,40 @@
+package service
+
+import (
+	"github.com/ingot-cloud/ingot-go/internal/app/core/security/principal"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/dto"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/enums"
+	"github.com/ingot-cloud/ingot-go/internal/app/service"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+)
+
+// SmsPhoneNormalizer 验证码与用户查找使用相同的手机号规范化规则
+type SmsPhoneNormalizer struct{}
+
+// Normalize 去除分隔符和大陆区号
+func (*SmsPhoneNormalizer) Normalize(phone string) string {
+	return principal.NormalizePhone(phone)
+}
+
+// SmsUserDetails 短信验证码登录时根据手机号加载用户
+type SmsUserDetails struct {
+	UserDetailService service.UserDetail
+}
+
+// LoadUserByPhone 根据手机号加载用户，租户的确定方式与用户名登录一致
+func (s *SmsUserDetails) LoadUserByPhone(phone string, details interface{}) (userdetails.UserDetails, error) {
+	clientID, tenantID, _ := resolveDetails(details)
+	params := dto.UserDetailsDto{
+		ClientID:      clientID,
+		TenantID:      tenantID,
+		Mode:          enums.Sms,
+		UniqueCode:    principal.NormalizePhone(phone),
+		PrincipalType: enums.PrincipalPhone,
+	}
+	authDetails, err := s.UserDetailService.GetUserAuthDetails(types.NewIDFromString(tenantID), params)
+	if err != nil {
+		return nil, err
+	}
+	return newIngotUser(authDetails), nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ingot-cloud/ingot-go/internal/app/model/dao"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/dto"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/enums"
+	"github.com/ingot-cloud/ingot-go/internal/app/service"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+
+	"gorm.io/gorm"
+)
+
+// SocialUserDetails 第三方登录时加载绑定的用户
+type SocialUserDetails struct {
+	UserDetailService     service.UserDetail
+	SocialService         service.Social
+	OauthClientDetailsDao *dao.OauthClientDetails
+}
+
+// LoadUserBySocial 根据授权码加载绑定的用户，未指定租户时使用客户端所属租户
+func (s *SocialUserDetails) LoadUserBySocial(socialType, code string, details interface{}) (userdetails.UserDetails, error) {
+	clientID, tenantID, _ := resolveDetails(details)
+	tenant := types.NewIDFromString(tenantID)
+	if tenant == types.Zero && clientID != "" {
+		client, err := s.OauthClientDetailsDao.GetByClientID(context.TODO(), clientID, types.Zero)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if err == nil {
+			tenant = types.NewIDFrom(client.TenantID)
+		}
+	}
+
+	binding, err := s.SocialService.GetBinding(tenant, socialType, code)
+	if err != nil || binding == nil {
+		return nil, err
+	}
+	params := dto.UserDetailsDto{
+		ClientID:   clientID,
+		TenantID:   tenantID,
+		Mode:       enums.Social,
+		UniqueCode: binding.UserID.String(),
+	}
+	authDetails, err := s.UserDetailService.GetUserAuthDetails(types.NewIDFrom(binding.TenantID), params)
+	if err != nil {
+		return nil, err
+	}
+	return newIngotUser(authDetails), nil
+}
@@ -1,29 +1,106 @@
 package service
 
 import (
+	"context"
+
+	"github.com/ingot-cloud/ingot-go/internal/app/core/security/principal"
 	"github.com/ingot-cloud/ingot-go/internal/app/core/security/user"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/dao"
 	"github.com/ingot-cloud/ingot-go/internal/app/model/dto"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/enums"
 	"github.com/ingot-cloud/ingot-go/internal/app/service"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/authority"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/constants"
 )
 
 // UserDetails 服务
 type UserDetails struct {
-	UserDetailService service.UserDetail
+	UserDetailService     service.UserDetail
+	OauthClientDetailsDao *dao.OauthClientDetails
 }
 
 // LoadUserByUsername 加载指定 username 的用户
 func (u *UserDetails) LoadUserByUsername(username string) (userdetails.UserDetails, error) {
+	return u.LoadUserByUsernameAndDetails(username, nil)
+}
 
-	// TODO 临时测试
+// LoadUserByUsernameAndDetails 加载指定 username 的用户，租户优先使用请求头中指定的租户，其次使用客户端所属租户，
+// username 可以是用户名、手机号或邮箱
+func (u *UserDetails) LoadUserByUsernameAndDetails(username string, details interface{}) (userdetails.UserDetails, error) {
+	clientID, tenantID, requestedType := resolveDetails(details)
+	principalType, detected := principal.Resolve(username, requestedType)
 	params := dto.UserDetailsDto{
-		UniqueCode: username,
+		ClientID:      clientID,
+		TenantID:      tenantID,
+		Mode:          enums.Password,
+		UniqueCode:    principal.Normalize(principalType, username),
+		PrincipalType: principalType,
 	}
-	details, err := u.UserDetailService.GetUserAuthDetails(1, params)
+	authDetails, err := u.UserDetailService.GetUserAuthDetails(types.NewIDFromString(tenantID), params)
 	if err != nil {
 		return nil, err
 	}
-	coreUser := userdetails.NewUserAllParams(username, details.Password, authority.CreateAuthorityList(details.Roles), true, true, true, true)
-	return user.NewIngotUser(details.ID, details.DeptID, details.TenantID, details.AuthType, coreUser), nil
+	// 自动识别为手机号或邮箱但未找到用户时，按用户名查找
+	if authDetails == nil && detected && principalType != enums.PrincipalUsername {
+		params.UniqueCode = username
+		params.PrincipalType = enums.PrincipalUsername
+		authDetails, err = u.UserDetailService.GetUserAuthDetails(types.NewIDFromString(tenantID), params)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newIngotUser(authDetails), nil
+}
+
+func newIngotUser(authDetails *dto.UserAuthDetails) userdetails.UserDetails {
+	if authDetails == nil {
+		return nil
+	}
+
+	nonLocked := authDetails.Status != enums.UserStatusLock
+	enabled := authDetails.Status == enums.UserStatusEnable || authDetails.Status == enums.UserStatusLock
+	coreUser := userdetails.NewUserAllParams(authDetails.Username, authDetails.Password, authority.CreateAuthorityList(authDetails.Roles), true, nonLocked, true, enabled)
+	return user.NewIngotUser(authDetails.ID, authDetails.DeptID, authDetails.TenantID, authDetails.AuthType, coreUser)
+}
+
+// MatchesDetails 缓存中的用户包含租户和客户端的授权类型，与当前请求不一致时重新加载
+func (u *UserDetails) MatchesDetails(details userdetails.UserDetails, authDetails interface{}) bool {
+	ingotUser, ok := details.(*user.IngotUser)
+	if !ok {
+		return true
+	}
+	clientID, tenantID, requestedType := resolveDetails(authDetails)
+	// 缓存以用户名为 key，使用手机号或邮箱登录时不能使用缓存
+	if principalType, _ := principal.Resolve(ingotUser.GetUsername(), requestedType); principalType != enums.PrincipalUsername {
+		return false
+	}
+	if tenantID != "" && ingotUser.TenantID != types.NewIDFromString(tenantID) {
+		return false
+	}
+	if clientID == "" {
+		return true
+	}
+	// 请求头指定的租户中不存在该客户端时重新加载，由加载用户时拒绝
+	client, err := u.OauthClientDetailsDao.GetByClientID(context.TODO(), clientID, types.NewIDFromString(tenantID))
+	if err != nil {
+		return false
+	}
+	if tenantID == "" && ingotUser.TenantID != types.NewIDFrom(client.TenantID) {
+		return false
+	}
+	return client.AuthType == "" || client.AuthType == ingotUser.AuthType
+}
+
+// 从认证请求的附加信息中获取客户端ID、请求头中指定的租户ID和用户标识类型
+func resolveDetails(details interface{}) (string, string, string) {
+	switch d := details.(type) {
+	case map[string]string:
+		return d[constants.ClientID], d[authentication.ParameterTenantID], d[principal.ParameterType]
+	case *authentication.WebAuthenticationDetails:
+		return "", d.GetTenantID(), ""
+	}
+	return "", "", ""
 }
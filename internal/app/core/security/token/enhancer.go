@@ -2,8 +2,10 @@ package token
 
 import (
 	"github.com/ingot-cloud/ingot-go/internal/app/core/security/user"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/config"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/constants"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/mtls"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token/store"
 )
@@ -12,10 +14,13 @@ type IngotEnhancerChain struct {
 	*token.EnhancerChain
 }
 
-func NewIngotEnhancerChain(jwt *store.JwtAccessTokenConverter) *IngotEnhancerChain {
+func NewIngotEnhancerChain(config config.OAuth2, jwt *store.JwtAccessTokenConverter) *IngotEnhancerChain {
 	chain := token.NewEnhancerChain()
 	var enhancers []token.Enhancer
 	enhancers = append(enhancers, &IngotEnhancer{})
+	if config.AuthorizationServer.CertificateBoundAccessTokens {
+		enhancers = append(enhancers, mtls.NewCertificateBoundEnhancer())
+	}
 	// 默认追加 jwt enhancer
 	enhancers = append(enhancers, jwt)
 	chain.SetTokenEnhancers(enhancers)
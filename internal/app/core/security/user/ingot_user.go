@@ -4,8 +4,17 @@ import (
 	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails/cache"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/session"
 )
 
+func init() {
+	// 表单登录使用 cookie 或 redis 保存会话时需要注册
+	session.RegisterType(&IngotUser{})
+	// 使用用户缓存时需要注册
+	cache.RegisterType(&IngotUser{})
+}
+
 // IngotUser 自定义User
 type IngotUser struct {
 	*userdetails.User
@@ -16,6 +25,19 @@ type IngotUser struct {
 	AuthType string
 }
 
+// GetUserID 用户ID，登录失败次数按用户ID记录
+func (u *IngotUser) GetUserID() string {
+	return u.ID.String()
+}
+
+// GetTenantID 用户所属租户，刷新令牌等场景在该租户中重新加载用户
+func (u *IngotUser) GetTenantID() string {
+	if u.TenantID == types.Zero {
+		return ""
+	}
+	return u.TenantID.String()
+}
+
 // NewIngotUser 实例化
 func NewIngotUser(id, deptID, tenantID types.ID, authType string, user *userdetails.User) *IngotUser {
 	return &IngotUser{
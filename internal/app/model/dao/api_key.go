@@ -0,0 +1,34 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/internal/app/model/domain"
+
+	"gorm.io/gorm"
+)
+
+func getApiKeyDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, db, new(domain.SysApiKey))
+}
+
+// ApiKey Dao
+type ApiKey struct {
+	DB *gorm.DB
+}
+
+// GetByPrefix 根据前缀获取，不存在时返回 nil
+func (a *ApiKey) GetByPrefix(ctx context.Context, prefix string) (*domain.SysApiKey, error) {
+	var list []*domain.SysApiKey
+	err := getApiKeyDB(ctx, a.DB).Where("prefix = ?", prefix).Limit(1).Find(&list).Error
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return list[0], nil
+}
+
+// UpdateLastUsed 更新最后使用时间
+func (a *ApiKey) UpdateLastUsed(ctx context.Context, id string, lastUsed time.Time) error {
+	return getApiKeyDB(ctx, a.DB).Where("id = ?", id).Update("last_used_at", lastUsed).Error
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/ingot-cloud/ingot-go/internal/app/model/domain"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+
 	"gorm.io/gorm"
 )
 
@@ -16,12 +18,20 @@ type OauthClientDetails struct {
 	DB *gorm.DB
 }
 
-// GetByID 根据ID获取Client信息
-func (c *OauthClientDetails) GetByID(context context.Context, clientID string) (*domain.SysOauthClientDetails, error) {
-	db := getOauthClientDetailsDB(context, c.DB).Where("client_id = ?", clientID)
-
-	client := new(domain.SysOauthClientDetails)
-	err := db.First(client).Error
+// GetByClientID 获取指定租户中的Client信息，租户为空时查询所有租户，
+// 客户端ID在多个租户中存在时返回 gorm.ErrRecordNotFound
+func (c *OauthClientDetails) GetByClientID(ctx context.Context, clientID string, tenantID types.ID) (*domain.SysOauthClientDetails, error) {
+	db := getOauthClientDetailsDB(ctx, c.DB).Where("client_id = ?", clientID)
+	if tenantID != types.Zero {
+		db = db.Where("tenant_id = ?", tenantID)
+	}
 
-	return client, err
+	var list []*domain.SysOauthClientDetails
+	if err := db.Limit(2).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	if len(list) != 1 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return list[0], nil
 }
@@ -0,0 +1,52 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/internal/app/model/domain"
+
+	"gorm.io/gorm"
+)
+
+func getPersistentLoginsDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, db, new(domain.SysPersistentLogins))
+}
+
+// PersistentLogins Dao
+type PersistentLogins struct {
+	DB *gorm.DB
+}
+
+// Create 创建
+func (p *PersistentLogins) Create(ctx context.Context, token *domain.SysPersistentLogins) error {
+	return GetDB(ctx, p.DB).Create(token).Error
+}
+
+// UpdateToken 更新 series 对应的 token
+func (p *PersistentLogins) UpdateToken(ctx context.Context, series, token string, lastUsed time.Time) error {
+	return getPersistentLoginsDB(ctx, p.DB).Where("series = ?", series).Updates(map[string]interface{}{
+		"token":     token,
+		"last_used": lastUsed,
+	}).Error
+}
+
+// GetBySeries 根据 series 获取，不存在时返回 nil
+func (p *PersistentLogins) GetBySeries(ctx context.Context, series string) (*domain.SysPersistentLogins, error) {
+	var list []*domain.SysPersistentLogins
+	err := getPersistentLoginsDB(ctx, p.DB).Where("series = ?", series).Limit(1).Find(&list).Error
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return list[0], nil
+}
+
+// DeleteBySeries 根据 series 删除
+func (p *PersistentLogins) DeleteBySeries(ctx context.Context, series string) error {
+	return GetDB(ctx, p.DB).Where("series = ?", series).Delete(new(domain.SysPersistentLogins)).Error
+}
+
+// DeleteByUsername 删除指定租户中用户的所有 token
+func (p *PersistentLogins) DeleteByUsername(ctx context.Context, tenantID int, username string) error {
+	return GetDB(ctx, p.DB).Where("username = ? AND tenant_id = ?", username, tenantID).Delete(new(domain.SysPersistentLogins)).Error
+}
@@ -0,0 +1,32 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/ingot-cloud/ingot-go/internal/app/model/domain"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/enums"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+
+	"gorm.io/gorm"
+)
+
+func getSocialDetailsDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, db, new(domain.SysSocialDetails))
+}
+
+// SocialDetails Dao
+type SocialDetails struct {
+	DB *gorm.DB
+}
+
+// ListByType 获取指定租户中可用的第三方平台配置，租户为空时查询所有租户
+func (s *SocialDetails) ListByType(ctx context.Context, tenantID types.ID, socialType string) ([]*domain.SysSocialDetails, error) {
+	db := getSocialDetailsDB(ctx, s.DB).Where("type = ? AND status = ? AND deleted_at IS NULL", socialType, enums.StatusEnabled)
+	if tenantID != types.Zero {
+		db = db.Where("tenant_id = ?", tenantID)
+	}
+
+	var list []*domain.SysSocialDetails
+	err := db.Find(&list).Error
+	return list, err
+}
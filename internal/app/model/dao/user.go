@@ -2,9 +2,11 @@ package dao
 
 import (
 	"context"
+	"strings"
 
 	"github.com/ingot-cloud/ingot-go/internal/app/model/domain"
 	"github.com/ingot-cloud/ingot-go/internal/app/model/dto"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
 
 	"gorm.io/gorm"
@@ -57,3 +59,57 @@ func (u *User) One(ctx context.Context, username string) (*domain.SysUser, error
 
 	return &user, err
 }
+
+// ListByUsername 获取指定租户中的用户，租户为空时查询所有租户
+func (u *User) ListByUsername(ctx context.Context, tenantID types.ID, username string) ([]*domain.SysUser, error) {
+	return u.listOfTenant(getUserDB(ctx, u.DB).Where("username = ?", username), tenantID)
+}
+
+// ListByPhone 根据手机号获取指定租户中的用户，租户为空时查询所有租户
+func (u *User) ListByPhone(ctx context.Context, tenantID types.ID, phone string) ([]*domain.SysUser, error) {
+	return u.listOfTenant(getUserDB(ctx, u.DB).Where("phone = ?", phone), tenantID)
+}
+
+// ListByEmail 根据邮箱获取指定租户中的用户，邮箱不区分大小写，租户为空时查询所有租户
+func (u *User) ListByEmail(ctx context.Context, tenantID types.ID, email string) ([]*domain.SysUser, error) {
+	return u.listOfTenant(getUserDB(ctx, u.DB).Where("LOWER(email) = ?", strings.ToLower(email)), tenantID)
+}
+
+// ListByID 根据ID获取指定租户中的用户，租户为空时查询所有租户
+func (u *User) ListByID(ctx context.Context, tenantID types.ID, id string) ([]*domain.SysUser, error) {
+	return u.listOfTenant(getUserDB(ctx, u.DB).Where("id = ?", id), tenantID)
+}
+
+func (u *User) listOfTenant(db *gorm.DB, tenantID types.ID) ([]*domain.SysUser, error) {
+	if tenantID != types.Zero {
+		db = db.Where("tenant_id = ?", tenantID)
+	}
+
+	var list []*domain.SysUser
+	err := db.Scan(&list).Error
+
+	return list, err
+}
+
+// UpdateMfa 更新多因素认证信息
+func (u *User) UpdateMfa(ctx context.Context, id string, enabled bool, secret, recoveryCodes string) error {
+	db := getUserDB(ctx, u.DB)
+	return db.Where("id = ?", id).Updates(map[string]interface{}{
+		"mfa_enabled":        enabled,
+		"mfa_secret":         secret,
+		"mfa_recovery_codes": recoveryCodes,
+	}).Error
+}
+
+// UseMfaStep 使用动态码的时间步长，只有大于最后使用的时间步长时更新成功，返回是否更新成功
+func (u *User) UseMfaStep(ctx context.Context, id string, step int64) (bool, error) {
+	db := getUserDB(ctx, u.DB)
+	result := db.Where("id = ? AND mfa_last_step < ?", id, step).Update("mfa_last_step", step)
+	return result.RowsAffected == 1, result.Error
+}
+
+// UpdatePassword 根据用户ID更新密码，用户名在不同租户中可能重复
+func (u *User) UpdatePassword(ctx context.Context, id string, password string) error {
+	db := getUserDB(ctx, u.DB)
+	return db.Where("id = ?", id).Update("password", password).Error
+}
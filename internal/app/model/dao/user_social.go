@@ -0,0 +1,50 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/ingot-cloud/ingot-go/internal/app/model/domain"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+
+	"gorm.io/gorm"
+)
+
+func getUserSocialDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, db, new(domain.SysUserSocial))
+}
+
+// UserSocial Dao
+type UserSocial struct {
+	DB *gorm.DB
+}
+
+// GetByUniqueID 根据第三方账号获取绑定信息，不存在时返回 nil
+func (u *UserSocial) GetByUniqueID(ctx context.Context, tenantID int64, socialType, uniqueID string) (*domain.SysUserSocial, error) {
+	db := getUserSocialDB(ctx, u.DB).Where("tenant_id = ? AND type = ? AND unique_id = ?", tenantID, socialType, uniqueID)
+	return u.first(db)
+}
+
+// GetByUserID 获取用户绑定的指定类型的第三方账号，不存在时返回 nil
+func (u *UserSocial) GetByUserID(ctx context.Context, userID types.ID, socialType string) (*domain.SysUserSocial, error) {
+	db := getUserSocialDB(ctx, u.DB).Where("user_id = ? AND type = ?", userID, socialType)
+	return u.first(db)
+}
+
+// Create 创建绑定
+func (u *UserSocial) Create(ctx context.Context, binding *domain.SysUserSocial) error {
+	return GetDB(ctx, u.DB).Create(binding).Error
+}
+
+// DeleteByUserID 解除用户绑定的指定类型的第三方账号
+func (u *UserSocial) DeleteByUserID(ctx context.Context, userID types.ID, socialType string) error {
+	return GetDB(ctx, u.DB).Where("user_id = ? AND type = ?", userID, socialType).Delete(new(domain.SysUserSocial)).Error
+}
+
+func (u *UserSocial) first(db *gorm.DB) (*domain.SysUserSocial, error) {
+	var list []*domain.SysUserSocial
+	err := db.Limit(1).Find(&list).Error
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return list[0], nil
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+)
+
+// SysApiKey API key，只保存 key 的摘要
+type SysApiKey struct {
+	ID         types.ID `gorm:"primary_key;size:20"`
+	Name       string
+	Prefix     string `gorm:"size:32;uniqueIndex"`
+	HashedKey  string
+	Username   string
+	Scopes     string
+	Status     string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// TableName 表名
+func (*SysApiKey) TableName() string {
+	return "sys_api_key"
+}
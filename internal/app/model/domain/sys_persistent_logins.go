@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// SysPersistentLogins 记住我持久化 token
+type SysPersistentLogins struct {
+	Series   string `gorm:"primary_key;size:64"`
+	Username string `gorm:"size:64;index"`
+	TenantID int
+	Token    string `gorm:"size:64"`
+	LastUsed time.Time
+}
+
+// TableName 表名
+func (*SysPersistentLogins) TableName() string {
+	return "sys_persistent_logins"
+}
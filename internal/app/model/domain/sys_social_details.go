@@ -13,6 +13,8 @@ type SysSocialDetails struct {
 	AppID       string
 	AppSecret   string
 	RedirectURL string
+	TokenURL    string
+	UserInfoURL string
 	Name        string
 	Type        string
 	Status      string
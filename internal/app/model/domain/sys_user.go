@@ -4,18 +4,24 @@ import "github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
 
 // SysUser 用户表
 type SysUser struct {
-	ID        types.ID `gorm:"primary_key;size:20"`
-	TenantID  int
-	DeptID    int64
-	Username  string
-	Password  string
-	RealName  string
-	Phone     string
-	Email     string
-	Status    string
-	CreatedAt string
-	UpdatedAt string
-	DeletedAt string
+	ID       types.ID `gorm:"primary_key;size:20"`
+	TenantID int
+	DeptID   int64
+	Username string
+	Password string
+	RealName string
+	Phone    string
+	Email    string
+	Status   string
+	// 多因素认证，恢复码只保存摘要，逗号分隔
+	MfaEnabled       bool
+	MfaSecret        string
+	MfaRecoveryCodes string
+	// 最后使用的动态码时间步长，不大于该值的动态码不能再次使用
+	MfaLastStep int64
+	CreatedAt   string
+	UpdatedAt   string
+	DeletedAt   string
 }
 
 // TableName 表名
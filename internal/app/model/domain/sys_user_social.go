@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+)
+
+// SysUserSocial 用户绑定的第三方账号
+type SysUserSocial struct {
+	ID       types.ID `gorm:"primary_key;size:20"`
+	TenantID int64
+	UserID   types.ID
+	Type     string
+	// 第三方平台中用户的唯一标识
+	UniqueID  string
+	Name      string
+	CreatedAt time.Time
+}
+
+// TableName 表名
+func (*SysUserSocial) TableName() string {
+	return "sys_user_social"
+}
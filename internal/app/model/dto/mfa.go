@@ -0,0 +1,18 @@
+package dto
+
+// MfaSecret 新生成的 TOTP 密钥
+type MfaSecret struct {
+	Secret string `json:"secret"`
+	// otpauth URI，用于生成二维码
+	KeyURI string `json:"keyUri"`
+}
+
+// MfaCode 动态码参数
+type MfaCode struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// MfaRecoveryCodes 恢复码，只在生成时返回一次
+type MfaRecoveryCodes struct {
+	Codes []string `json:"codes"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// SocialBind 绑定第三方账号参数
+type SocialBind struct {
+	Type string `json:"type" binding:"required"`
+	Code string `json:"code" binding:"required"`
+}
+
+// SocialUnbind 解除绑定第三方账号参数
+type SocialUnbind struct {
+	Type string `json:"type" binding:"required"`
+}
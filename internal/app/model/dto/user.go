@@ -11,6 +11,8 @@ type UserDetailsDto struct {
 	TenantID   string
 	Mode       enums.UserDetailsModeEnum
 	UniqueCode string
+	// 用户标识类型，为空时根据 UniqueCode 自动识别
+	PrincipalType enums.PrincipalTypeEnum
 }
 
 // UserAuthDetails 详情
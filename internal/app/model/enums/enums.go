@@ -17,6 +17,17 @@ type UserDetailsModeEnum string
 const (
 	Password UserDetailsModeEnum = "password"
 	Social   UserDetailsModeEnum = "social"
+	Sms      UserDetailsModeEnum = "sms"
+)
+
+// PrincipalTypeEnum 登录时用户标识的类型
+type PrincipalTypeEnum string
+
+// 用户标识类型
+const (
+	PrincipalUsername PrincipalTypeEnum = "username"
+	PrincipalPhone    PrincipalTypeEnum = "phone"
+	PrincipalEmail    PrincipalTypeEnum = "email"
 )
 
 // UserStatusEnum 用户状态
@@ -29,3 +40,14 @@ const (
 	// 锁定
 	UserStatusLock UserStatusEnum = "9"
 )
+
+// ApiKeyStatusEnum API key 状态
+type ApiKeyStatusEnum string
+
+// API key 状态
+const (
+	// 可用
+	ApiKeyStatusEnable ApiKeyStatusEnum = "0"
+	// 禁用
+	ApiKeyStatusDisable ApiKeyStatusEnum = "9"
+)
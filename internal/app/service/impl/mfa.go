@@ -0,0 +1,131 @@
+package impl
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/internal/app/model/dao"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/domain"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/dto"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/mfa"
+)
+
+// Mfa 服务实现
+type Mfa struct {
+	UserDao   *dao.User
+	MfaConfig mfa.Config
+}
+
+// GenerateSecret 生成新的密钥，开启前需要使用动态码确认
+func (m *Mfa) GenerateSecret(userID types.ID) (*dto.MfaSecret, error) {
+	user, err := m.UserDao.GetByID(context.TODO(), userID.String())
+	if err != nil {
+		return nil, err
+	}
+	if user.MfaEnabled {
+		return nil, errors.IllegalOperation("Multi-factor authentication is already enabled")
+	}
+
+	secret, err := mfa.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := m.UserDao.UpdateMfa(context.TODO(), userID.String(), false, secret, ""); err != nil {
+		return nil, err
+	}
+	return &dto.MfaSecret{
+		Secret: secret,
+		KeyURI: mfa.KeyURI(m.issuer(), user.Username, secret),
+	}, nil
+}
+
+// Enable 校验动态码并开启多因素认证，返回恢复码
+func (m *Mfa) Enable(userID types.ID, code string) (*dto.MfaRecoveryCodes, error) {
+	user, err := m.UserDao.GetByID(context.TODO(), userID.String())
+	if err != nil {
+		return nil, err
+	}
+	if user.MfaEnabled {
+		return nil, errors.IllegalOperation("Multi-factor authentication is already enabled")
+	}
+	if user.MfaSecret == "" {
+		return nil, errors.IllegalOperation("Generate a secret before enabling multi-factor authentication")
+	}
+	if err := m.checkCode(user, code); err != nil {
+		return nil, err
+	}
+	return m.saveRecoveryCodes(user)
+}
+
+// Disable 校验动态码并关闭多因素认证
+func (m *Mfa) Disable(userID types.ID, code string) error {
+	user, err := m.UserDao.GetByID(context.TODO(), userID.String())
+	if err != nil {
+		return err
+	}
+	if !user.MfaEnabled {
+		return errors.IllegalOperation("Multi-factor authentication is not enabled")
+	}
+	if err := m.checkCode(user, code); err != nil {
+		return err
+	}
+	return m.UserDao.UpdateMfa(context.TODO(), userID.String(), false, "", "")
+}
+
+// RegenerateRecoveryCodes 校验动态码并重新生成恢复码
+func (m *Mfa) RegenerateRecoveryCodes(userID types.ID, code string) (*dto.MfaRecoveryCodes, error) {
+	user, err := m.UserDao.GetByID(context.TODO(), userID.String())
+	if err != nil {
+		return nil, err
+	}
+	if !user.MfaEnabled {
+		return nil, errors.IllegalOperation("Multi-factor authentication is not enabled")
+	}
+	if err := m.checkCode(user, code); err != nil {
+		return nil, err
+	}
+	return m.saveRecoveryCodes(user)
+}
+
+func (m *Mfa) checkCode(user *domain.SysUser, code string) error {
+	step, ok := mfa.MatchCode(user.MfaSecret, code, time.Now())
+	if !ok {
+		return errors.IllegalArgument("Invalid one-time password")
+	}
+	used, err := m.UserDao.UseMfaStep(context.TODO(), user.ID.String(), step)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return errors.IllegalArgument("One-time password has already been used")
+	}
+	return nil
+}
+
+func (m *Mfa) saveRecoveryCodes(user *domain.SysUser) (*dto.MfaRecoveryCodes, error) {
+	codes, err := mfa.GenerateRecoveryCodes(mfa.RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+	hashed := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashed = append(hashed, mfa.HashRecoveryCode(code))
+	}
+	err = m.UserDao.UpdateMfa(context.TODO(), user.ID.String(), true, user.MfaSecret, strings.Join(hashed, ","))
+	if err != nil {
+		return nil, err
+	}
+	return &dto.MfaRecoveryCodes{
+		Codes: codes,
+	}, nil
+}
+
+func (m *Mfa) issuer() string {
+	if m.MfaConfig.Issuer == "" {
+		return "Ingot"
+	}
+	return m.MfaConfig.Issuer
+}
@@ -0,0 +1,109 @@
+package impl
+
+import (
+	"context"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/internal/app/model/dao"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/domain"
+	"github.com/ingot-cloud/ingot-go/pkg/component/id"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/social"
+	securityErrors "github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
+)
+
+// Social 服务实现，第三方平台配置保存在 sys_social_details 中，绑定关系保存在 sys_user_social 中
+type Social struct {
+	SocialDetailsDao *dao.SocialDetails
+	UserSocialDao    *dao.UserSocial
+	UserDao          *dao.User
+	IDGenerator      id.Generator
+}
+
+// GetBinding 使用授权码获取第三方账号的绑定信息
+func (s *Social) GetBinding(tenantID types.ID, socialType, code string) (*domain.SysUserSocial, error) {
+	ctx := context.TODO()
+	details, info, err := s.exchange(ctx, tenantID, socialType, code)
+	if err != nil {
+		return nil, err
+	}
+	return s.UserSocialDao.GetByUniqueID(ctx, details.TenantID, socialType, info.ID)
+}
+
+// Bind 使用授权码将第三方账号绑定到指定用户，每种第三方平台只能绑定一个账号
+func (s *Social) Bind(userID types.ID, socialType, code string) error {
+	ctx := context.TODO()
+	user, err := s.UserDao.GetByID(ctx, userID.String())
+	if err != nil {
+		return err
+	}
+	details, info, err := s.exchange(ctx, types.NewIDFrom(user.TenantID), socialType, code)
+	if err != nil {
+		return err
+	}
+
+	bound, err := s.UserSocialDao.GetByUniqueID(ctx, details.TenantID, socialType, info.ID)
+	if err != nil {
+		return err
+	}
+	if bound != nil {
+		if bound.UserID == userID {
+			return nil
+		}
+		return errors.IllegalOperation("The " + socialType + " account is already bound to another user")
+	}
+	current, err := s.UserSocialDao.GetByUserID(ctx, userID, socialType)
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		return errors.IllegalOperation("A " + socialType + " account is already bound, unbind it first")
+	}
+
+	bindingID, err := s.IDGenerator.NextID()
+	if err != nil {
+		return err
+	}
+	return s.UserSocialDao.Create(ctx, &domain.SysUserSocial{
+		ID:        types.ID(bindingID),
+		TenantID:  details.TenantID,
+		UserID:    userID,
+		Type:      socialType,
+		UniqueID:  info.ID,
+		Name:      info.Name,
+		CreatedAt: time.Now(),
+	})
+}
+
+// Unbind 解除用户绑定的指定类型的第三方账号
+func (s *Social) Unbind(userID types.ID, socialType string) error {
+	return s.UserSocialDao.DeleteByUserID(context.TODO(), userID, socialType)
+}
+
+// 根据第三方平台配置使用授权码换取第三方用户信息
+func (s *Social) exchange(ctx context.Context, tenantID types.ID, socialType, code string) (*domain.SysSocialDetails, *social.UserInfo, error) {
+	list, err := s.SocialDetailsDao.ListByType(ctx, tenantID, socialType)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 无法确定租户时，第三方平台在多个租户中配置视为不支持
+	if len(list) != 1 {
+		return nil, nil, securityErrors.SocialAuthFailed("Unsupported social type: ", socialType)
+	}
+	details := list[0]
+
+	provider := social.NewProvider(social.Config{
+		Type:         details.Type,
+		ClientID:     details.AppID,
+		ClientSecret: details.AppSecret,
+		RedirectURL:  details.RedirectURL,
+		TokenURL:     details.TokenURL,
+		UserInfoURL:  details.UserInfoURL,
+	})
+	info, err := provider.Exchange(code)
+	if err != nil {
+		return nil, nil, err
+	}
+	return details, info, nil
+}
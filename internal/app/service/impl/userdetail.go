@@ -1,28 +1,115 @@
 package impl
 
 import (
+	"context"
+	"errors"
+
+	"github.com/ingot-cloud/ingot-go/internal/app/model/dao"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/domain"
 	"github.com/ingot-cloud/ingot-go/internal/app/model/dto"
 	"github.com/ingot-cloud/ingot-go/internal/app/model/enums"
+	coreEnums "github.com/ingot-cloud/ingot-go/pkg/framework/core/model/enums"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+	securityErrors "github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
+
+	"gorm.io/gorm"
 )
 
 // UserDetail 服务实现
 type UserDetail struct {
+	UserDao               *dao.User
+	RoleUserDao           *dao.RoleUser
+	RoleDao               *dao.Role
+	OauthClientDetailsDao *dao.OauthClientDetails
 }
 
-// GetUserAuthDetails 获取用户详情信息
+// GetUserAuthDetails 获取用户详情信息，未指定租户时使用客户端所属租户
 func (u *UserDetail) GetUserAuthDetails(tenantID types.ID, params dto.UserDetailsDto) (*dto.UserAuthDetails, error) {
-	// todo 查询该用户所有角色，查询授权应用，以及对应的 authType
+	ctx := context.TODO()
+
+	authType := coreEnums.AuthTypeStandard
+	if params.ClientID != "" {
+		// 客户端ID只在租户内唯一，在请求头指定的租户中查找客户端
+		client, err := u.OauthClientDetailsDao.GetByClientID(ctx, params.ClientID, tenantID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if err != nil && tenantID != types.Zero {
+			// 请求头指定的租户中不存在该客户端
+			return nil, securityErrors.BadCredentials("Tenant ID does not match the client")
+		}
+		if err == nil {
+			if tenantID == types.Zero {
+				tenantID = types.NewIDFrom(client.TenantID)
+			}
+			if client.AuthType != "" {
+				authType = client.AuthType
+			}
+		}
+	}
+
+	users, err := u.listUsers(ctx, tenantID, params)
+	if err != nil {
+		return nil, err
+	}
+	// 无法确定租户时，用户名在多个租户中存在视为用户不存在
+	if len(users) != 1 {
+		return nil, nil
+	}
+	user := users[0]
+
+	roles, err := u.getRoleCodes(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
 
-	// TODO 目前临时返回固定测试值
 	return &dto.UserAuthDetails{
-		ID:       1,
-		DeptID:   1,
-		TenantID: 1,
-		Username: params.UniqueCode,
-		Password: "{noop}" + params.UniqueCode,
-		Status:   enums.UserStatusEnable,
-		AuthType: "standard",
-		Roles:    []string{"admin"},
+		ID:       user.ID,
+		DeptID:   types.NewIDFrom(user.DeptID),
+		TenantID: types.NewIDFrom(user.TenantID),
+		Username: user.Username,
+		Password: user.Password,
+		Status:   enums.UserStatusEnum(user.Status),
+		AuthType: authType,
+		Roles:    roles,
 	}, nil
 }
+
+// 根据登录模式和用户标识类型查找用户
+func (u *UserDetail) listUsers(ctx context.Context, tenantID types.ID, params dto.UserDetailsDto) ([]*domain.SysUser, error) {
+	// 第三方登录时 UniqueCode 为绑定的用户ID
+	if params.Mode == enums.Social {
+		return u.UserDao.ListByID(ctx, tenantID, params.UniqueCode)
+	}
+	switch params.PrincipalType {
+	case enums.PrincipalPhone:
+		return u.UserDao.ListByPhone(ctx, tenantID, params.UniqueCode)
+	case enums.PrincipalEmail:
+		return u.UserDao.ListByEmail(ctx, tenantID, params.UniqueCode)
+	}
+	return u.UserDao.ListByUsername(ctx, tenantID, params.UniqueCode)
+}
+
+// 获取用户所有可用角色编码
+func (u *UserDetail) getRoleCodes(ctx context.Context, userID types.ID) ([]string, error) {
+	roleIDs, err := u.RoleUserDao.GetUserRoleIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(*roleIDs) == 0 {
+		return []string{}, nil
+	}
+
+	list, err := u.RoleDao.List(ctx, dto.QueryCondition{
+		IDs:    *roleIDs,
+		Status: coreEnums.StatusEnabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]string, 0, len(*list))
+	for _, role := range *list {
+		roles = append(roles, role.Code)
+	}
+	return roles, nil
+}
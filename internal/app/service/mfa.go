@@ -0,0 +1,18 @@
+package service
+
+import (
+	"github.com/ingot-cloud/ingot-go/internal/app/model/dto"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+)
+
+// Mfa 多因素认证注册服务
+type Mfa interface {
+	// 生成新的密钥，开启前需要使用动态码确认
+	GenerateSecret(userID types.ID) (*dto.MfaSecret, error)
+	// 校验动态码并开启多因素认证，返回恢复码
+	Enable(userID types.ID, code string) (*dto.MfaRecoveryCodes, error)
+	// 校验动态码并关闭多因素认证
+	Disable(userID types.ID, code string) error
+	// 校验动态码并重新生成恢复码
+	RegenerateRecoveryCodes(userID types.ID, code string) (*dto.MfaRecoveryCodes, error)
+}
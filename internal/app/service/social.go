@@ -0,0 +1,16 @@
+package service
+
+import (
+	"github.com/ingot-cloud/ingot-go/internal/app/model/domain"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+)
+
+// Social 第三方账号绑定服务
+type Social interface {
+	// 使用授权码获取第三方账号的绑定信息，租户为空时要求第三方平台配置唯一，未绑定时返回 nil
+	GetBinding(tenantID types.ID, socialType, code string) (*domain.SysUserSocial, error)
+	// 使用授权码将第三方账号绑定到指定用户
+	Bind(userID types.ID, socialType, code string) error
+	// 解除用户绑定的指定类型的第三方账号
+	Unbind(userID types.ID, socialType string) error
+}
@@ -9,4 +9,16 @@ type HTTPConfig struct {
 	ReadTimeout  time.Duration `yaml:"readTimeout"`
 	WriteTimeout time.Duration `yaml:"writeTimeout"`
 	Prefix       string        `yaml:"prefix"`
+	TLS          TLSConfig     `yaml:"tls"`
+}
+
+// TLSConfig HTTPS 配置
+type TLSConfig struct {
+	Enable   bool   `yaml:"enable"`
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+	// 用于验证客户端证书的 CA，PEM 格式
+	ClientCAFile string `yaml:"clientCAFile"`
+	// 客户端证书策略，none、request、require_any、verify_if_given、require_and_verify
+	ClientAuth string `yaml:"clientAuth"`
 }
@@ -33,7 +33,7 @@ func (app *IngotApplication) Run() error {
 }
 
 func (app *IngotApplication) listeningSignal(doExit func()) {
-	quit := make(chan os.Signal)
+	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt)
 	<-quit
 	log.WithContext(app.Context).Info("Server exiting")
@@ -67,9 +67,25 @@ func (server *HTTPServer) runHTTPServer(handler http.Handler) func() {
 		WriteTimeout: server.Config.WriteTimeout * time.Second,
 	}
 
+	tlsEnabled := server.Config.TLS.Enable
+	if tlsEnabled {
+		tlsConfig, err := newTLSConfig(server.Config.TLS)
+		if err != nil {
+			log.WithContext(server.Context).Fatalf("tls: %s\n", err)
+			panic(err)
+		}
+		httpServer.TLSConfig = tlsConfig
+	}
+
 	go func() {
-		log.WithContext(server.Context).Infof("=== HTTP server started successfully, address=%s ===", httpServer.Addr)
-		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.WithContext(server.Context).Infof("=== HTTP server started successfully, address=%s, tls=%t ===", httpServer.Addr, tlsEnabled)
+		var err error
+		if tlsEnabled {
+			err = httpServer.ListenAndServeTLS(server.Config.TLS.CertFile, server.Config.TLS.KeyFile)
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			log.WithContext(server.Context).Fatalf("listen: %s\n", err)
 			panic(err)
 		}
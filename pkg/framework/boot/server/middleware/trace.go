@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/utils/uuid"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+)
+
+// TraceIDHeader 跟踪ID请求头
+const TraceIDHeader = "X-Request-Id"
+
+// TraceMiddleware 为每个请求设置跟踪ID，优先使用请求头中的跟踪ID
+func TraceMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		traceID := c.GetHeader(TraceIDHeader)
+		if traceID == "" || len(traceID) > 128 {
+			traceID = uuid.MustString()
+		}
+		c.Request = c.Request.WithContext(log.NewTraceIDContext(c.Request.Context(), traceID))
+		c.Header(TraceIDHeader, traceID)
+		c.Next()
+	}
+}
@@ -10,6 +10,7 @@ import (
 func enableDefaultMiddleware(engine *gin.Engine) {
 	engine.NoMethod(middleware.NoMethodHandler())
 	engine.NoRoute(middleware.NoRouteHandler())
+	engine.Use(middleware.TraceMiddleware())
 	engine.Use(middleware.RecoveryMiddleware())
 }
 
@@ -3,9 +3,22 @@ package container
 import (
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
 	coreAuth "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/apikey"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/basic"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/dao"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/preauth"
+	smsProvider "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/sms"
+	socialProvider "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/social"
+	coreApiKey "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/apikey"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/attempt"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/event"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/mfa"
+	coreSession "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/session"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/sms"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/social"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails/cache"
+	cryptoFactory "github.com/ingot-cloud/ingot-go/pkg/framework/security/crypto/factory"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/crypto/password"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/authentication"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/config"
@@ -14,17 +27,53 @@ import (
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token/granter"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token/store"
+	apiKeyConfigurer "github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/apikey"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/cors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/csrf"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/formlogin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/headers"
+	preAuthConfigurer "github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/preauth"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/rememberme"
+	securityFilter "github.com/ingot-cloud/ingot-go/pkg/framework/security/web/filter"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/session"
+	redisStore "github.com/ingot-cloud/ingot-go/pkg/framework/store"
 )
 
 // CommonContainer 容器
 type CommonContainer struct {
-	WebSecurityConfigurers security.WebSecurityConfigurers
-	PasswordEncoder        password.Encoder
-	UserCache              userdetails.UserCache
-	PreChecker             userdetails.PreChecker
-	PostChecker            userdetails.PostChecker
-	UserDetailsService     userdetails.Service
-	ClientDetailsService   clientdetails.Service
+	WebSecurityConfigurers       security.WebSecurityConfigurers
+	PasswordEncoderConfig        cryptoFactory.Config
+	PasswordEncoder              password.Encoder
+	UserCacheConfig              cache.Config
+	UserCache                    userdetails.UserCache
+	UserCacheEvictor             *cache.Evictor
+	PreChecker                   userdetails.PreChecker
+	PostChecker                  userdetails.PostChecker
+	UserDetailsService           userdetails.Service
+	UserDetailsPasswordService   userdetails.PasswordService
+	ClientDetailsConfig          clientdetails.Config
+	ClientDetailsService         clientdetails.Service
+	ApiKeyService                coreApiKey.Service
+	CorsSource                   cors.ConfigurationSource
+	HeadersConfig                headers.Config
+	FilterChainDebugConfig       securityFilter.DebugConfig
+	RedisClient                  *redisStore.RedisClient
+	SessionManagementConfig      coreSession.Config
+	SessionRegistry              coreSession.Registry
+	ConcurrentSessionControl     *coreSession.ConcurrentControl
+	MfaConfig                    mfa.Config
+	MfaService                   mfa.Service
+	MfaTokenStore                mfa.Store
+	LoginAttemptConfig           attempt.Config
+	LoginAttemptTracker          *attempt.Tracker
+	SmsConfig                    sms.Config
+	SmsSender                    sms.Sender
+	SmsPhoneNormalizer           sms.PhoneNormalizer
+	SmsCodeStore                 sms.Store
+	SmsCodeService               *sms.CodeService
+	SmsUserDetailsService        sms.UserDetailsService
+	SocialUserDetailsService     social.UserDetailsService
+	AuthenticationEventPublisher event.Publisher
 }
 
 // OAuth2Container OAuth2 容器
@@ -42,6 +91,10 @@ type ResourceServerContainer struct {
 	ResourceServerConfigurer    security.ResourceServerConfigurer
 	ResourceServerTokenServices token.ResourceServerTokenServices
 	TokenExtractor              authentication.TokenExtractor
+	ApiKeyConfig                apiKeyConfigurer.Config
+	ApiKeyConfigurer            *apiKeyConfigurer.SecurityConfigurer
+	PreAuthConfig               preAuthConfigurer.Config
+	PreAuthConfigurer           *preAuthConfigurer.SecurityConfigurer
 }
 
 // AuthorizationServerContainer 授权服务器容器
@@ -55,6 +108,12 @@ type AuthorizationServerContainer struct {
 	TokenEnhancer                    token.Enhancer
 	TokenGranter                     token.Granter
 	PasswordTokenGranter             *granter.PasswordTokenGranter
+	MfaTokenGranter                  *granter.MfaTokenGranter
+	SmsTokenGranter                  *granter.SmsTokenGranter
+	SocialTokenGranter               *granter.SocialTokenGranter
+	SmsCodeEndpoint                  *endpoint.SmsCodeEndpoint
+	ClientRegistrationEndpoint       *endpoint.ClientRegistrationEndpoint
+	MfaManager                       *mfa.Manager
 }
 
 // AuthProvidersContainer 认证提供者容器
@@ -62,4 +121,22 @@ type AuthProvidersContainer struct {
 	Providers coreAuth.Providers
 	Basic     *basic.AuthenticationProvider
 	Dao       *dao.AuthenticationProvider
+	ApiKey    *apikey.AuthenticationProvider
+	PreAuth   *preauth.AuthenticationProvider
+	Sms       *smsProvider.AuthenticationProvider
+	Social    *socialProvider.AuthenticationProvider
+}
+
+// WebContainer Web 表单登录容器
+type WebContainer struct {
+	FormLoginConfig           formlogin.Config
+	SessionStore              session.Store
+	CsrfConfig                csrf.Config
+	CsrfTokenRepository       csrf.TokenRepository
+	CsrfConfigurer            *csrf.SecurityConfigurer
+	RememberMeConfig          rememberme.Config
+	RememberMeTokenRepository rememberme.TokenRepository
+	RememberMeServices        rememberme.Services
+	RememberMeConfigurer      *rememberme.SecurityConfigurer
+	FormLoginConfigurer       security.FormLoginConfigurer
 }
@@ -7,6 +7,7 @@ type SecurityContainerImpl struct {
 	ResourceServerContainer      *ResourceServerContainer      `container:"true"`
 	AuthorizationServerContainer *AuthorizationServerContainer `container:"true"`
 	AuthProvidersContainer       *AuthProvidersContainer       `container:"true"`
+	WebContainer                 *WebContainer                 `container:"true"`
 }
 
 // GetCommonContainer 获取容器
@@ -33,3 +34,8 @@ func (s *SecurityContainerImpl) GetAuthorizationServerContainer() *Authorization
 func (s *SecurityContainerImpl) GetAuthProvidersContainer() *AuthProvidersContainer {
 	return s.AuthProvidersContainer
 }
+
+// GetWebContainer 获取容器
+func (s *SecurityContainerImpl) GetWebContainer() *WebContainer {
+	return s.WebContainer
+}
@@ -3,16 +3,27 @@ package provider
 import (
 	securityContainer "github.com/ingot-cloud/ingot-go/pkg/framework/container/security"
 	coreAuth "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/apikey"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/basic"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/dao"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/preauth"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/sms"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/social"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails/cache"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
 )
 
 // ProvidersImpl 接口实现
 type ProvidersImpl struct {
 	providers []coreAuth.Provider
 
-	Basic *basic.AuthenticationProvider
-	Dao   *dao.AuthenticationProvider
+	Basic   *basic.AuthenticationProvider
+	Dao     *dao.AuthenticationProvider
+	ApiKey  *apikey.AuthenticationProvider
+	PreAuth *preauth.AuthenticationProvider
+	Sms     *sms.AuthenticationProvider
+	Social  *social.AuthenticationProvider
 }
 
 // Add 追加provider
@@ -22,17 +33,47 @@ func (p *ProvidersImpl) Add(item coreAuth.Provider) {
 
 // Get 获取所有Provider
 func (p *ProvidersImpl) Get() []coreAuth.Provider {
-	p.providers = append(p.providers, p.Basic)
-	p.providers = append(p.providers, p.Dao)
-	return p.providers
+	providers := make([]coreAuth.Provider, 0, len(p.providers)+6)
+	providers = append(providers, p.providers...)
+	return append(providers, p.Basic, p.Dao, p.ApiKey, p.PreAuth, p.Sms, p.Social)
 }
 
 // DaoAuthenticationProvider UsernamePasswordAuthenticationToken 认证提供者
 func DaoAuthenticationProvider(common *securityContainer.CommonContainer) *dao.AuthenticationProvider {
-	return dao.NewProvider(common.PasswordEncoder, common.UserDetailsService, common.UserCache, common.PreChecker, common.PostChecker)
+	provider := dao.NewProvider(common.PasswordEncoder, common.UserDetailsService, common.UserCache, common.PreChecker, common.PostChecker, common.LoginAttemptTracker)
+	if _, ok := common.UserDetailsPasswordService.(*userdetails.NilPasswordService); !ok {
+		provider.UserDetailsPasswordService = common.UserDetailsPasswordService
+	}
+	return provider
 }
 
-// BasicAuthenticationProvider 认证提供者，其中注入了 ClientDetailsUserDetailsService
+// BasicAuthenticationProvider 认证提供者，其中注入了 ClientDetailsUserDetailsService，
+// 客户端 ID 和用户名可能相同，不能与用户共用缓存
 func BasicAuthenticationProvider(common *securityContainer.CommonContainer) *basic.AuthenticationProvider {
-	return basic.NewProvider(common.PasswordEncoder, common.ClientDetailsService, common.UserCache, common.PreChecker, common.PostChecker)
+	provider := basic.NewProvider(common.PasswordEncoder, common.ClientDetailsService, cache.NewNilUserCache(), common.PreChecker, common.PostChecker)
+	// 客户端服务支持保存密钥时，升级旧编码方式保存的密钥
+	if secretService, ok := common.ClientDetailsService.(clientdetails.SecretService); ok {
+		provider.UserDetailsPasswordService = clientdetails.NewPasswordService(secretService)
+	}
+	return provider
+}
+
+// ApiKeyAuthenticationProvider ApiKeyAuthenticationToken 认证提供者
+func ApiKeyAuthenticationProvider(common *securityContainer.CommonContainer) *apikey.AuthenticationProvider {
+	return apikey.NewProvider(common.ApiKeyService)
+}
+
+// PreAuthAuthenticationProvider 预身份验证提供者，根据已验证的主体加载用户
+func PreAuthAuthenticationProvider(common *securityContainer.CommonContainer) *preauth.AuthenticationProvider {
+	return preauth.NewProvider(common.UserDetailsService, common.PreChecker)
+}
+
+// SmsAuthenticationProvider SmsCodeAuthenticationToken 认证提供者
+func SmsAuthenticationProvider(common *securityContainer.CommonContainer) *sms.AuthenticationProvider {
+	return sms.NewProvider(common.SmsCodeService, common.SmsUserDetailsService, common.PreChecker, common.PostChecker)
+}
+
+// SocialAuthenticationProvider SocialAuthenticationToken 认证提供者
+func SocialAuthenticationProvider(common *securityContainer.CommonContainer) *social.AuthenticationProvider {
+	return social.NewProvider(common.SocialUserDetailsService, common.PreChecker, common.PostChecker)
 }
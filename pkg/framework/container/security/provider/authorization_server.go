@@ -4,22 +4,28 @@ import (
 	securityContainer "github.com/ingot-cloud/ingot-go/pkg/framework/container/security"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/mfa"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/config"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/configurer"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/configurer/tlsclient"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/endpoint"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/mtls"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token/granter"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/cors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/headers"
 )
 
 // AuthorizationAuthenticationManager 授权服务器中的认证管理器
-func AuthorizationAuthenticationManager(pc *securityContainer.AuthProvidersContainer) authentication.AuthorizationManager {
-	return authentication.NewProviderManager(pc.Providers)
+func AuthorizationAuthenticationManager(pc *securityContainer.AuthProvidersContainer, common *securityContainer.CommonContainer) authentication.AuthorizationManager {
+	return authentication.NewProviderManager(pc.Providers, common.AuthenticationEventPublisher)
 }
 
 // AuthorizationServerConfigurer 授权服务器配置
-func AuthorizationServerConfigurer(manager authentication.AuthorizationManager) security.AuthorizationServerConfigurer {
-	return configurer.NewAuthorizationServerConfigurer(manager)
+func AuthorizationServerConfigurer(config config.OAuth2, manager authentication.AuthorizationManager, corsSource cors.ConfigurationSource, headersConfig headers.Config, common *securityContainer.CommonContainer) security.AuthorizationServerConfigurer {
+	tlsClientConfigurer := tlsclient.NewSecurityConfigurer(config.AuthorizationServer.TLSClientAuth, common.ClientDetailsService)
+	return configurer.NewAuthorizationServerConfigurer(manager, corsSource, headersConfig, tlsClientConfigurer)
 }
 
 // AuthorizationServerTokenServices 授权服务器 token 服务
@@ -34,12 +40,17 @@ func AuthorizationServerTokenServices(config config.OAuth2, tokenStore token.Sto
 	}
 	tokenServices.TokenEnhancer = enhancer
 	tokenServices.AuthenticationManager = manager
+	tokenServices.ConcurrentControl = common.ConcurrentSessionControl
+	tokenServices.EventPublisher = common.AuthenticationEventPublisher
 	return tokenServices
 }
 
 // ConsumerTokenServices 令牌撤销
-func ConsumerTokenServices(tokenStore token.Store) token.ConsumerTokenServices {
-	return token.NewDefaultTokenServices(tokenStore)
+func ConsumerTokenServices(tokenStore token.Store, common *securityContainer.CommonContainer) token.ConsumerTokenServices {
+	tokenServices := token.NewDefaultTokenServices(tokenStore)
+	tokenServices.ConcurrentControl = common.ConcurrentSessionControl
+	tokenServices.EventPublisher = common.AuthenticationEventPublisher
+	return tokenServices
 }
 
 // TokenEndpoint 端点
@@ -48,14 +59,29 @@ func TokenEndpoint(granter token.Granter, common *securityContainer.CommonContai
 }
 
 // TokenEndpointHTTPConfigurer 端点配置
-func TokenEndpointHTTPConfigurer(tokenEndpoint *endpoint.TokenEndpoint) endpoint.OAuth2HTTPConfigurer {
-	return endpoint.NewOAuth2ApiConfig(tokenEndpoint)
+func TokenEndpointHTTPConfigurer(tokenEndpoint *endpoint.TokenEndpoint, smsCodeEndpoint *endpoint.SmsCodeEndpoint, registrationEndpoint *endpoint.ClientRegistrationEndpoint) endpoint.OAuth2HTTPConfigurer {
+	return endpoint.NewOAuth2ApiConfig(tokenEndpoint, smsCodeEndpoint, registrationEndpoint)
+}
+
+// SmsCodeEndpoint 短信验证码端点
+func SmsCodeEndpoint(common *securityContainer.CommonContainer) *endpoint.SmsCodeEndpoint {
+	return endpoint.NewSmsCodeEndpoint(common.SmsCodeService, common.ClientDetailsService)
+}
+
+// ClientRegistrationEndpoint 动态注册客户端端点，客户端服务不支持保存客户端时不可用
+func ClientRegistrationEndpoint(config config.OAuth2, common *securityContainer.CommonContainer) *endpoint.ClientRegistrationEndpoint {
+	registrationService, _ := common.ClientDetailsService.(clientdetails.RegistrationService)
+	return endpoint.NewClientRegistrationEndpoint(config.AuthorizationServer.Registration, registrationService, common.PasswordEncoder)
 }
 
 // TokenEnhancer token增强，默认使用增强链
-func TokenEnhancer(oauth2Container *securityContainer.OAuth2Container) token.Enhancer {
+func TokenEnhancer(config config.OAuth2, oauth2Container *securityContainer.OAuth2Container) token.Enhancer {
 	chain := token.NewEnhancerChain()
 	var enhancers []token.Enhancer
+	// 证书绑定需要在 jwt 编码之前写入附加信息
+	if config.AuthorizationServer.CertificateBoundAccessTokens {
+		enhancers = append(enhancers, mtls.NewCertificateBoundEnhancer())
+	}
 	// 默认追加 jwt enhancer
 	enhancers = append(enhancers, oauth2Container.JwtAccessTokenConverter)
 	chain.SetTokenEnhancers(enhancers)
@@ -63,13 +89,40 @@ func TokenEnhancer(oauth2Container *securityContainer.OAuth2Container) token.Enh
 }
 
 // TokenGranter token 授权
-func TokenGranter(password *granter.PasswordTokenGranter) token.Granter {
+func TokenGranter(password *granter.PasswordTokenGranter, mfa *granter.MfaTokenGranter, sms *granter.SmsTokenGranter, social *granter.SocialTokenGranter) token.Granter {
 	result := granter.NewCompositeTokenGranter()
 	result.AddTokenGranter(password)
+	result.AddTokenGranter(mfa)
+	result.AddTokenGranter(sms)
+	result.AddTokenGranter(social)
 	return result
 }
 
 // PasswordTokenGranter 密码模式授权
-func PasswordTokenGranter(tokenServices token.AuthorizationServerTokenServices, manager authentication.AuthorizationManager) *granter.PasswordTokenGranter {
-	return granter.NewPasswordTokenGranter(tokenServices, manager)
+func PasswordTokenGranter(tokenServices token.AuthorizationServerTokenServices, manager authentication.AuthorizationManager, mfaManager *mfa.Manager) *granter.PasswordTokenGranter {
+	result := granter.NewPasswordTokenGranter(tokenServices, manager)
+	result.MfaManager = mfaManager
+	return result
+}
+
+// MfaTokenGranter 多因素认证授权
+func MfaTokenGranter(tokenServices token.AuthorizationServerTokenServices, manager authentication.AuthorizationManager, mfaManager *mfa.Manager) *granter.MfaTokenGranter {
+	return granter.NewMfaTokenGranter(tokenServices, manager, mfaManager)
+}
+
+// SmsTokenGranter 短信验证码授权
+func SmsTokenGranter(tokenServices token.AuthorizationServerTokenServices, manager authentication.AuthorizationManager) *granter.SmsTokenGranter {
+	return granter.NewSmsTokenGranter(tokenServices, manager)
+}
+
+// SocialTokenGranter 第三方登录授权
+func SocialTokenGranter(tokenServices token.AuthorizationServerTokenServices, manager authentication.AuthorizationManager) *granter.SocialTokenGranter {
+	return granter.NewSocialTokenGranter(tokenServices, manager)
+}
+
+// MfaManager 多因素认证，密码模式和表单登录共用
+func MfaManager(config mfa.Config, store mfa.Store, common *securityContainer.CommonContainer) *mfa.Manager {
+	manager := mfa.NewManager(config, common.MfaService, store)
+	manager.LoginAttemptTracker = common.LoginAttemptTracker
+	return manager
 }
@@ -4,11 +4,20 @@ import (
 	"github.com/ingot-cloud/ingot-go/pkg/framework/container/security/provider/null"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/dao"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/apikey"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/attempt"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/event"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/mfa"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/session"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/sms"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/social"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails/cache"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/crypto/factory"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/crypto/password"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/cors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
 )
 
 // WebSecurityConfigurersImpl 接口实现
@@ -27,13 +36,18 @@ func (web *WebSecurityConfigurersImpl) Get() []security.WebSecurityConfigurer {
 }
 
 // PasswordEncoder encoder
-func PasswordEncoder() password.Encoder {
-	return factory.CreateDelegatingPasswordEncoder()
+func PasswordEncoder(config factory.Config) password.Encoder {
+	return factory.CreateDelegatingPasswordEncoderWithConfig(config)
 }
 
-// UserCache 用户缓存
-func UserCache() userdetails.UserCache {
-	return cache.NewNilUserCache()
+// UserCache 用户缓存，根据配置选择内存或 redis，默认不缓存
+func UserCache(config cache.Config, redisClient *store.RedisClient) userdetails.UserCache {
+	return cache.NewUserCache(config, redisClient)
+}
+
+// UserCacheEvictor 用户或角色信息变更后清理用户缓存
+func UserCacheEvictor(userCache userdetails.UserCache) *cache.Evictor {
+	return cache.NewEvictor(userCache)
 }
 
 // PreChecker 前置检查器
@@ -51,7 +65,85 @@ func UserDetailsService() userdetails.Service {
 	return null.UserDetailsService()
 }
 
-// ClientDetailsService 客户端详情服务
-func ClientDetailsService() clientdetails.Service {
+// UserDetailsPasswordService 更新用户密码，默认不升级密码编码
+func UserDetailsPasswordService() userdetails.PasswordService {
+	return null.UserDetailsPasswordService()
+}
+
+// ClientDetailsService 客户端详情服务，配置了客户端时使用内存客户端服务
+func ClientDetailsService(config clientdetails.Config) clientdetails.Service {
+	if len(config.Clients) != 0 {
+		return clientdetails.NewInMemoryClientDetailsService(config.Clients)
+	}
 	return null.ClientDetails()
 }
+
+// ApiKeyService API key 服务
+func ApiKeyService() apikey.Service {
+	return null.ApiKeyService()
+}
+
+// MfaService 多因素认证服务
+func MfaService() mfa.Service {
+	return null.MfaService()
+}
+
+// CorsSource 跨域配置源
+func CorsSource(config cors.Config) cors.ConfigurationSource {
+	return cors.NewConfigurationSource(config)
+}
+
+// SessionRegistry 会话注册表
+func SessionRegistry(config session.Config, redisClient *store.RedisClient) session.Registry {
+	return session.NewRegistry(config, redisClient)
+}
+
+// ConcurrentSessionControl 并发会话控制
+func ConcurrentSessionControl(config session.Config, registry session.Registry) *session.ConcurrentControl {
+	return session.NewConcurrentControl(config, registry)
+}
+
+// MfaTokenStore 多因素认证 mfa_token 存储
+func MfaTokenStore(config mfa.Config, redisClient *store.RedisClient) mfa.Store {
+	return mfa.NewStore(config, redisClient)
+}
+
+// LoginAttemptTracker 登录失败跟踪，连续失败后临时锁定账户
+func LoginAttemptTracker(config attempt.Config, redisClient *store.RedisClient) *attempt.Tracker {
+	return attempt.NewTracker(config, attempt.NewStore(config, redisClient))
+}
+
+// SmsSender 短信发送，默认空实现，接入短信服务商时需要注入实现
+func SmsSender() sms.Sender {
+	return null.SmsSender()
+}
+
+// SmsPhoneNormalizer 手机号规范化，默认只去除分隔符
+func SmsPhoneNormalizer() sms.PhoneNormalizer {
+	return sms.NewDefaultPhoneNormalizer()
+}
+
+// SmsCodeStore 短信验证码存储
+func SmsCodeStore(config sms.Config, redisClient *store.RedisClient) sms.Store {
+	return sms.NewStore(config, redisClient)
+}
+
+// SmsCodeService 短信验证码发送和校验
+func SmsCodeService(config sms.Config, store sms.Store, sender sms.Sender, normalizer sms.PhoneNormalizer) *sms.CodeService {
+	return sms.NewCodeService(config, store, sender, normalizer)
+}
+
+// SmsUserDetailsService 根据手机号加载用户
+func SmsUserDetailsService() sms.UserDetailsService {
+	return null.SmsUserDetailsService()
+}
+
+// SocialUserDetailsService 根据第三方平台授权码加载用户
+func SocialUserDetailsService() social.UserDetailsService {
+	return null.SocialUserDetailsService()
+}
+
+// AuthenticationEventPublisher 认证事件发布者，默认写入审计日志
+func AuthenticationEventPublisher() event.Publisher {
+	return event.NewPublisher(event.NewAuditLogListener())
+}
@@ -16,10 +16,26 @@ var All = wire.NewSet(
 	// Fields
 	PasswordEncoder,
 	UserCache,
+	UserCacheEvictor,
 	PreChecker,
 	PostChecker,
 	UserDetailsService,
+	UserDetailsPasswordService,
 	ClientDetailsService,
+	ApiKeyService,
+	CorsSource,
+	SessionRegistry,
+	ConcurrentSessionControl,
+	MfaService,
+	MfaTokenStore,
+	LoginAttemptTracker,
+	SmsSender,
+	SmsPhoneNormalizer,
+	SmsCodeStore,
+	SmsCodeService,
+	SmsUserDetailsService,
+	SocialUserDetailsService,
+	AuthenticationEventPublisher,
 	wire.Struct(new(WebSecurityConfigurersImpl)),
 	wire.Bind(new(security.WebSecurityConfigurers), new(*WebSecurityConfigurersImpl)),
 	/* CommonContainer end */
@@ -47,6 +63,12 @@ var All = wire.NewSet(
 	TokenEnhancer,
 	TokenGranter,
 	PasswordTokenGranter,
+	MfaTokenGranter,
+	SmsTokenGranter,
+	SocialTokenGranter,
+	SmsCodeEndpoint,
+	ClientRegistrationEndpoint,
+	MfaManager,
 	/* AuthorizationServerContainer end */
 
 	/* ResourceServerContainer start */
@@ -57,6 +79,8 @@ var All = wire.NewSet(
 	ResourceServerConfigurer,
 	ResourceServerTokenServices,
 	TokenExtractor,
+	ApiKeyConfigurer,
+	PreAuthConfigurer,
 	/* ResourceServerContainer end */
 
 	/* AuthProvidersContainer start */
@@ -65,10 +89,27 @@ var All = wire.NewSet(
 	// Fields
 	DaoAuthenticationProvider,
 	BasicAuthenticationProvider,
-	wire.Struct(new(ProvidersImpl), "Basic", "Dao"),
+	ApiKeyAuthenticationProvider,
+	PreAuthAuthenticationProvider,
+	SmsAuthenticationProvider,
+	SocialAuthenticationProvider,
+	wire.Struct(new(ProvidersImpl), "Basic", "Dao", "ApiKey", "PreAuth", "Sms", "Social"),
 	wire.Bind(new(authentication.Providers), new(*ProvidersImpl)),
 	/* AuthProvidersContainer end */
 
+	/* WebContainer start */
+	wire.Struct(new(securityContainer.WebContainer), "*"),
+
+	// Fields
+	SessionStore,
+	CsrfTokenRepository,
+	CsrfConfigurer,
+	RememberMeTokenRepository,
+	RememberMeServices,
+	RememberMeConfigurer,
+	FormLoginConfigurer,
+	/* WebContainer end */
+
 	// 容器相关
 	wire.Struct(new(securityContainer.SecurityContainerImpl), "*"),
 	wire.Bind(new(securityContainer.SecurityContainer), new(*securityContainer.SecurityContainerImpl)),
@@ -81,10 +122,26 @@ var ProviderSet = di.NewSet(
 	// Fields
 	di.Func(PasswordEncoder),
 	di.Func(UserCache),
+	di.Func(UserCacheEvictor),
 	di.Func(PreChecker),
 	di.Func(PostChecker),
 	di.Func(UserDetailsService),
+	di.Func(UserDetailsPasswordService),
 	di.Func(ClientDetailsService),
+	di.Func(ApiKeyService),
+	di.Func(CorsSource),
+	di.Func(SessionRegistry),
+	di.Func(ConcurrentSessionControl),
+	di.Func(MfaService),
+	di.Func(MfaTokenStore),
+	di.Func(LoginAttemptTracker),
+	di.Func(SmsSender),
+	di.Func(SmsPhoneNormalizer),
+	di.Func(SmsCodeStore),
+	di.Func(SmsCodeService),
+	di.Func(SmsUserDetailsService),
+	di.Func(SocialUserDetailsService),
+	di.Func(AuthenticationEventPublisher),
 	di.Struct(new(WebSecurityConfigurersImpl)),
 	di.Bind(new(security.WebSecurityConfigurers), new(WebSecurityConfigurersImpl)),
 	/* CommonContainer end */
@@ -112,6 +169,12 @@ var ProviderSet = di.NewSet(
 	di.Func(TokenEnhancer),
 	di.Func(TokenGranter),
 	di.Func(PasswordTokenGranter),
+	di.Func(MfaTokenGranter),
+	di.Func(SmsTokenGranter),
+	di.Func(SocialTokenGranter),
+	di.Func(SmsCodeEndpoint),
+	di.Func(ClientRegistrationEndpoint),
+	di.Func(MfaManager),
 	/* AuthorizationServerContainer end */
 
 	/* ResourceServerContainer start */
@@ -122,6 +185,8 @@ var ProviderSet = di.NewSet(
 	di.Func(ResourceServerConfigurer),
 	di.Func(ResourceServerTokenServices),
 	di.Func(TokenExtractor),
+	di.Func(ApiKeyConfigurer),
+	di.Func(PreAuthConfigurer),
 	/* ResourceServerContainer end */
 
 	/* AuthProvidersContainer start */
@@ -130,8 +195,25 @@ var ProviderSet = di.NewSet(
 	// Fields
 	di.Func(DaoAuthenticationProvider),
 	di.Func(BasicAuthenticationProvider),
-	di.Struct(new(ProvidersImpl), "Basic", "Dao"),
+	di.Func(ApiKeyAuthenticationProvider),
+	di.Func(PreAuthAuthenticationProvider),
+	di.Func(SmsAuthenticationProvider),
+	di.Func(SocialAuthenticationProvider),
+	di.Struct(new(ProvidersImpl), "Basic", "Dao", "ApiKey", "PreAuth", "Sms", "Social"),
 	di.Bind(new(authentication.Providers), new(ProvidersImpl)),
 	/* AuthProvidersContainer end */
 
+	/* WebContainer start */
+	di.Struct(new(securityContainer.WebContainer)),
+
+	// Fields
+	di.Func(SessionStore),
+	di.Func(CsrfTokenRepository),
+	di.Func(CsrfConfigurer),
+	di.Func(RememberMeTokenRepository),
+	di.Func(RememberMeServices),
+	di.Func(RememberMeConfigurer),
+	di.Func(FormLoginConfigurer),
+	/* WebContainer end */
+
 )
@@ -1,6 +1,10 @@
 package null
 
 import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/apikey"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/mfa"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/sms"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/social"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
 )
@@ -14,3 +18,33 @@ func ClientDetails() clientdetails.Service {
 func UserDetailsService() userdetails.Service {
 	return &userdetails.NilUserDetailsService{}
 }
+
+// ApiKeyService 空实现
+func ApiKeyService() apikey.Service {
+	return &apikey.NilService{}
+}
+
+// MfaService 空实现
+func MfaService() mfa.Service {
+	return &mfa.NilService{}
+}
+
+// UserDetailsPasswordService 空实现
+func UserDetailsPasswordService() userdetails.PasswordService {
+	return &userdetails.NilPasswordService{}
+}
+
+// SmsUserDetailsService 空实现
+func SmsUserDetailsService() sms.UserDetailsService {
+	return &sms.NilUserDetailsService{}
+}
+
+// SmsSender 空实现
+func SmsSender() sms.Sender {
+	return &sms.NilSender{}
+}
+
+// SocialUserDetailsService 空实现
+func SocialUserDetailsService() social.UserDetailsService {
+	return &social.NilUserDetailsService{}
+}
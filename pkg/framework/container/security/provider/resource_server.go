@@ -1,12 +1,18 @@
 package provider
 
 import (
+	securityContainer "github.com/ingot-cloud/ingot-go/pkg/framework/container/security"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
 	coreAuth "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/session"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/authentication"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/config"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/configurer"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/apikey"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/cors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/headers"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/preauth"
 )
 
 // ResourceAuthenticationManager 资源服务器中使用的认证管理器
@@ -17,13 +23,24 @@ func ResourceAuthenticationManager(oauthConfig config.OAuth2, tokenService token
 }
 
 // ResourceServerConfigurer 资源服务器配置
-func ResourceServerConfigurer(tokenExtractor authentication.TokenExtractor, authenticationManager coreAuth.ResourceManager) security.ResourceServerConfigurer {
-	return configurer.NewResourceServerConfigurer(tokenExtractor, authenticationManager)
+func ResourceServerConfigurer(tokenExtractor authentication.TokenExtractor, authenticationManager coreAuth.ResourceManager, corsSource cors.ConfigurationSource, headersConfig headers.Config, apiKeyConfigurer *apikey.SecurityConfigurer, preAuthConfigurer *preauth.SecurityConfigurer, common *securityContainer.CommonContainer) security.ResourceServerConfigurer {
+	return configurer.NewResourceServerConfigurer(tokenExtractor, authenticationManager, corsSource, headersConfig, apiKeyConfigurer, preAuthConfigurer, common.AuthenticationEventPublisher)
+}
+
+// ApiKeyConfigurer API key 配置，使用授权认证管理器中的 ApiKey 提供者验证
+func ApiKeyConfigurer(apiKeyConfig apikey.Config, manager coreAuth.AuthorizationManager) *apikey.SecurityConfigurer {
+	return apikey.NewSecurityConfigurer(apiKeyConfig, manager)
+}
+
+// PreAuthConfigurer 预身份验证配置，使用授权认证管理器中的 PreAuth 提供者加载用户
+func PreAuthConfigurer(preAuthConfig preauth.Config, manager coreAuth.AuthorizationManager) *preauth.SecurityConfigurer {
+	return preauth.NewSecurityConfigurer(preAuthConfig, manager)
 }
 
 // ResourceServerTokenServices 资源服务器 token 服务
-func ResourceServerTokenServices(tokenStore token.Store) token.ResourceServerTokenServices {
+func ResourceServerTokenServices(tokenStore token.Store, concurrentControl *session.ConcurrentControl) token.ResourceServerTokenServices {
 	service := token.NewDefaultTokenServices(tokenStore)
+	service.ConcurrentControl = concurrentControl
 	return service
 }
 
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	securityContainer "github.com/ingot-cloud/ingot-go/pkg/framework/container/security"
+	ginwrapper "github.com/ingot-cloud/ingot-go/pkg/framework/core/wrapper/gin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/mfa"
+	coreSession "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/session"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/endpoint"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/config"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/cors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/csrf"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/formlogin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/headers"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/rememberme"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/session"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/utils"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
+)
+
+// SessionStore 会话存储
+func SessionStore(config formlogin.Config, redisClient *store.RedisClient) session.Store {
+	return session.NewStore(config.Session, redisClient)
+}
+
+// CsrfTokenRepository CSRF token 存储
+func CsrfTokenRepository(csrfConfig csrf.Config, sessionStore session.Store) csrf.TokenRepository {
+	return csrf.NewTokenRepository(csrfConfig, sessionStore)
+}
+
+// CsrfConfigurer CSRF 配置，使用 bearer token 的请求和 OAuth2 端点不需要校验
+func CsrfConfigurer(csrfConfig csrf.Config, repository csrf.TokenRepository) *csrf.SecurityConfigurer {
+	bearer := utils.NewRequestMatcher("Bearer token request", func(ctx *ingot.Context) bool {
+		return ginwrapper.GetBearerToken(ctx.Context) != ""
+	})
+	return csrf.NewSecurityConfigurer(csrfConfig, repository).
+		IgnoringRequestMatchers(bearer, utils.NewAntPathRequestMatchers(endpoint.Paths...))
+}
+
+// RememberMeTokenRepository 记住我 token 存储
+func RememberMeTokenRepository(rememberMeConfig rememberme.Config, redisClient *store.RedisClient) rememberme.TokenRepository {
+	return rememberme.NewTokenRepository(rememberMeConfig, redisClient)
+}
+
+// RememberMeServices 记住我服务
+func RememberMeServices(rememberMeConfig rememberme.Config, repository rememberme.TokenRepository, common *securityContainer.CommonContainer) rememberme.Services {
+	return rememberme.NewPersistentTokenServices(rememberMeConfig, repository, common.UserDetailsService, common.PreChecker)
+}
+
+// RememberMeConfigurer 记住我配置
+func RememberMeConfigurer(rememberMeConfig rememberme.Config, services rememberme.Services, sessionStore session.Store, concurrentControl *coreSession.ConcurrentControl) *rememberme.SecurityConfigurer {
+	configurer := rememberme.NewSecurityConfigurer(rememberMeConfig, services, sessionStore)
+	configurer.ConcurrentControl = concurrentControl
+	return configurer
+}
+
+// FormLoginConfigurer 表单登录配置
+func FormLoginConfigurer(formLoginConfig formlogin.Config, manager authentication.AuthorizationManager, sessionStore session.Store, csrfConfigurer *csrf.SecurityConfigurer, rememberMeConfigurer *rememberme.SecurityConfigurer, corsSource cors.ConfigurationSource, headersConfig headers.Config, concurrentControl *coreSession.ConcurrentControl, mfaManager *mfa.Manager) security.FormLoginConfigurer {
+	return config.NewFormLoginConfigurer(formLoginConfig, manager, sessionStore, csrfConfigurer, rememberMeConfigurer, corsSource, headersConfig, concurrentControl, mfaManager)
+}
@@ -7,4 +7,5 @@ type SecurityContainer interface {
 	GetResourceServerContainer() *ResourceServerContainer
 	GetAuthorizationServerContainer() *AuthorizationServerContainer
 	GetAuthProvidersContainer() *AuthProvidersContainer
+	GetWebContainer() *WebContainer
 }
@@ -30,6 +30,16 @@ func New(statusCode int, code string, message string) error {
 	}
 }
 
+// NewWithData error，data 会作为响应数据返回
+func NewWithData(statusCode int, code string, message string, data interface{}) error {
+	return &E{
+		StatusCode: statusCode,
+		Code:       code,
+		Message:    message,
+		Data:       data,
+	}
+}
+
 // Unpack error
 func Unpack(err error) *E {
 	if e, ok := err.(*E); ok {
@@ -53,6 +63,7 @@ type E struct {
 	StatusCode int
 	Code       string
 	Message    string
+	Data       interface{}
 }
 
 func (e *E) Error() string {
@@ -2,11 +2,13 @@ package pathmatcher
 
 import (
 	"strings"
+	"sync"
 )
 
-// Ant 路径匹配
+// Ant 路径匹配，可并发使用
 type Ant struct {
 	tokenizedPatternCache map[string][]string
+	lock                  sync.RWMutex
 }
 
 // NewAntPathMatcher 实例化
@@ -196,13 +198,17 @@ func (a *Ant) matchStrings(pattern, target string) bool {
 func (a *Ant) tokenizePattern(pattern string) []string {
 	var tokenized []string
 
+	a.lock.RLock()
 	tokenized = a.tokenizedPatternCache[pattern]
+	a.lock.RUnlock()
 	if len(tokenized) != 0 {
 		return tokenized
 	}
 
 	tokenized = a.tokenizePath(pattern)
+	a.lock.Lock()
 	a.tokenizedPatternCache[pattern] = tokenized
+	a.lock.Unlock()
 
 	return tokenized
 }
@@ -48,7 +48,11 @@ func Failure(ctx *gin.Context, code string, message string) {
 
 // FailureWithE response
 func FailureWithE(ctx *gin.Context, e *errors.E) {
-	Result(ctx, e.StatusCode, e.Code, &D{}, e.Message)
+	var data interface{} = &D{}
+	if e.Data != nil {
+		data = e.Data
+	}
+	Result(ctx, e.StatusCode, e.Code, data, e.Message)
 }
 
 // FailureWithError response
@@ -0,0 +1,48 @@
+package authentication
+
+import "github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+
+// NewUnauthenticatedApiKeyAuthToken 获取未验证的token
+func NewUnauthenticatedApiKeyAuthToken(key string) *ApiKeyAuthenticationToken {
+	token := &ApiKeyAuthenticationToken{
+		Key:                         key,
+		AbstractAuthenticationToken: &AbstractAuthenticationToken{},
+	}
+	token.SetAuthenticated(false)
+	return token
+}
+
+// NewAuthenticatedApiKeyAuthToken 获取验证的token
+func NewAuthenticatedApiKeyAuthToken(principal interface{}, authorities []core.GrantedAuthority) *ApiKeyAuthenticationToken {
+	token := &ApiKeyAuthenticationToken{
+		Principal: principal,
+		AbstractAuthenticationToken: &AbstractAuthenticationToken{
+			Authorities: authorities,
+		},
+	}
+	token.SetAuthenticated(true)
+	return token
+}
+
+// ApiKeyAuthenticationToken API key 身份验证令牌
+type ApiKeyAuthenticationToken struct {
+	Principal interface{}
+	Key       string
+	*AbstractAuthenticationToken
+}
+
+// GetCredentials 凭证信息
+func (token *ApiKeyAuthenticationToken) GetCredentials() string {
+	return token.Key
+}
+
+// GetPrincipal 身份验证的主体
+func (token *ApiKeyAuthenticationToken) GetPrincipal() interface{} {
+	return token.Principal
+}
+
+// EraseCredentials 擦除敏感数据
+func (token *ApiKeyAuthenticationToken) EraseCredentials() {
+	token.AbstractAuthenticationToken.EraseCredentials()
+	token.Key = ""
+}
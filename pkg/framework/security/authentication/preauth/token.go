@@ -15,14 +15,14 @@ type AuthenticationToken struct {
 // NewAuthenticationToken 创建预验证令牌
 func NewAuthenticationToken(principal interface{}, credentials string, authorities []core.GrantedAuthority) *AuthenticationToken {
 	auth := &AuthenticationToken{
-		Principal:   principal,
-		Credentials: credentials,
+		Principal:                   principal,
+		Credentials:                 credentials,
+		AbstractAuthenticationToken: authentication.NewAbstractAuthenticationToken(authorities),
 	}
 
 	if authorities != nil {
 		auth.SetAuthenticated(true)
 	}
-	auth.AbstractAuthenticationToken = authentication.NewAbstractAuthenticationToken(authorities)
 
 	return auth
 }
@@ -0,0 +1,67 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	coreApiKey "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/apikey"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/authority"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
+)
+
+// AuthenticationProvider API key 提供者
+type AuthenticationProvider struct {
+	ApiKeyService coreApiKey.Service
+}
+
+// NewProvider 实例化
+func NewProvider(service coreApiKey.Service) *AuthenticationProvider {
+	return &AuthenticationProvider{
+		ApiKeyService: service,
+	}
+}
+
+// Supports 该身份验证提供者是否支持指定的认证信息
+func (p *AuthenticationProvider) Supports(auth interface{}) bool {
+	_, ok := auth.(*authentication.ApiKeyAuthenticationToken)
+	return ok
+}
+
+// Authenticate 身份验证
+func (p *AuthenticationProvider) Authenticate(auth core.Authentication) (core.Authentication, error) {
+	prefix, secret, ok := coreApiKey.Split(auth.GetCredentials())
+	if !ok {
+		return nil, errors.BadCredentials("Invalid api key")
+	}
+	details, err := p.ApiKeyService.LoadByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if details == nil || !coreApiKey.Matches(secret, details.HashedKey) {
+		return nil, errors.BadCredentials("Invalid api key")
+	}
+	if !details.Enabled {
+		return nil, errors.AccountDisabled("Api key is disabled")
+	}
+	now := time.Now()
+	if details.IsExpired(now) {
+		return nil, errors.CredentialsExpired("Api key has expired")
+	}
+	if err := p.ApiKeyService.UpdateLastUsed(details.ID, now); err != nil {
+		// 更新使用时间失败不影响认证结果
+		log.Errorf("Failed to update api key last used time: %s", err.Error())
+	}
+
+	result := authentication.NewAuthenticatedApiKeyAuthToken(details.Principal, authority.CreateAuthorityList(details.Scopes))
+	result.SetDetails(&coreApiKey.Details{
+		ID:        details.ID,
+		Prefix:    details.Prefix,
+		Principal: details.Principal,
+		Scopes:    details.Scopes,
+		ExpiresAt: details.ExpiresAt,
+		Enabled:   details.Enabled,
+	})
+	return result, nil
+}
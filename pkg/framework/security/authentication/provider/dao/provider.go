@@ -1,8 +1,11 @@
 package dao
 
 import (
+	coreErrors "github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/attempt"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/crypto/password"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
@@ -15,16 +18,20 @@ type AuthenticationProvider struct {
 	UserCache                userdetails.UserCache
 	PreAuthenticationChecks  userdetails.PreChecker
 	PostAuthenticationChecks userdetails.PostChecker
+	LoginAttemptTracker      *attempt.Tracker
+	// 可选，存在时登录成功后升级密码编码
+	UserDetailsPasswordService userdetails.PasswordService
 }
 
 // NewProvider 实例化
-func NewProvider(encoder password.Encoder, service userdetails.Service, cache userdetails.UserCache, preChecker userdetails.PreChecker, postChecker userdetails.PostChecker) *AuthenticationProvider {
+func NewProvider(encoder password.Encoder, service userdetails.Service, cache userdetails.UserCache, preChecker userdetails.PreChecker, postChecker userdetails.PostChecker, tracker *attempt.Tracker) *AuthenticationProvider {
 	return &AuthenticationProvider{
 		PasswordEncoder:          encoder,
 		UserDetailsService:       service,
 		UserCache:                cache,
 		PreAuthenticationChecks:  preChecker,
 		PostAuthenticationChecks: postChecker,
+		LoginAttemptTracker:      tracker,
 	}
 }
 
@@ -34,10 +41,14 @@ func (p *AuthenticationProvider) Authenticate(auth core.Authentication) (core.Au
 	cacheWasUsed := true
 	// Supports 方法已经确定了该 auth 为 UsernamePasswordAuthenticationToken
 	userAuth, _ := auth.(*authentication.UsernamePasswordAuthenticationToken)
+	remoteAddress := attempt.RemoteAddress(auth.GetDetails())
 	user, err := p.UserCache.GetUserFromCache(username)
 	if err != nil {
 		return nil, err
 	}
+	if user != nil && !p.matchesDetails(user, auth.GetDetails()) {
+		user = nil
+	}
 	if user == nil {
 		cacheWasUsed = false
 		user, err = p.retrieveUser(username, userAuth)
@@ -45,8 +56,14 @@ func (p *AuthenticationProvider) Authenticate(auth core.Authentication) (core.Au
 			return nil, err
 		}
 	}
-	// 前置检测
-	err = p.PreAuthenticationChecks.Check(user)
+	// 失败次数按加载的用户记录，避免使用不同的登录标识绕过锁定
+	identity := attempt.Identity(user)
+	// 前置检测，登录失败次数过多时用户处于锁定状态
+	checked, err := p.applyLock(user, identity, remoteAddress)
+	if err != nil {
+		return nil, err
+	}
+	err = p.PreAuthenticationChecks.Check(checked)
 	if err != nil {
 		return nil, err
 	}
@@ -54,28 +71,39 @@ func (p *AuthenticationProvider) Authenticate(auth core.Authentication) (core.Au
 	if err != nil {
 		// 如果检测的 user 不是缓存的，那么直接抛出异常
 		if !cacheWasUsed {
-			return nil, err
+			return nil, p.loginFailed(identity, remoteAddress, err)
 		}
 		// 如果检测的 user 是从缓存中获取的，那么重新获取最新数据进行检查
+		cacheWasUsed = false
 		user, err = p.retrieveUser(username, userAuth)
 		if err != nil {
 			return nil, err
 		}
-		err = p.PreAuthenticationChecks.Check(user)
+		identity = attempt.Identity(user)
+		checked, err = p.applyLock(user, identity, remoteAddress)
 		if err != nil {
 			return nil, err
 		}
-		err = p.additionalAuthenticationChecks(user, userAuth)
+		err = p.PreAuthenticationChecks.Check(checked)
 		if err != nil {
 			return nil, err
 		}
+		err = p.additionalAuthenticationChecks(user, userAuth)
+		if err != nil {
+			return nil, p.loginFailed(identity, remoteAddress, err)
+		}
 	}
 	// 后置检测
 	err = p.PostAuthenticationChecks.Check(user)
 	if err != nil {
 		return nil, err
 	}
-	if !cacheWasUsed {
+	if err := p.LoginAttemptTracker.LoginSucceeded(identity, remoteAddress); err != nil {
+		return nil, err
+	}
+	user, upgraded := p.upgradeEncoding(user, userAuth)
+	// 缓存中的用户已过期或密码已升级时更新缓存
+	if !cacheWasUsed || upgraded {
 		p.UserCache.PutUserInCache(user)
 	}
 
@@ -97,7 +125,13 @@ func (p *AuthenticationProvider) determineUsername(auth core.Authentication) str
 }
 
 func (p *AuthenticationProvider) retrieveUser(username string, auth *authentication.UsernamePasswordAuthenticationToken) (userdetails.UserDetails, error) {
-	loadedUser, err := p.UserDetailsService.LoadUserByUsername(username)
+	var loadedUser userdetails.UserDetails
+	var err error
+	if service, ok := p.UserDetailsService.(userdetails.DetailsService); ok {
+		loadedUser, err = service.LoadUserByUsernameAndDetails(username, auth.GetDetails())
+	} else {
+		loadedUser, err = p.UserDetailsService.LoadUserByUsername(username)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -107,6 +141,14 @@ func (p *AuthenticationProvider) retrieveUser(username string, auth *authenticat
 	return loadedUser, nil
 }
 
+// 缓存的用户与当前认证请求不一致时不使用缓存
+func (p *AuthenticationProvider) matchesDetails(user userdetails.UserDetails, details interface{}) bool {
+	if service, ok := p.UserDetailsService.(userdetails.DetailsService); ok {
+		return service.MatchesDetails(user, details)
+	}
+	return true
+}
+
 func (p *AuthenticationProvider) additionalAuthenticationChecks(userDetails userdetails.UserDetails, auth *authentication.UsernamePasswordAuthenticationToken) error {
 	if auth.GetCredentials() == "" {
 		return errors.BadCredentials("Bad credentials")
@@ -122,6 +164,49 @@ func (p *AuthenticationProvider) additionalAuthenticationChecks(userDetails user
 	return nil
 }
 
+// 已存储的密码使用旧的编码方式时，使用当前编码器重新编码并保存，失败时不影响登录
+func (p *AuthenticationProvider) upgradeEncoding(user userdetails.UserDetails, auth *authentication.UsernamePasswordAuthenticationToken) (userdetails.UserDetails, bool) {
+	if p.UserDetailsPasswordService == nil || !p.PasswordEncoder.UpgradeEncoding(user.GetPassword()) {
+		return user, false
+	}
+	newPassword, err := p.PasswordEncoder.Encode(auth.GetCredentials())
+	if err != nil {
+		log.Warnf("Failed to encode password, username=%s, err=%s", user.GetUsername(), err.Error())
+		return user, false
+	}
+	var updated userdetails.UserDetails
+	if service, ok := p.UserDetailsPasswordService.(userdetails.DetailsPasswordService); ok {
+		updated, err = service.UpdatePasswordWithDetails(user, newPassword, auth.GetDetails())
+	} else {
+		updated, err = p.UserDetailsPasswordService.UpdatePassword(user, newPassword)
+	}
+	if err != nil {
+		log.Warnf("Failed to upgrade password encoding, username=%s, err=%s", user.GetUsername(), err.Error())
+		return user, false
+	}
+	return updated, true
+}
+
+// 登录失败次数过多时返回处于锁定状态的用户
+func (p *AuthenticationProvider) applyLock(user userdetails.UserDetails, identity, remoteAddress string) (userdetails.UserDetails, error) {
+	locked, err := p.LoginAttemptTracker.IsLocked(identity, remoteAddress)
+	if err != nil {
+		return nil, err
+	}
+	return p.LoginAttemptTracker.Apply(user, locked), nil
+}
+
+// 密码错误时记录失败次数，其他错误原样返回
+func (p *AuthenticationProvider) loginFailed(identity, remoteAddress string, err error) error {
+	if e, ok := err.(*coreErrors.E); !ok || e.Code != errors.BadCredentialsCode {
+		return err
+	}
+	if recordErr := p.LoginAttemptTracker.LoginFailed(identity, remoteAddress); recordErr != nil {
+		return recordErr
+	}
+	return err
+}
+
 func (p *AuthenticationProvider) createSuccessAuthentication(principal interface{}, auth core.Authentication, user userdetails.UserDetails) (core.Authentication, error) {
 	result := authentication.NewAuthenticatedUsernamePasswordAuthToken(principal, auth.GetCredentials(), user.GetAuthorities())
 	result.SetDetails(auth.GetDetails())
@@ -0,0 +1,85 @@
+package preauth
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/preauth"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
+)
+
+// AuthenticationProvider 预身份验证提供者，主体已经由外部系统（网关、TLS 客户端证书等）验证，
+// 此处只根据主体名称加载用户并检查用户状态
+type AuthenticationProvider struct {
+	UserDetailsService userdetails.Service
+	UserDetailsChecker userdetails.PreChecker
+}
+
+// NewProvider 实例化
+func NewProvider(service userdetails.Service, checker userdetails.PreChecker) *AuthenticationProvider {
+	return &AuthenticationProvider{
+		UserDetailsService: service,
+		UserDetailsChecker: checker,
+	}
+}
+
+// Supports 该身份验证提供者是否支持指定的认证信息
+func (p *AuthenticationProvider) Supports(auth interface{}) bool {
+	_, ok := auth.(*preauth.AuthenticationToken)
+	return ok
+}
+
+// Authenticate 身份验证
+func (p *AuthenticationProvider) Authenticate(auth core.Authentication) (core.Authentication, error) {
+	username := determineUsername(auth.GetPrincipal())
+	if username == "" {
+		return nil, errors.BadCredentials("No pre-authenticated principal found in request")
+	}
+
+	user, err := p.loadUser(username, auth)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.UsernameNotFound("Failed to find user: ", username)
+	}
+	if err := p.UserDetailsChecker.Check(user); err != nil {
+		return nil, err
+	}
+
+	result := preauth.NewAuthenticationToken(user, auth.GetCredentials(), user.GetAuthorities())
+	result.SetDetails(auth.GetDetails())
+	return result, nil
+}
+
+// 多租户时用户名只在租户内唯一，使用原始认证的租户和认证详情加载用户
+func (p *AuthenticationProvider) loadUser(username string, auth core.Authentication) (userdetails.UserDetails, error) {
+	service, ok := p.UserDetailsService.(userdetails.DetailsService)
+	if !ok {
+		return p.UserDetailsService.LoadUserByUsername(username)
+	}
+	details := auth.GetDetails()
+	// 刷新令牌时主体为原始身份验证信息，当前请求没有认证详情时使用原始认证详情
+	if original, ok := auth.GetPrincipal().(core.Authentication); ok && details == nil {
+		details = original.GetDetails()
+	}
+	details, err := authentication.WithTenantID(details, authentication.PrincipalTenantID(auth.GetPrincipal()))
+	if err != nil {
+		return nil, err
+	}
+	return service.LoadUserByUsernameAndDetails(username, details)
+}
+
+// 主体可能是用户名、用户信息或者已有的身份验证信息（刷新令牌时）
+func determineUsername(principal interface{}) string {
+	switch value := principal.(type) {
+	case string:
+		return value
+	case userdetails.UserDetails:
+		return value.GetUsername()
+	case core.Authentication:
+		return value.GetName(value)
+	default:
+		return ""
+	}
+}
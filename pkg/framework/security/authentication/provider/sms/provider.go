@@ -0,0 +1,60 @@
+package sms
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	coreSms "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/sms"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
+)
+
+// AuthenticationProvider 短信验证码提供者，校验验证码后根据手机号加载用户
+type AuthenticationProvider struct {
+	CodeService              *coreSms.CodeService
+	UserDetailsService       coreSms.UserDetailsService
+	PreAuthenticationChecks  userdetails.PreChecker
+	PostAuthenticationChecks userdetails.PostChecker
+}
+
+// NewProvider 实例化
+func NewProvider(codeService *coreSms.CodeService, service coreSms.UserDetailsService, preChecker userdetails.PreChecker, postChecker userdetails.PostChecker) *AuthenticationProvider {
+	return &AuthenticationProvider{
+		CodeService:              codeService,
+		UserDetailsService:       service,
+		PreAuthenticationChecks:  preChecker,
+		PostAuthenticationChecks: postChecker,
+	}
+}
+
+// Supports 该身份验证提供者是否支持指定的认证信息
+func (p *AuthenticationProvider) Supports(auth interface{}) bool {
+	_, ok := auth.(*authentication.SmsCodeAuthenticationToken)
+	return ok
+}
+
+// Authenticate 身份验证
+func (p *AuthenticationProvider) Authenticate(auth core.Authentication) (core.Authentication, error) {
+	phone, _ := auth.GetPrincipal().(string)
+	// 先校验验证码，避免通过错误信息判断手机号是否注册
+	if err := p.CodeService.Verify(phone, auth.GetCredentials()); err != nil {
+		return nil, err
+	}
+
+	user, err := p.UserDetailsService.LoadUserByPhone(phone, auth.GetDetails())
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.UsernameNotFound("Failed to find user: ", phone)
+	}
+	if err := p.PreAuthenticationChecks.Check(user); err != nil {
+		return nil, err
+	}
+	if err := p.PostAuthenticationChecks.Check(user); err != nil {
+		return nil, err
+	}
+
+	result := authentication.NewAuthenticatedSmsCodeAuthToken(user, user.GetAuthorities())
+	result.SetDetails(auth.GetDetails())
+	return result, nil
+}
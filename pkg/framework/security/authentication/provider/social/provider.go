@@ -0,0 +1,57 @@
+package social
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	coreSocial "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/social"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
+)
+
+// AuthenticationProvider 第三方登录提供者，使用授权码换取第三方用户信息后加载绑定的用户
+type AuthenticationProvider struct {
+	UserDetailsService       coreSocial.UserDetailsService
+	PreAuthenticationChecks  userdetails.PreChecker
+	PostAuthenticationChecks userdetails.PostChecker
+}
+
+// NewProvider 实例化
+func NewProvider(service coreSocial.UserDetailsService, preChecker userdetails.PreChecker, postChecker userdetails.PostChecker) *AuthenticationProvider {
+	return &AuthenticationProvider{
+		UserDetailsService:       service,
+		PreAuthenticationChecks:  preChecker,
+		PostAuthenticationChecks: postChecker,
+	}
+}
+
+// Supports 该身份验证提供者是否支持指定的认证信息
+func (p *AuthenticationProvider) Supports(auth interface{}) bool {
+	_, ok := auth.(*authentication.SocialAuthenticationToken)
+	return ok
+}
+
+// Authenticate 身份验证
+func (p *AuthenticationProvider) Authenticate(auth core.Authentication) (core.Authentication, error) {
+	socialType, _ := auth.GetPrincipal().(string)
+	if socialType == "" {
+		return nil, errors.SocialAuthFailed("Missing social_type")
+	}
+
+	user, err := p.UserDetailsService.LoadUserBySocial(socialType, auth.GetCredentials(), auth.GetDetails())
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.SocialUserNotBound("No user is bound to the ", socialType, " account")
+	}
+	if err := p.PreAuthenticationChecks.Check(user); err != nil {
+		return nil, err
+	}
+	if err := p.PostAuthenticationChecks.Check(user); err != nil {
+		return nil, err
+	}
+
+	result := authentication.NewAuthenticatedSocialAuthToken(user, user.GetAuthorities())
+	result.SetDetails(auth.GetDetails())
+	return result, nil
+}
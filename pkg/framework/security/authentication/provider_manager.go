@@ -4,42 +4,87 @@ import (
 	"fmt"
 
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/event"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
 )
 
 // ProviderManager Provider管理器
 type ProviderManager struct {
-	providers Providers
+	providers      Providers
+	eventPublisher event.Publisher
+	// 可选，所有 provider 都无法完成认证时委托给父管理器
+	Parent Manager
 }
 
 // NewProviderManager 实例化
-func NewProviderManager(providers Providers) *ProviderManager {
+func NewProviderManager(providers Providers, publisher event.Publisher) *ProviderManager {
 	return &ProviderManager{
-		providers: providers,
+		providers:      providers,
+		eventPublisher: publisher,
 	}
 }
 
 func (*ProviderManager) Authorization() {}
 
 // Authenticate 对 Authentication 进行身份验证，验证成功后返回完全填充的Authentication
+// 依次尝试支持该认证信息的 provider，第一个成功的结果即为最终结果，
+// 全部失败时返回最后一个 provider 的错误
 func (m *ProviderManager) Authenticate(auth core.Authentication) (core.Authentication, error) {
-
 	var result core.Authentication
-	var err error = nil
+	var lastErr error
 	for _, provider := range m.providers.Get() {
 		if !provider.Supports(auth) {
 			continue
 		}
 
-		result, err = provider.Authenticate(auth)
+		authResult, err := provider.Authenticate(auth)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if authResult != nil {
+			result = authResult
+			break
+		}
+	}
+
+	// 父管理器会自行发布事件
+	parentUsed := false
+	if result == nil && m.Parent != nil {
+		parentResult, err := m.Parent.Authenticate(auth)
 		if err != nil {
-			return nil, err
+			if lastErr == nil {
+				lastErr = err
+				parentUsed = true
+			}
+		} else {
+			result = parentResult
+			parentUsed = true
 		}
 	}
 
-	if result == nil {
-		return nil, errors.ProviderNotFound("No AuthenticationProvider found for ", fmt.Sprintf("%v", auth))
+	if result != nil {
+		// 认证完成后擦除凭证，避免密码传递给令牌转换器
+		if container, ok := result.(core.CredentialsContainer); ok {
+			container.EraseCredentials()
+		}
+		if !parentUsed {
+			m.publish(event.NewEvent(event.AuthenticationSuccess, result, nil))
+		}
+		return result, nil
 	}
 
-	return result, nil
+	if lastErr == nil {
+		lastErr = errors.ProviderNotFound("No AuthenticationProvider found for ", fmt.Sprintf("%v", auth))
+	}
+	if !parentUsed {
+		m.publish(event.NewFailureEvent(auth, lastErr))
+	}
+	return nil, lastErr
+}
+
+func (m *ProviderManager) publish(e *event.Event) {
+	if m.eventPublisher != nil {
+		m.eventPublisher.Publish(e)
+	}
 }
@@ -0,0 +1,50 @@
+package authentication
+
+import "github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+
+// NewUnauthenticatedSmsCodeAuthToken 获取未验证的token
+func NewUnauthenticatedSmsCodeAuthToken(phone, code string) *SmsCodeAuthenticationToken {
+	token := &SmsCodeAuthenticationToken{
+		Principal:                   phone,
+		Code:                        code,
+		AbstractAuthenticationToken: &AbstractAuthenticationToken{},
+	}
+	token.SetAuthenticated(false)
+	return token
+}
+
+// NewAuthenticatedSmsCodeAuthToken 获取验证的token
+func NewAuthenticatedSmsCodeAuthToken(principal interface{}, authorities []core.GrantedAuthority) *SmsCodeAuthenticationToken {
+	token := &SmsCodeAuthenticationToken{
+		Principal: principal,
+		AbstractAuthenticationToken: &AbstractAuthenticationToken{
+			Authorities: authorities,
+		},
+	}
+	token.SetAuthenticated(true)
+	return token
+}
+
+// SmsCodeAuthenticationToken 短信验证码身份验证令牌，未验证时主体为手机号
+type SmsCodeAuthenticationToken struct {
+	Principal interface{}
+	Code      string
+	*AbstractAuthenticationToken
+}
+
+// GetCredentials 凭证信息
+func (token *SmsCodeAuthenticationToken) GetCredentials() string {
+	return token.Code
+}
+
+// GetPrincipal 身份验证的主体
+func (token *SmsCodeAuthenticationToken) GetPrincipal() interface{} {
+	return token.Principal
+}
+
+// EraseCredentials 擦除敏感数据
+func (token *SmsCodeAuthenticationToken) EraseCredentials() {
+	token.AbstractAuthenticationToken.EraseCredentials()
+	token.EraseSecret(token.Principal)
+	token.Code = ""
+}
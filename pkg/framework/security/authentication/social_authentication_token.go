@@ -0,0 +1,50 @@
+package authentication
+
+import "github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+
+// NewUnauthenticatedSocialAuthToken 获取未验证的token
+func NewUnauthenticatedSocialAuthToken(socialType, code string) *SocialAuthenticationToken {
+	token := &SocialAuthenticationToken{
+		Principal:                   socialType,
+		Code:                        code,
+		AbstractAuthenticationToken: &AbstractAuthenticationToken{},
+	}
+	token.SetAuthenticated(false)
+	return token
+}
+
+// NewAuthenticatedSocialAuthToken 获取验证的token
+func NewAuthenticatedSocialAuthToken(principal interface{}, authorities []core.GrantedAuthority) *SocialAuthenticationToken {
+	token := &SocialAuthenticationToken{
+		Principal: principal,
+		AbstractAuthenticationToken: &AbstractAuthenticationToken{
+			Authorities: authorities,
+		},
+	}
+	token.SetAuthenticated(true)
+	return token
+}
+
+// SocialAuthenticationToken 第三方登录身份验证令牌，未验证时主体为第三方平台类型，凭证为授权码
+type SocialAuthenticationToken struct {
+	Principal interface{}
+	Code      string
+	*AbstractAuthenticationToken
+}
+
+// GetCredentials 凭证信息
+func (token *SocialAuthenticationToken) GetCredentials() string {
+	return token.Code
+}
+
+// GetPrincipal 身份验证的主体
+func (token *SocialAuthenticationToken) GetPrincipal() interface{} {
+	return token.Principal
+}
+
+// EraseCredentials 擦除敏感数据
+func (token *SocialAuthenticationToken) EraseCredentials() {
+	token.AbstractAuthenticationToken.EraseCredentials()
+	token.EraseSecret(token.Principal)
+	token.Code = ""
+}
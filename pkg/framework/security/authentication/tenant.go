@@ -0,0 +1,59 @@
+package authentication
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/utils/maputil"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
+)
+
+// TenantDetails 可提供所属租户的用户或认证详情
+type TenantDetails interface {
+	// 租户ID，未指定时返回空
+	GetTenantID() string
+}
+
+// PrincipalTenantID 主体所属租户，主体可以是用户信息或者已有的身份验证信息
+func PrincipalTenantID(principal interface{}) string {
+	switch value := principal.(type) {
+	case core.Authentication:
+		return PrincipalTenantID(value.GetPrincipal())
+	case TenantDetails:
+		return value.GetTenantID()
+	}
+	return ""
+}
+
+// DetailsTenantID 认证详情中指定的租户，支持 TenantDetails 和令牌请求参数
+func DetailsTenantID(details interface{}) string {
+	switch value := details.(type) {
+	case TenantDetails:
+		return value.GetTenantID()
+	case map[string]string:
+		return value[ParameterTenantID]
+	}
+	return ""
+}
+
+// WithTenantID 返回使用指定租户的认证详情副本，用于按原始认证的租户重新加载用户，
+// 认证详情中已经指定其他租户时返回异常
+func WithTenantID(details interface{}, tenantID string) (interface{}, error) {
+	if tenantID == "" {
+		return details, nil
+	}
+	if requested := DetailsTenantID(details); requested != "" && requested != tenantID {
+		return nil, errors.BadCredentials("Tenant ID does not match the original authentication")
+	}
+	switch value := details.(type) {
+	case nil:
+		return map[string]string{ParameterTenantID: tenantID}, nil
+	case map[string]string:
+		result := maputil.CopyStringStringMap(value)
+		result[ParameterTenantID] = tenantID
+		return result, nil
+	case *WebAuthenticationDetails:
+		result := *value
+		result.TenantID = tenantID
+		return &result, nil
+	}
+	return details, nil
+}
@@ -46,5 +46,7 @@ func (token *UsernamePasswordAuthenticationToken) GetPrincipal() interface{} {
 // EraseCredentials 擦除敏感数据
 func (token *UsernamePasswordAuthenticationToken) EraseCredentials() {
 	token.AbstractAuthenticationToken.EraseCredentials()
+	// 嵌入结构中无法获取当前 token 的 principal，需要单独擦除
+	token.EraseSecret(token.Principal)
 	token.Credentials = ""
 }
@@ -0,0 +1,70 @@
+package authentication
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+)
+
+// 多租户时通过请求头指定租户
+const (
+	// TenantIDHeader 租户ID请求头
+	TenantIDHeader = "Tenant-ID"
+	// ParameterTenantID 令牌请求参数中记录租户ID的 key
+	ParameterTenantID = "tenant_id"
+)
+
+// WebAuthenticationDetails Web 认证请求的详细信息
+type WebAuthenticationDetails struct {
+	// 客户端地址
+	RemoteAddress string
+	// 请求跟踪ID
+	TraceID string
+	// 请求头中指定的租户ID
+	TenantID string
+}
+
+// NewWebAuthenticationDetails 实例化
+func NewWebAuthenticationDetails(ctx *ingot.Context) *WebAuthenticationDetails {
+	return &WebAuthenticationDetails{
+		RemoteAddress: RemoteAddress(ctx.Request),
+		TraceID:       log.FromTraceIDContext(ctx.Request.Context()),
+		TenantID:      TenantID(ctx.Request),
+	}
+}
+
+// GetClientID 客户端ID，表单登录没有客户端
+func (d *WebAuthenticationDetails) GetClientID() string {
+	return ""
+}
+
+// GetRemoteAddress 客户端地址
+func (d *WebAuthenticationDetails) GetRemoteAddress() string {
+	return d.RemoteAddress
+}
+
+// GetTraceID 跟踪ID
+func (d *WebAuthenticationDetails) GetTraceID() string {
+	return d.TraceID
+}
+
+// GetTenantID 租户ID
+func (d *WebAuthenticationDetails) GetTenantID() string {
+	return d.TenantID
+}
+
+// TenantID 获取请求头中指定的租户ID
+func TenantID(req *http.Request) string {
+	return req.Header.Get(TenantIDHeader)
+}
+
+// RemoteAddress 获取连接的远端地址，不使用可被伪造的 X-Forwarded-For
+func RemoteAddress(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/web/ingot"
+	securityFilter "github.com/ingot-cloud/ingot-go/pkg/framework/security/web/filter"
+)
+
+// APISecurityFilterChains 调试端点，查看所有安全过滤器链
+const APISecurityFilterChains = "/debug/security/filter-chains"
+
+// enableDebugEndpoint 开启调试端点，按匹配顺序输出过滤器链、匹配器以及过滤器，
+// 端点和其他接口一样经过安全过滤器链
+func enableDebugEndpoint(engine *gin.Engine) {
+	ingotRouter := ingot.NewRouter(engine.Group(""))
+	ingotRouter.GET(APISecurityFilterChains, func(ctx *gin.Context) (interface{}, error) {
+		proxy, ok := webSecurity.Filter.(*securityFilter.ChainProxy)
+		if !ok {
+			return nil, errors.IllegalOperation("Security filter is not ChainProxy")
+		}
+		return proxy.Describe(), nil
+	})
+}
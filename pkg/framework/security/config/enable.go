@@ -22,8 +22,20 @@ func EnableWebSecurity(enableAuthorization, enableResource bool, securityContain
 		webConfigurers.Add(securityContainer.GetAuthorizationServerContainer().AuthorizationServerConfigurer)
 	}
 
+	// 开启表单登录，增加基于会话的安全配置
+	webContainer := securityContainer.GetWebContainer()
+	if webContainer.FormLoginConfig.Enable {
+		log.Info(">>>>>> 开启表单登录安全配置 <<<<<<")
+		webConfigurers.Add(webContainer.FormLoginConfigurer)
+	}
+
 	enableWebSecurity(engine, webConfigurers)
 
+	if securityContainer.GetCommonContainer().FilterChainDebugConfig.Enable {
+		log.Infof(">>>>>> 开启安全调试端点 %s <<<<<<", APISecurityFilterChains)
+		enableDebugEndpoint(engine)
+	}
+
 	// 增加端点，需要在设置完 WebSecurity 后在进行开启端点，下面的端点不会执行过滤器链
 	if enableAuthorization {
 		enableOAuth2Endpoint(securityContainer, engine)
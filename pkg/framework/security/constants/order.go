@@ -1,12 +1,45 @@
 package constants
 
 const (
+	// OrderFilterHeaders 安全响应头过滤器，最先执行
+	OrderFilterHeaders = 10
+	// OrderFilterCors 跨域过滤器，需要在认证之前处理预检请求
+	OrderFilterCors = 50
+	// OrderFilterSecurityContext 从会话中恢复身份信息
+	OrderFilterSecurityContext = 60
+	// OrderFilterCsrf CSRF过滤器，需要在注销和登录之前校验
+	OrderFilterCsrf = 65
+	// OrderFilterLogout 注销过滤器
+	OrderFilterLogout = 70
+	// OrderFilterX509 客户端证书预身份验证过滤器
+	OrderFilterX509 = 80
+	// OrderFilterPreAuthenticated 请求头预身份验证过滤器
+	OrderFilterPreAuthenticated = 90
+	// OrderFilterTLSClientAuth 客户端证书认证过滤器，需要在 basic 之前执行
+	OrderFilterTLSClientAuth = 95
 	// OrderFilterBasic BasicFilter排序索引
 	OrderFilterBasic = 100
+	// OrderFilterFormLogin 表单登录过滤器
+	OrderFilterFormLogin = 110
+	// OrderFilterApiKey API key 过滤器
+	OrderFilterApiKey = 150
 	// OrderFilterOAuth2 OAuth2过滤器排序序号
 	OrderFilterOAuth2 = 200
+	// OrderFilterRememberMe 记住我过滤器，其他认证方式均未认证时执行
+	OrderFilterRememberMe = 250
 	// OrderFilterAnonymous 序号
 	OrderFilterAnonymous = 300
 	// OrderFilterAuthenticationResult 认证结果过滤器
 	OrderFilterAuthenticationResult = 400
 )
+
+const (
+	// OrderWebSecurityDefault WebSecurityConfigurer 默认排序
+	OrderWebSecurityDefault = 100
+	// OrderWebSecurityAuthorizationServer 授权服务器安全配置排序
+	OrderWebSecurityAuthorizationServer = 100
+	// OrderWebSecurityFormLogin 表单登录安全配置排序，需要在资源服务器之前
+	OrderWebSecurityFormLogin = 500
+	// OrderWebSecurityResourceServer 资源服务器安全配置排序，匹配范围最大，需要排在最后
+	OrderWebSecurityResourceServer = 1000
+)
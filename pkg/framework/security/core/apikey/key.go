@@ -0,0 +1,59 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+// Separator key 中前缀和密钥的分隔符，key 格式为 prefix.secret
+const Separator = "."
+
+// 长度为十六进制字符数，前缀用于唯一查找 key，使用 12 字节随机数避免冲突
+const (
+	prefixLength = 24
+	secretLength = 32
+)
+
+// Generate 生成新的 key，返回完整 key、前缀以及需要保存的摘要
+func Generate() (key, prefix, hashed string, err error) {
+	prefix, err = randomString(prefixLength)
+	if err != nil {
+		return
+	}
+	secret, err := randomString(secretLength)
+	if err != nil {
+		return
+	}
+	return prefix + Separator + secret, prefix, Hash(secret), nil
+}
+
+// Split 拆分 key 为前缀和密钥
+func Split(key string) (prefix, secret string, ok bool) {
+	index := strings.Index(key, Separator)
+	if index <= 0 || index == len(key)-1 {
+		return "", "", false
+	}
+	return key[:index], key[index+1:], true
+}
+
+// Hash 计算密钥摘要，key 为高熵随机串，使用 SHA-256 即可
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Matches 校验密钥和摘要是否匹配
+func Matches(secret, hashed string) bool {
+	return subtle.ConstantTimeCompare([]byte(Hash(secret)), []byte(hashed)) == 1
+}
+
+func randomString(length int) (string, error) {
+	buf := make([]byte, length/2)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
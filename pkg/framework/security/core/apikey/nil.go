@@ -0,0 +1,16 @@
+package apikey
+
+import "time"
+
+// NilService 空实现
+type NilService struct{}
+
+// LoadByPrefix 根据前缀加载 key
+func (*NilService) LoadByPrefix(prefix string) (*Details, error) {
+	return nil, nil
+}
+
+// UpdateLastUsed 更新最后使用时间
+func (*NilService) UpdateLastUsed(id string, lastUsed time.Time) error {
+	return nil
+}
@@ -0,0 +1,32 @@
+package apikey
+
+import "time"
+
+// Details API key 信息，只保存 key 的摘要
+type Details struct {
+	ID string
+	// key 前缀，用于查找 key
+	Prefix string
+	// key 摘要
+	HashedKey string
+	// key 所属主体
+	Principal string
+	// 授权范围
+	Scopes []string
+	// 过期时间，零值时永不过期
+	ExpiresAt time.Time
+	Enabled   bool
+}
+
+// IsExpired 是否已过期
+func (d *Details) IsExpired(now time.Time) bool {
+	return !d.ExpiresAt.IsZero() && now.After(d.ExpiresAt)
+}
+
+// Service 用于加载 API key
+type Service interface {
+	// 根据前缀加载 key，不存在时返回 nil
+	LoadByPrefix(prefix string) (*Details, error)
+	// 更新最后使用时间
+	UpdateLastUsed(id string, lastUsed time.Time) error
+}
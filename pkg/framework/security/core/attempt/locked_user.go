@@ -0,0 +1,13 @@
+package attempt
+
+import "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+
+// LockedUser 因登录失败次数过多被临时锁定的用户
+type LockedUser struct {
+	userdetails.UserDetails
+}
+
+// IsAccountNonLocked 账户是否未锁定
+func (u *LockedUser) IsAccountNonLocked() bool {
+	return false
+}
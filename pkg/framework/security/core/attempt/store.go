@@ -0,0 +1,35 @@
+package attempt
+
+import "time"
+
+// Record 登录失败记录
+type Record struct {
+	// 当前锁定周期内的连续失败次数
+	Failures int
+	// 已触发锁定的次数，用于计算指数退避的锁定时长
+	Locks int
+	// 锁定截止时间
+	LockedUntil time.Time
+	// 记录过期时间，过期后失败次数和锁定次数全部清零
+	ExpiresAt time.Time
+}
+
+// IsLocked 是否处于锁定状态
+func (r *Record) IsLocked(now time.Time) bool {
+	return now.Before(r.LockedUntil)
+}
+
+// IsExpired 是否过期
+func (r *Record) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// Store 登录失败记录存储
+type Store interface {
+	// 保存记录，key 由用户标识和客户端地址组成
+	Save(key string, record *Record) error
+	// 获取记录，不存在或已过期时返回 nil
+	Get(key string) (*Record, error)
+	// 删除记录
+	Remove(key string) error
+}
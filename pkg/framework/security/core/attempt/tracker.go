@@ -0,0 +1,228 @@
+package attempt
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/event"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
+)
+
+// ParameterRemoteAddress 令牌请求参数中记录客户端地址的 key
+const ParameterRemoteAddress = event.ParameterRemoteAddress
+
+// 存储方式
+const (
+	StoreMemory = "memory"
+	StoreRedis  = "redis"
+)
+
+// Config 登录失败锁定配置
+type Config struct {
+	Enable bool `yaml:"enable"`
+	// 连续失败多少次后锁定
+	MaxAttempts int `yaml:"maxAttempts"`
+	// 首次锁定时长，单位秒，之后每次锁定时长翻倍
+	LockSeconds int `yaml:"lockSeconds"`
+	// 最大锁定时长，单位秒
+	MaxLockSeconds int `yaml:"maxLockSeconds"`
+	// 最后一次失败后多久清除失败记录，单位秒
+	ResetSeconds int `yaml:"resetSeconds"`
+	// 存储方式 memory, redis
+	Store string `yaml:"store"`
+}
+
+// GetMaxAttempts 锁定前允许的连续失败次数
+func (c Config) GetMaxAttempts() int {
+	if c.MaxAttempts <= 0 {
+		return 5
+	}
+	return c.MaxAttempts
+}
+
+// GetLockDuration 首次锁定时长
+func (c Config) GetLockDuration() time.Duration {
+	if c.LockSeconds <= 0 {
+		return time.Minute
+	}
+	return time.Duration(c.LockSeconds) * time.Second
+}
+
+// GetMaxLockDuration 最大锁定时长
+func (c Config) GetMaxLockDuration() time.Duration {
+	if c.MaxLockSeconds <= 0 {
+		return time.Hour
+	}
+	return time.Duration(c.MaxLockSeconds) * time.Second
+}
+
+// GetResetDuration 失败记录保留时长，不小于最大锁定时长
+func (c Config) GetResetDuration() time.Duration {
+	reset := 24 * time.Hour
+	if c.ResetSeconds > 0 {
+		reset = time.Duration(c.ResetSeconds) * time.Second
+	}
+	if max := c.GetMaxLockDuration(); reset < max {
+		return max
+	}
+	return reset
+}
+
+// NewStore 根据配置创建存储
+func NewStore(config Config, redisClient *store.RedisClient) Store {
+	if config.Store == StoreRedis {
+		return NewRedisStore(redisClient)
+	}
+	return NewMemoryStore()
+}
+
+// Tracker 登录失败跟踪，按用户标识和客户端地址记录连续失败次数，
+// 达到上限后临时锁定，锁定时长按指数退避增长，登录成功后清除记录
+type Tracker struct {
+	Config Config
+	Store  Store
+}
+
+// NewTracker 实例化
+func NewTracker(config Config, store Store) *Tracker {
+	return &Tracker{
+		Config: config,
+		Store:  store,
+	}
+}
+
+// Enabled 是否开启登录失败锁定
+func (t *Tracker) Enabled() bool {
+	return t != nil && t.Config.Enable
+}
+
+// IsLocked 指定用户在客户端地址下是否处于锁定状态，密码错误和多因素认证失败均会导致锁定
+func (t *Tracker) IsLocked(identity, remoteAddress string) (bool, error) {
+	if !t.Enabled() {
+		return false, nil
+	}
+	now := time.Now()
+	for _, k := range []string{key(identity, remoteAddress), secondFactorKey(identity, remoteAddress)} {
+		record, err := t.Store.Get(k)
+		if err != nil {
+			return false, err
+		}
+		if record != nil && record.IsLocked(now) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Apply 处于锁定状态时包装用户，通过 IsAccountNonLocked 体现锁定状态
+func (t *Tracker) Apply(user userdetails.UserDetails, locked bool) userdetails.UserDetails {
+	if !locked {
+		return user
+	}
+	return &LockedUser{UserDetails: user}
+}
+
+// LoginFailed 记录一次失败，达到上限后锁定
+func (t *Tracker) LoginFailed(identity, remoteAddress string) error {
+	if !t.Enabled() {
+		return nil
+	}
+	return t.failed(key(identity, remoteAddress), identity, remoteAddress)
+}
+
+// LoginSucceeded 登录成功，清除失败记录
+func (t *Tracker) LoginSucceeded(identity, remoteAddress string) error {
+	if !t.Enabled() {
+		return nil
+	}
+	return t.Store.Remove(key(identity, remoteAddress))
+}
+
+// SecondFactorFailed 记录一次多因素认证失败，单独记录，密码登录成功时不清除
+func (t *Tracker) SecondFactorFailed(identity, remoteAddress string) error {
+	if !t.Enabled() {
+		return nil
+	}
+	return t.failed(secondFactorKey(identity, remoteAddress), identity, remoteAddress)
+}
+
+// SecondFactorSucceeded 多因素认证成功，清除多因素认证的失败记录
+func (t *Tracker) SecondFactorSucceeded(identity, remoteAddress string) error {
+	if !t.Enabled() {
+		return nil
+	}
+	return t.Store.Remove(secondFactorKey(identity, remoteAddress))
+}
+
+func (t *Tracker) failed(k, identity, remoteAddress string) error {
+	record, err := t.Store.Get(k)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if record == nil {
+		record = &Record{}
+	}
+	record.Failures++
+	if record.Failures >= t.Config.GetMaxAttempts() {
+		record.Locks++
+		record.Failures = 0
+		record.LockedUntil = now.Add(t.lockDuration(record.Locks))
+		log.Warnf("Too many failed login attempts, lock identity=%s, remoteAddress=%s until %s",
+			identity, remoteAddress, record.LockedUntil.Format(time.RFC3339))
+	}
+	record.ExpiresAt = now.Add(t.Config.GetResetDuration())
+	return t.Store.Save(k, record)
+}
+
+// 第 n 次锁定的时长为 首次锁定时长 * 2^(n-1)，不超过最大锁定时长
+func (t *Tracker) lockDuration(locks int) time.Duration {
+	duration := t.Config.GetLockDuration()
+	max := t.Config.GetMaxLockDuration()
+	for i := 1; i < locks && duration < max; i++ {
+		duration *= 2
+	}
+	if duration > max {
+		return max
+	}
+	return duration
+}
+
+// Identifier 可提供唯一用户ID的用户
+type Identifier interface {
+	GetUserID() string
+}
+
+// Identity 用户标识，优先使用用户ID，否则使用忽略大小写和首尾空白的用户名，
+// 避免使用不同写法的用户名绕过锁定
+func Identity(user userdetails.UserDetails) string {
+	if u, ok := user.(Identifier); ok {
+		if id := u.GetUserID(); id != "" {
+			return "id:" + id
+		}
+	}
+	return strings.ToLower(strings.TrimSpace(user.GetUsername()))
+}
+
+// RemoteAddress 从认证详情中获取客户端地址，
+// 表单登录为 WebAuthenticationDetails，密码模式为令牌请求参数
+func RemoteAddress(details interface{}) string {
+	switch d := details.(type) {
+	case *authentication.WebAuthenticationDetails:
+		return d.RemoteAddress
+	case map[string]string:
+		return d[ParameterRemoteAddress]
+	}
+	return ""
+}
+
+func key(identity, remoteAddress string) string {
+	return identity + ":" + remoteAddress
+}
+
+func secondFactorKey(identity, remoteAddress string) string {
+	return "mfa:" + key(identity, remoteAddress)
+}
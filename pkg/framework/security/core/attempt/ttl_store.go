@@ -0,0 +1,53 @@
+package attempt
+
+import (
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
+)
+
+// TTLStore 基于 store.TTLStore 的存储，按记录过期时间设置 TTL
+type TTLStore struct {
+	store store.TTLStore
+}
+
+// NewTTLStore 实例化
+func NewTTLStore(ttlStore store.TTLStore) *TTLStore {
+	return &TTLStore{
+		store: ttlStore,
+	}
+}
+
+// NewMemoryStore 内存存储，只适用于单实例
+func NewMemoryStore() *TTLStore {
+	return NewTTLStore(store.NewMemoryTTLStore())
+}
+
+// NewRedisStore redis 存储，适用于多实例部署
+func NewRedisStore(client *store.RedisClient) *TTLStore {
+	return NewTTLStore(store.NewRedisTTLStore(client))
+}
+
+// Save 保存
+func (s *TTLStore) Save(key string, record *Record) error {
+	return s.store.Set(s.key(key), record, time.Until(record.ExpiresAt))
+}
+
+// Get 获取
+func (s *TTLStore) Get(key string) (*Record, error) {
+	var record Record
+	ok, err := s.store.Get(s.key(key), &record)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Remove 删除
+func (s *TTLStore) Remove(key string) error {
+	return s.store.Delete(s.key(key))
+}
+
+func (s *TTLStore) key(key string) string {
+	return "login:attempt:" + key
+}
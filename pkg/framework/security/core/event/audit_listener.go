@@ -0,0 +1,33 @@
+package event
+
+import "github.com/ingot-cloud/ingot-go/pkg/framework/log"
+
+// AuditLogListener 将认证事件写入审计日志
+type AuditLogListener struct{}
+
+// NewAuditLogListener 实例化
+func NewAuditLogListener() *AuditLogListener {
+	return &AuditLogListener{}
+}
+
+// OnEvent 处理事件
+func (*AuditLogListener) OnEvent(e *Event) {
+	entry := log.WithFields(log.Fields{
+		log.TagKey:      "[audit]",
+		log.TraceIDKey:  e.TraceID,
+		"event":         e.Type,
+		"principal":     e.Principal,
+		"clientID":      e.ClientID,
+		"remoteAddress": e.RemoteAddress,
+	})
+	if e.Error != nil {
+		entry.Warnf("Authentication event %s: %s", e.Type, e.Error.Error())
+		return
+	}
+	// 令牌认证成功事件数量较多，只在调试时输出
+	if e.Type == TokenAuthenticated {
+		entry.Debugf("Authentication event %s", e.Type)
+		return
+	}
+	entry.Infof("Authentication event %s", e.Type)
+}
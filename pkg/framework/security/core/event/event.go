@@ -0,0 +1,116 @@
+package event
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	coreErrors "github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
+	oauth2Errors "github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/errors"
+)
+
+// 令牌请求参数中记录审计信息的 key
+const (
+	ParameterClientID      = "client_id"
+	ParameterRemoteAddress = "remote_address"
+	ParameterTraceID       = "trace_id"
+)
+
+// Type 事件类型
+type Type string
+
+// 事件类型
+const (
+	AuthenticationSuccess Type = "authentication_success"
+	AuthenticationFailure Type = "authentication_failure"
+	BadCredentials        Type = "bad_credentials"
+	AccountLocked         Type = "account_locked"
+	AccessDenied          Type = "access_denied"
+	TokenIssued           Type = "token_issued"
+	TokenRefreshed        Type = "token_refreshed"
+	TokenRevoked          Type = "token_revoked"
+	// 资源服务器令牌认证成功，每个请求都会触发
+	TokenAuthenticated Type = "token_authenticated"
+)
+
+// Details 认证详情实现该接口后，事件中会携带对应的审计信息
+type Details interface {
+	// 客户端ID
+	GetClientID() string
+	// 客户端地址
+	GetRemoteAddress() string
+	// 跟踪ID
+	GetTraceID() string
+}
+
+// Event 认证事件
+type Event struct {
+	Type          Type
+	Principal     string
+	ClientID      string
+	RemoteAddress string
+	TraceID       string
+	Error         error
+	Time          time.Time
+	// 触发事件的身份验证信息，可能为 nil
+	Authentication core.Authentication
+}
+
+// NewEvent 创建事件，从认证详情中读取审计信息
+func NewEvent(t Type, auth core.Authentication, err error) *Event {
+	e := &Event{
+		Type:           t,
+		Error:          err,
+		Time:           time.Now(),
+		Authentication: auth,
+	}
+	if auth != nil {
+		e.Principal = auth.GetName(auth)
+		e.SetDetails(auth.GetDetails())
+	}
+	return e
+}
+
+// NewFailureEvent 创建认证失败事件，根据错误码区分事件类型
+func NewFailureEvent(auth core.Authentication, err error) *Event {
+	t := AuthenticationFailure
+	switch coreErrors.Unpack(err).Code {
+	case errors.BadCredentialsCode:
+		t = BadCredentials
+	case errors.AccountLockCode:
+		t = AccountLocked
+	case oauth2Errors.AccessDeniedCode, oauth2Errors.InsufficientScopeCode:
+		t = AccessDenied
+	}
+	return NewEvent(t, auth, err)
+}
+
+// TokenFingerprint 令牌指纹，事件中不记录令牌原文
+func TokenFingerprint(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "token:" + hex.EncodeToString(sum[:8])
+}
+
+// SetDetails 读取审计信息，支持 Details 和令牌请求参数
+func (e *Event) SetDetails(details interface{}) {
+	switch d := details.(type) {
+	case Details:
+		e.set(d.GetClientID(), d.GetRemoteAddress(), d.GetTraceID())
+	case map[string]string:
+		e.set(d[ParameterClientID], d[ParameterRemoteAddress], d[ParameterTraceID])
+	}
+}
+
+func (e *Event) set(clientID, remoteAddress, traceID string) {
+	if clientID != "" {
+		e.ClientID = clientID
+	}
+	if remoteAddress != "" {
+		e.RemoteAddress = remoteAddress
+	}
+	if traceID != "" {
+		e.TraceID = traceID
+	}
+}
@@ -0,0 +1,44 @@
+package event
+
+import "sync"
+
+// Listener 认证事件监听器
+type Listener interface {
+	// 处理事件，不应阻塞认证流程
+	OnEvent(e *Event)
+}
+
+// Publisher 认证事件发布者
+type Publisher interface {
+	// 发布事件
+	Publish(e *Event)
+}
+
+// DefaultPublisher 同步通知所有已注册的监听器
+type DefaultPublisher struct {
+	lock      sync.RWMutex
+	listeners []Listener
+}
+
+// NewPublisher 实例化
+func NewPublisher(listeners ...Listener) *DefaultPublisher {
+	return &DefaultPublisher{
+		listeners: listeners,
+	}
+}
+
+// AddListener 注册监听器
+func (p *DefaultPublisher) AddListener(listener Listener) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.listeners = append(p.listeners, listener)
+}
+
+// Publish 发布事件
+func (p *DefaultPublisher) Publish(e *Event) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	for _, listener := range p.listeners {
+		listener.OnEvent(e)
+	}
+}
@@ -0,0 +1,187 @@
+package mfa
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/attempt"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
+)
+
+// 请求参数
+const (
+	ParameterToken = "mfa_token"
+	ParameterCode  = "otp"
+)
+
+// 存储方式
+const (
+	StoreMemory = "memory"
+	StoreRedis  = "redis"
+)
+
+// Config 多因素认证配置
+type Config struct {
+	Enable bool `yaml:"enable"`
+	// 身份验证器应用中展示的发行方
+	Issuer string `yaml:"issuer"`
+	// mfa_token 有效时间，单位秒
+	TokenValiditySeconds int `yaml:"tokenValiditySeconds"`
+	// 每个 mfa_token 允许的最大尝试次数
+	MaxAttempts int `yaml:"maxAttempts"`
+	// 存储方式 memory, redis
+	Store string `yaml:"store"`
+}
+
+// GetTokenValidity mfa_token 有效时间
+func (c Config) GetTokenValidity() time.Duration {
+	if c.TokenValiditySeconds <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(c.TokenValiditySeconds) * time.Second
+}
+
+// GetMaxAttempts 最大尝试次数
+func (c Config) GetMaxAttempts() int {
+	if c.MaxAttempts <= 0 {
+		return 5
+	}
+	return c.MaxAttempts
+}
+
+// NewStore 根据配置创建存储
+func NewStore(config Config, redisClient *store.RedisClient) Store {
+	if config.Store == StoreRedis {
+		return NewRedisStore(redisClient)
+	}
+	return NewMemoryStore()
+}
+
+// Manager 多因素认证，第一步认证成功后签发 mfa_token，第二步使用 mfa_token 和动态码完成认证
+type Manager struct {
+	Config  Config
+	Service Service
+	Store   Store
+	// 动态码错误计入登录失败次数，达到上限后锁定用户
+	LoginAttemptTracker *attempt.Tracker
+}
+
+// NewManager 实例化
+func NewManager(config Config, service Service, store Store) *Manager {
+	return &Manager{
+		Config:  config,
+		Service: service,
+		Store:   store,
+	}
+}
+
+// Enabled 是否开启多因素认证
+func (m *Manager) Enabled() bool {
+	return m != nil && m.Config.Enable
+}
+
+// Challenge 第一步认证成功后调用，用户需要多因素认证时返回携带 mfa_token 的 mfa_required 错误
+func (m *Manager) Challenge(auth core.Authentication, clientID string) error {
+	if !m.Enabled() {
+		return nil
+	}
+	user, ok := auth.GetPrincipal().(userdetails.UserDetails)
+	if !ok {
+		return nil
+	}
+	username := user.GetUsername()
+	subject, required, err := m.Service.IsRequired(user)
+	if err != nil || !required {
+		return err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+	err = m.Store.Save(&Challenge{
+		Token:     token,
+		Username:  username,
+		Subject:   subject,
+		Identity:  attempt.Identity(user),
+		TenantID:  authentication.PrincipalTenantID(user),
+		ClientID:  clientID,
+		ExpiresAt: time.Now().Add(m.Config.GetTokenValidity()),
+	})
+	if err != nil {
+		return err
+	}
+	log.Debugf("Multi-factor authentication required, username=%s", username)
+	return errors.MfaRequired(token)
+}
+
+// Verify 校验 mfa_token 和动态码，成功后返回对应的登录请求，mfa_token 只能使用一次
+func (m *Manager) Verify(token, clientID, code, remoteAddress string) (*Challenge, error) {
+	if !m.Enabled() {
+		return nil, errors.MfaTokenInvalid("Multi-factor authentication is not enabled")
+	}
+	if token == "" || code == "" {
+		return nil, errors.MfaTokenInvalid("Missing mfa_token or otp")
+	}
+	challenge, err := m.Store.Get(token)
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil || challenge.ClientID != clientID {
+		return nil, errors.MfaTokenInvalid("Invalid mfa_token")
+	}
+
+	locked, err := m.LoginAttemptTracker.IsLocked(challenge.Identity, remoteAddress)
+	if err != nil {
+		return nil, err
+	}
+	if locked {
+		if err := m.Store.Remove(token); err != nil {
+			return nil, err
+		}
+		return nil, errors.AccountLock("User account is locked")
+	}
+
+	ok, err := m.Service.Verify(challenge.Subject, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := m.LoginAttemptTracker.SecondFactorFailed(challenge.Identity, remoteAddress); err != nil {
+			return nil, err
+		}
+		challenge.Attempts++
+		if challenge.Attempts >= m.Config.GetMaxAttempts() {
+			log.Warnf("Too many multi-factor authentication attempts, username=%s", challenge.Username)
+			err = m.Store.Remove(token)
+		} else {
+			err = m.Store.Save(challenge)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.MfaCodeInvalid("Invalid one-time password")
+	}
+
+	if err := m.Store.Remove(token); err != nil {
+		return nil, err
+	}
+	if err := m.LoginAttemptTracker.SecondFactorSucceeded(challenge.Identity, remoteAddress); err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+func newToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,44 @@
+package mfa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+// RecoveryCodeCount 默认生成的恢复码数量
+const RecoveryCodeCount = 10
+
+// GenerateRecoveryCodes 生成一次性恢复码，格式为 xxxxx-xxxxx
+func GenerateRecoveryCodes(count int) ([]string, error) {
+	codes := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		code := hex.EncodeToString(buf)
+		codes = append(codes, code[:5]+"-"+code[5:])
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode 计算恢复码摘要，只保存摘要
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// MatchRecoveryCode 在恢复码摘要中查找匹配项，返回下标，未匹配时返回 -1
+func MatchRecoveryCode(code string, hashed []string) int {
+	target := []byte(HashRecoveryCode(code))
+	for i, item := range hashed {
+		if subtle.ConstantTimeCompare([]byte(item), target) == 1 {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,24 @@
+package mfa
+
+import "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+
+// Service 用户的多因素认证信息
+type Service interface {
+	// 第一步认证成功的用户是否需要多因素认证，同时返回用户唯一标识，用于第二步校验
+	IsRequired(user userdetails.UserDetails) (subject string, required bool, err error)
+	// 校验指定用户的动态码或恢复码，动态码和恢复码都只能使用一次
+	Verify(subject string, code string) (bool, error)
+}
+
+// NilService 空实现，所有用户均不需要多因素认证
+type NilService struct{}
+
+// IsRequired 是否需要多因素认证
+func (*NilService) IsRequired(userdetails.UserDetails) (string, bool, error) {
+	return "", false, nil
+}
+
+// Verify 校验
+func (*NilService) Verify(string, string) (bool, error) {
+	return false, nil
+}
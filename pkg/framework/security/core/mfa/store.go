@@ -0,0 +1,34 @@
+package mfa
+
+import "time"
+
+// Challenge 等待多因素认证的登录请求，mfa_token 为其标识
+type Challenge struct {
+	Token    string
+	Username string
+	// 用户唯一标识，由 Service 提供
+	Subject string
+	// 登录失败次数记录使用的用户标识
+	Identity string
+	// 用户所属租户，第二步按该租户重新加载用户
+	TenantID string
+	// 发起登录的客户端，表单登录时为空
+	ClientID  string
+	Attempts  int
+	ExpiresAt time.Time
+}
+
+// IsExpired 是否已经过期
+func (c *Challenge) IsExpired(now time.Time) bool {
+	return c.ExpiresAt.Before(now)
+}
+
+// Store 保存等待认证的登录请求
+type Store interface {
+	// 保存，已存在时覆盖
+	Save(challenge *Challenge) error
+	// 获取，不存在或已过期时返回 nil
+	Get(token string) (*Challenge, error)
+	// 删除
+	Remove(token string) error
+}
@@ -0,0 +1,53 @@
+package mfa
+
+import (
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
+)
+
+// TTLStore 基于 store.TTLStore 的存储，按登录请求过期时间设置 TTL
+type TTLStore struct {
+	store store.TTLStore
+}
+
+// NewTTLStore 实例化
+func NewTTLStore(ttlStore store.TTLStore) *TTLStore {
+	return &TTLStore{
+		store: ttlStore,
+	}
+}
+
+// NewMemoryStore 内存存储，只适用于单实例
+func NewMemoryStore() *TTLStore {
+	return NewTTLStore(store.NewMemoryTTLStore())
+}
+
+// NewRedisStore redis 存储，适用于多实例部署
+func NewRedisStore(client *store.RedisClient) *TTLStore {
+	return NewTTLStore(store.NewRedisTTLStore(client))
+}
+
+// Save 保存
+func (s *TTLStore) Save(challenge *Challenge) error {
+	return s.store.Set(s.key(challenge.Token), challenge, time.Until(challenge.ExpiresAt))
+}
+
+// Get 获取
+func (s *TTLStore) Get(token string) (*Challenge, error) {
+	var challenge Challenge
+	ok, err := s.store.Get(s.key(token), &challenge)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// Remove 删除
+func (s *TTLStore) Remove(token string) error {
+	return s.store.Delete(s.key(token))
+}
+
+func (s *TTLStore) key(token string) string {
+	return "mfa:challenge:" + token
+}
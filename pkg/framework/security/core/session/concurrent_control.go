@@ -0,0 +1,148 @@
+package session
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
+)
+
+// 超出最大会话数量时的处理策略
+const (
+	// StrategyRejectNew 拒绝新的登录
+	StrategyRejectNew = "reject_new"
+	// StrategyExpireOldest 使最早的会话失效
+	StrategyExpireOldest = "expire_oldest"
+)
+
+// 注册表存储方式
+const (
+	RegistryMemory = "memory"
+	RegistryRedis  = "redis"
+)
+
+// Config 并发会话控制配置
+type Config struct {
+	Enable bool `yaml:"enable"`
+	// 每个用户最大会话（令牌）数量，小于等于0时不限制
+	MaximumSessions int `yaml:"maximumSessions"`
+	// 每个用户在每个客户端的最大会话（令牌）数量，小于等于0时不限制
+	MaximumSessionsPerClient int `yaml:"maximumSessionsPerClient"`
+	// 超出限制时的处理策略 reject_new, expire_oldest
+	Strategy string `yaml:"strategy"`
+	// 注册表存储方式 memory, redis
+	Registry string `yaml:"registry"`
+}
+
+// NewRegistry 根据配置创建会话注册表
+func NewRegistry(config Config, redisClient *store.RedisClient) Registry {
+	if config.Registry == RegistryRedis {
+		return NewRedisRegistry(redisClient)
+	}
+	return NewMemoryRegistry()
+}
+
+// ConcurrentControl 并发会话控制
+type ConcurrentControl struct {
+	Config   Config
+	Registry Registry
+}
+
+// NewConcurrentControl 实例化
+func NewConcurrentControl(config Config, registry Registry) *ConcurrentControl {
+	return &ConcurrentControl{
+		Config:   config,
+		Registry: registry,
+	}
+}
+
+// Enabled 是否开启并发控制
+func (c *ConcurrentControl) Enabled() bool {
+	return c != nil && c.Config.Enable
+}
+
+// OnAuthentication 认证成功后校验会话数量并注册新的会话
+func (c *ConcurrentControl) OnAuthentication(info *Information) error {
+	if !c.Enabled() {
+		return nil
+	}
+	sessions, err := c.Registry.GetAllSessions(info.Principal, false)
+	if err != nil {
+		return err
+	}
+
+	sessions, err = c.checkMaximum(sessions, c.Config.MaximumSessions)
+	if err != nil {
+		return err
+	}
+
+	if c.Config.MaximumSessionsPerClient > 0 {
+		var clientSessions []*Information
+		for _, item := range sessions {
+			if item.ClientID == info.ClientID {
+				clientSessions = append(clientSessions, item)
+			}
+		}
+		if _, err := c.checkMaximum(clientSessions, c.Config.MaximumSessionsPerClient); err != nil {
+			return err
+		}
+	}
+
+	if info.LastRequest.IsZero() {
+		info.LastRequest = time.Now()
+	}
+	return c.Registry.RegisterNewSession(info)
+}
+
+// IsExpired 会话是否已被并发控制标记失效
+func (c *ConcurrentControl) IsExpired(sessionID string) (bool, error) {
+	if !c.Enabled() {
+		return false, nil
+	}
+	info, err := c.Registry.GetSessionInformation(sessionID)
+	if err != nil || info == nil {
+		return false, err
+	}
+	return info.Expired, nil
+}
+
+// Refresh 刷新会话
+func (c *ConcurrentControl) Refresh(sessionID string, expiresAt time.Time) error {
+	if !c.Enabled() {
+		return nil
+	}
+	return c.Registry.Refresh(sessionID, expiresAt)
+}
+
+// Remove 删除会话
+func (c *ConcurrentControl) Remove(sessionID string) error {
+	if !c.Enabled() {
+		return nil
+	}
+	return c.Registry.RemoveSessionInformation(sessionID)
+}
+
+// 校验会话数量，返回剩余有效的会话
+func (c *ConcurrentControl) checkMaximum(sessions []*Information, maximum int) ([]*Information, error) {
+	if maximum <= 0 || len(sessions) < maximum {
+		return sessions, nil
+	}
+	if c.Config.Strategy == StrategyRejectNew {
+		return nil, errors.MaximumSessionsExceeded("Maximum sessions of ", strconv.Itoa(maximum), " for this principal exceeded")
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].LastRequest.Before(sessions[j].LastRequest)
+	})
+	count := len(sessions) - maximum + 1
+	for _, item := range sessions[:count] {
+		log.Debugf("Expire session %s of principal %s, maximum sessions exceeded", item.SessionID, item.Principal)
+		if err := c.Registry.ExpireNow(item.SessionID); err != nil {
+			return nil, err
+		}
+	}
+	return sessions[count:], nil
+}
@@ -0,0 +1,116 @@
+package session
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryRegistry 内存会话注册表，只适用于单实例
+type MemoryRegistry struct {
+	lock       sync.RWMutex
+	sessions   map[string]Information
+	principals map[string]map[string]struct{}
+}
+
+// NewMemoryRegistry 实例化
+func NewMemoryRegistry() *MemoryRegistry {
+	return &MemoryRegistry{
+		sessions:   make(map[string]Information),
+		principals: make(map[string]map[string]struct{}),
+	}
+}
+
+// GetAllSessions 获取主体的所有会话
+func (r *MemoryRegistry) GetAllSessions(principal string, includeExpired bool) ([]*Information, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := time.Now()
+	var result []*Information
+	for id := range r.principals[principal] {
+		info, ok := r.sessions[id]
+		if !ok || info.IsTimeout(now) {
+			r.remove(id, principal)
+			continue
+		}
+		if info.Expired && !includeExpired {
+			continue
+		}
+		copied := info
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+// GetSessionInformation 获取会话信息
+func (r *MemoryRegistry) GetSessionInformation(sessionID string) (*Information, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	info, ok := r.sessions[sessionID]
+	if !ok || info.IsTimeout(time.Now()) {
+		return nil, nil
+	}
+	return &info, nil
+}
+
+// RegisterNewSession 注册新的会话
+func (r *MemoryRegistry) RegisterNewSession(info *Information) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.sessions[info.SessionID] = *info
+	ids, ok := r.principals[info.Principal]
+	if !ok {
+		ids = make(map[string]struct{})
+		r.principals[info.Principal] = ids
+	}
+	ids[info.SessionID] = struct{}{}
+	return nil
+}
+
+// Refresh 刷新会话
+func (r *MemoryRegistry) Refresh(sessionID string, expiresAt time.Time) error {
+	r.update(sessionID, func(info *Information) {
+		info.LastRequest = time.Now()
+		info.ExpiresAt = expiresAt
+	})
+	return nil
+}
+
+// ExpireNow 标记会话失效
+func (r *MemoryRegistry) ExpireNow(sessionID string) error {
+	r.update(sessionID, func(info *Information) {
+		info.Expired = true
+	})
+	return nil
+}
+
+// RemoveSessionInformation 删除会话
+func (r *MemoryRegistry) RemoveSessionInformation(sessionID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if info, ok := r.sessions[sessionID]; ok {
+		r.remove(sessionID, info.Principal)
+	}
+	return nil
+}
+
+func (r *MemoryRegistry) update(sessionID string, fn func(*Information)) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	info, ok := r.sessions[sessionID]
+	if !ok {
+		return
+	}
+	fn(&info)
+	r.sessions[sessionID] = info
+}
+
+func (r *MemoryRegistry) remove(sessionID, principal string) {
+	delete(r.sessions, sessionID)
+	if ids, ok := r.principals[principal]; ok {
+		delete(ids, sessionID)
+		if len(ids) == 0 {
+			delete(r.principals, principal)
+		}
+	}
+}
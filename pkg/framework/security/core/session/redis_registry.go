@@ -0,0 +1,128 @@
+package session
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
+)
+
+// RedisRegistry redis 会话注册表，会话信息按过期时间设置 TTL，同时按主体维护会话ID集合
+type RedisRegistry struct {
+	client *store.RedisClient
+}
+
+// NewRedisRegistry 实例化
+func NewRedisRegistry(client *store.RedisClient) *RedisRegistry {
+	return &RedisRegistry{
+		client: client,
+	}
+}
+
+// GetAllSessions 获取主体的所有会话
+func (r *RedisRegistry) GetAllSessions(principal string, includeExpired bool) ([]*Information, error) {
+	principalKey := r.principalKey(principal)
+	ids, err := r.client.Cli.SMembers(principalKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	var result []*Information
+	for _, id := range ids {
+		info, err := r.GetSessionInformation(id)
+		if err != nil {
+			return nil, err
+		}
+		if info == nil {
+			// 会话信息已过期，清理集合
+			if err := r.client.Cli.SRem(principalKey, id).Err(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if info.Expired && !includeExpired {
+			continue
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+// GetSessionInformation 获取会话信息
+func (r *RedisRegistry) GetSessionInformation(sessionID string) (*Information, error) {
+	data, err := r.client.Cli.Get(r.sessionKey(sessionID)).Bytes()
+	if err == store.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var info Information
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// RegisterNewSession 注册新的会话
+func (r *RedisRegistry) RegisterNewSession(info *Information) error {
+	if err := r.save(info); err != nil {
+		return err
+	}
+	return r.client.Cli.SAdd(r.principalKey(info.Principal), info.SessionID).Err()
+}
+
+// Refresh 刷新会话
+func (r *RedisRegistry) Refresh(sessionID string, expiresAt time.Time) error {
+	info, err := r.GetSessionInformation(sessionID)
+	if err != nil || info == nil {
+		return err
+	}
+	info.LastRequest = time.Now()
+	info.ExpiresAt = expiresAt
+	return r.save(info)
+}
+
+// ExpireNow 标记会话失效
+func (r *RedisRegistry) ExpireNow(sessionID string) error {
+	info, err := r.GetSessionInformation(sessionID)
+	if err != nil || info == nil {
+		return err
+	}
+	info.Expired = true
+	return r.save(info)
+}
+
+// RemoveSessionInformation 删除会话
+func (r *RedisRegistry) RemoveSessionInformation(sessionID string) error {
+	info, err := r.GetSessionInformation(sessionID)
+	if err != nil || info == nil {
+		return err
+	}
+	if err := r.client.Cli.SRem(r.principalKey(info.Principal), sessionID).Err(); err != nil {
+		return err
+	}
+	return r.client.Cli.Del(r.sessionKey(sessionID)).Err()
+}
+
+func (r *RedisRegistry) save(info *Information) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	var ttl time.Duration
+	if !info.ExpiresAt.IsZero() {
+		ttl = time.Until(info.ExpiresAt)
+		if ttl <= 0 {
+			return r.client.Cli.Del(r.sessionKey(info.SessionID)).Err()
+		}
+	}
+	return r.client.Cli.Set(r.sessionKey(info.SessionID), data, ttl).Err()
+}
+
+func (r *RedisRegistry) sessionKey(sessionID string) string {
+	return r.client.KeyPrefix + "session-registry:session:" + sessionID
+}
+
+func (r *RedisRegistry) principalKey(principal string) string {
+	return r.client.KeyPrefix + "session-registry:principal:" + principal
+}
@@ -0,0 +1,50 @@
+package session
+
+import (
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+)
+
+// Information 主体的会话信息，表单登录时为会话ID，签发令牌时为令牌ID
+type Information struct {
+	SessionID   string
+	Principal   string
+	ClientID    string
+	LastRequest time.Time
+	// 过期时间，零值代表不过期
+	ExpiresAt time.Time
+	// 是否已被并发控制标记失效
+	Expired bool
+}
+
+// IsTimeout 是否已经超时
+func (i *Information) IsTimeout(now time.Time) bool {
+	return !i.ExpiresAt.IsZero() && i.ExpiresAt.Before(now)
+}
+
+// PrincipalName 会话注册表中的主体，不同租户中的用户名可能相同，主体属于租户时使用租户ID和用户名
+func PrincipalName(auth core.Authentication) string {
+	name := auth.GetName(auth)
+	if tenantID := authentication.PrincipalTenantID(auth); tenantID != "" {
+		return tenantID + ":" + name
+	}
+	return name
+}
+
+// Registry 会话注册表
+type Registry interface {
+	// 获取主体的所有会话，includeExpired 为 false 时不包含已被标记失效的会话
+	GetAllSessions(principal string, includeExpired bool) ([]*Information, error)
+	// 获取会话信息，不存在时返回 nil
+	GetSessionInformation(sessionID string) (*Information, error)
+	// 注册新的会话
+	RegisterNewSession(info *Information) error
+	// 刷新最后请求时间和过期时间
+	Refresh(sessionID string, expiresAt time.Time) error
+	// 标记会话失效
+	ExpireNow(sessionID string) error
+	// 删除会话
+	RemoveSessionInformation(sessionID string) error
+}
@@ -0,0 +1,198 @@
+package sms
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
+)
+
+// 请求参数
+const (
+	ParameterPhone = "mobile"
+	ParameterCode  = "sms_code"
+)
+
+// 存储方式
+const (
+	StoreMemory = "memory"
+	StoreRedis  = "redis"
+)
+
+// Config 短信验证码登录配置
+type Config struct {
+	Enable bool `yaml:"enable"`
+	// 验证码位数
+	CodeLength int `yaml:"codeLength"`
+	// 验证码有效时间，单位秒
+	CodeValiditySeconds int `yaml:"codeValiditySeconds"`
+	// 每个验证码允许的最大尝试次数
+	MaxAttempts int `yaml:"maxAttempts"`
+	// 同一手机号重新发送的间隔，单位秒
+	ResendSeconds int `yaml:"resendSeconds"`
+	// 存储方式 redis, memory，默认 redis
+	Store string `yaml:"store"`
+}
+
+// GetCodeLength 验证码位数
+func (c Config) GetCodeLength() int {
+	if c.CodeLength <= 0 {
+		return 6
+	}
+	return c.CodeLength
+}
+
+// GetCodeValidity 验证码有效时间
+func (c Config) GetCodeValidity() time.Duration {
+	if c.CodeValiditySeconds <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(c.CodeValiditySeconds) * time.Second
+}
+
+// GetMaxAttempts 最大尝试次数
+func (c Config) GetMaxAttempts() int {
+	if c.MaxAttempts <= 0 {
+		return 5
+	}
+	return c.MaxAttempts
+}
+
+// GetResendInterval 重新发送的间隔
+func (c Config) GetResendInterval() time.Duration {
+	if c.ResendSeconds <= 0 {
+		return time.Minute
+	}
+	return time.Duration(c.ResendSeconds) * time.Second
+}
+
+// NewStore 根据配置创建存储
+func NewStore(config Config, redisClient *store.RedisClient) Store {
+	if config.Store == StoreMemory {
+		return NewMemoryStore()
+	}
+	return NewRedisStore(redisClient)
+}
+
+// CodeService 短信验证码，发送后在有效期内可以校验一次，
+// 错误次数达到上限后失效，同一手机号发送间隔不能小于配置的间隔
+type CodeService struct {
+	Config          Config
+	Store           Store
+	Sender          Sender
+	PhoneNormalizer PhoneNormalizer
+}
+
+// NewCodeService 实例化
+func NewCodeService(config Config, store Store, sender Sender, normalizer PhoneNormalizer) *CodeService {
+	return &CodeService{
+		Config:          config,
+		Store:           store,
+		Sender:          sender,
+		PhoneNormalizer: normalizer,
+	}
+}
+
+// Enabled 是否开启短信验证码登录
+func (s *CodeService) Enabled() bool {
+	return s != nil && s.Config.Enable
+}
+
+// Send 生成并发送验证码
+func (s *CodeService) Send(phone string) error {
+	if !s.Enabled() {
+		return errors.SmsCodeInvalid("SMS login is not enabled")
+	}
+	phone = s.normalize(phone)
+	if phone == "" {
+		return errors.SmsCodeInvalid("Missing mobile")
+	}
+	now := time.Now()
+	last, err := s.Store.Get(phone)
+	if err != nil {
+		return err
+	}
+	if last != nil && now.Before(last.SentAt.Add(s.Config.GetResendInterval())) {
+		return errors.SmsCodeTooFrequent("SMS code was sent too frequently")
+	}
+
+	code, err := newCode(s.Config.GetCodeLength())
+	if err != nil {
+		return err
+	}
+	err = s.Store.Save(&Code{
+		Phone:     phone,
+		Hash:      hashCode(code),
+		SentAt:    now,
+		ExpiresAt: now.Add(s.Config.GetCodeValidity()),
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.Sender.Send(phone, code); err != nil {
+		log.Warnf("Failed to send SMS code, phone=%s, err=%s", phone, err.Error())
+		s.Store.Remove(phone)
+		return err
+	}
+	return nil
+}
+
+// Verify 校验验证码，验证码只能使用一次
+func (s *CodeService) Verify(phone, code string) error {
+	if !s.Enabled() {
+		return errors.SmsCodeInvalid("SMS login is not enabled")
+	}
+	phone = s.normalize(phone)
+	if phone == "" || code == "" {
+		return errors.SmsCodeInvalid("Missing mobile or sms_code")
+	}
+	saved, err := s.Store.Get(phone)
+	if err != nil {
+		return err
+	}
+	if saved == nil || saved.Attempts >= s.Config.GetMaxAttempts() {
+		return errors.SmsCodeInvalid("SMS code is invalid or expired")
+	}
+	if subtle.ConstantTimeCompare([]byte(saved.Hash), []byte(hashCode(code))) != 1 {
+		// 保留失效的验证码直到过期，避免绕过发送间隔
+		saved.Attempts++
+		if err := s.Store.Save(saved); err != nil {
+			return err
+		}
+		return errors.SmsCodeInvalid("Bad SMS code")
+	}
+	return s.Store.Remove(phone)
+}
+
+// 使用规范化后的手机号作为 key
+func (s *CodeService) normalize(phone string) string {
+	if s.PhoneNormalizer == nil {
+		return NewDefaultPhoneNormalizer().Normalize(phone)
+	}
+	return s.PhoneNormalizer.Normalize(phone)
+}
+
+func newCode(length int) (string, error) {
+	var b strings.Builder
+	max := big.NewInt(10)
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(n.String())
+	}
+	return b.String(), nil
+}
+
+func hashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,25 @@
+package sms
+
+import "strings"
+
+// 手机号中常见的分隔符
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+
+// PhoneNormalizer 手机号规范化，同一手机号的不同写法使用同一个验证码
+type PhoneNormalizer interface {
+	// 返回规范化后的手机号
+	Normalize(phone string) string
+}
+
+// DefaultPhoneNormalizer 去除首尾空白和常见分隔符，区号等规则由应用注入实现
+type DefaultPhoneNormalizer struct{}
+
+// NewDefaultPhoneNormalizer 实例化
+func NewDefaultPhoneNormalizer() *DefaultPhoneNormalizer {
+	return &DefaultPhoneNormalizer{}
+}
+
+// Normalize 规范化
+func (*DefaultPhoneNormalizer) Normalize(phone string) string {
+	return phoneSeparators.Replace(strings.TrimSpace(phone))
+}
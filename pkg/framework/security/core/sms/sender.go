@@ -0,0 +1,34 @@
+package sms
+
+import (
+	coreErrors "github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+)
+
+// Sender 短信发送，接入短信服务商时实现该接口
+type Sender interface {
+	// 发送验证码
+	Send(phone, code string) error
+}
+
+// NilSender 空实现，未接入短信服务商时发送失败
+type NilSender struct{}
+
+// Send 发送验证码
+func (*NilSender) Send(string, string) error {
+	return coreErrors.InternalServer("SMS sender is not configured")
+}
+
+// LogSender 只将验证码写入日志，用于本地开发时手动注入，不能用于生产环境
+type LogSender struct{}
+
+// NewLogSender 实例化
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+// Send 发送验证码
+func (*LogSender) Send(phone, code string) error {
+	log.Infof("[sms] phone=%s, code=%s", phone, code)
+	return nil
+}
@@ -0,0 +1,17 @@
+package sms
+
+import "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+
+// UserDetailsService 根据手机号加载用户
+type UserDetailsService interface {
+	// 根据手机号和认证请求的附加信息加载用户，用户不存在时返回 nil
+	LoadUserByPhone(phone string, details interface{}) (userdetails.UserDetails, error)
+}
+
+// NilUserDetailsService 空实现，所有手机号均没有对应用户
+type NilUserDetailsService struct{}
+
+// LoadUserByPhone 根据手机号加载用户
+func (*NilUserDetailsService) LoadUserByPhone(string, interface{}) (userdetails.UserDetails, error) {
+	return nil, nil
+}
@@ -0,0 +1,27 @@
+package sms
+
+import "time"
+
+// Code 已发送的验证码，只保存摘要
+type Code struct {
+	Phone     string
+	Hash      string
+	Attempts  int
+	SentAt    time.Time
+	ExpiresAt time.Time
+}
+
+// IsExpired 是否已经过期
+func (c *Code) IsExpired(now time.Time) bool {
+	return c.ExpiresAt.Before(now)
+}
+
+// Store 保存已发送的验证码，每个手机号只保留最后一次发送的验证码
+type Store interface {
+	// 保存，已存在时覆盖
+	Save(code *Code) error
+	// 获取，不存在或已过期时返回 nil
+	Get(phone string) (*Code, error)
+	// 删除
+	Remove(phone string) error
+}
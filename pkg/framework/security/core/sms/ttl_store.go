@@ -0,0 +1,53 @@
+package sms
+
+import (
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
+)
+
+// TTLStore 基于 store.TTLStore 的存储，按验证码过期时间设置 TTL
+type TTLStore struct {
+	store store.TTLStore
+}
+
+// NewTTLStore 实例化
+func NewTTLStore(ttlStore store.TTLStore) *TTLStore {
+	return &TTLStore{
+		store: ttlStore,
+	}
+}
+
+// NewMemoryStore 内存存储，只适用于单实例
+func NewMemoryStore() *TTLStore {
+	return NewTTLStore(store.NewMemoryTTLStore())
+}
+
+// NewRedisStore redis 存储，适用于多实例部署
+func NewRedisStore(client *store.RedisClient) *TTLStore {
+	return NewTTLStore(store.NewRedisTTLStore(client))
+}
+
+// Save 保存
+func (s *TTLStore) Save(code *Code) error {
+	return s.store.Set(s.key(code.Phone), code, time.Until(code.ExpiresAt))
+}
+
+// Get 获取
+func (s *TTLStore) Get(phone string) (*Code, error) {
+	var code Code
+	ok, err := s.store.Get(s.key(phone), &code)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// Remove 删除
+func (s *TTLStore) Remove(phone string) error {
+	return s.store.Delete(s.key(phone))
+}
+
+func (s *TTLStore) key(phone string) string {
+	return "login:sms:" + phone
+}
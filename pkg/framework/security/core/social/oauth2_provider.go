@@ -0,0 +1,149 @@
+package social
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
+)
+
+// 用户信息中可以作为唯一标识和名称的字段，按顺序取第一个非空值
+var (
+	idAttributes   = []string{"sub", "id", "openid", "unionid"}
+	nameAttributes = []string{"name", "preferred_username", "login", "nickname"}
+)
+
+// OAuth2Provider 通用 OAuth2/OIDC 实现，使用授权码换取 access_token 后请求用户信息接口
+type OAuth2Provider struct {
+	Config     Config
+	HTTPClient *http.Client
+}
+
+// NewOAuth2Provider 实例化
+func NewOAuth2Provider(config Config) *OAuth2Provider {
+	return &OAuth2Provider{
+		Config: config,
+		HTTPClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange 使用授权码换取用户信息
+func (p *OAuth2Provider) Exchange(code string) (*UserInfo, error) {
+	if code == "" {
+		return nil, errors.SocialAuthFailed("Missing code")
+	}
+	if p.Config.TokenURL == "" || p.Config.UserInfoURL == "" {
+		return nil, errors.SocialAuthFailed("Token or user info endpoint of ", p.Config.Type, " is not configured")
+	}
+
+	accessToken, err := p.exchangeToken(code)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := p.userInfo(accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &UserInfo{
+		ID:         firstAttribute(attributes, idAttributes),
+		Name:       firstAttribute(attributes, nameAttributes),
+		Email:      firstAttribute(attributes, []string{"email"}),
+		Attributes: attributes,
+	}
+	if info.ID == "" {
+		return nil, errors.SocialAuthFailed("User info of ", p.Config.Type, " has no identifier")
+	}
+	return info, nil
+}
+
+func (p *OAuth2Provider) exchangeToken(code string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.Config.RedirectURL)
+	form.Set("client_id", p.Config.ClientID)
+	form.Set("client_secret", p.Config.ClientSecret)
+
+	req, err := http.NewRequest(http.MethodPost, p.Config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var result tokenResponse
+	if err := p.do(req, &result); err != nil {
+		return "", err
+	}
+	if result.Error != "" {
+		return "", errors.SocialAuthFailed(result.Error, ": ", result.ErrorDescription)
+	}
+	if result.AccessToken == "" {
+		return "", errors.SocialAuthFailed("No access_token returned by ", p.Config.Type)
+	}
+	return result.AccessToken, nil
+}
+
+func (p *OAuth2Provider) userInfo(accessToken string) (map[string]interface{}, error) {
+	req, err := http.NewRequest(http.MethodGet, p.Config.UserInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	attributes := make(map[string]interface{})
+	if err := p.do(req, &attributes); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+func (p *OAuth2Provider) do(req *http.Request, result interface{}) error {
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return errors.SocialAuthFailed("Request ", p.Config.Type, " failed: ", err.Error())
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	// 数字类型的 ID 保持原样，避免转为浮点数
+	decoder.UseNumber()
+	if err := decoder.Decode(result); err != nil {
+		return errors.SocialAuthFailed("Invalid response from ", p.Config.Type, ", status ", resp.Status)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		if token, ok := result.(*tokenResponse); ok && token.Error != "" {
+			return errors.SocialAuthFailed(token.Error, ": ", token.ErrorDescription)
+		}
+		return errors.SocialAuthFailed("Request ", p.Config.Type, " failed, status ", resp.Status)
+	}
+	return nil
+}
+
+func firstAttribute(attributes map[string]interface{}, keys []string) string {
+	for _, key := range keys {
+		value, ok := attributes[key]
+		if !ok || value == nil {
+			continue
+		}
+		if s := fmt.Sprint(value); s != "" {
+			return s
+		}
+	}
+	return ""
+}
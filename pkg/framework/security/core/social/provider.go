@@ -0,0 +1,54 @@
+package social
+
+import "sync"
+
+// UserInfo 第三方平台返回的用户信息
+type UserInfo struct {
+	// 第三方平台中用户的唯一标识
+	ID    string
+	Name  string
+	Email string
+	// 用户信息接口返回的原始数据
+	Attributes map[string]interface{}
+}
+
+// Provider 第三方登录提供者，使用授权码换取第三方平台的用户信息
+type Provider interface {
+	Exchange(code string) (*UserInfo, error)
+}
+
+// Config 第三方平台配置
+type Config struct {
+	Type         string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	TokenURL     string
+	UserInfoURL  string
+}
+
+// Factory 根据配置创建 Provider
+type Factory func(config Config) Provider
+
+var (
+	lock      sync.RWMutex
+	factories = make(map[string]Factory)
+)
+
+// RegisterProvider 注册指定类型的 Provider，第三方平台不兼容标准 OAuth2 时使用
+func RegisterProvider(socialType string, factory Factory) {
+	lock.Lock()
+	defer lock.Unlock()
+	factories[socialType] = factory
+}
+
+// NewProvider 创建 Provider，未注册的类型使用通用的 OAuth2 实现
+func NewProvider(config Config) Provider {
+	lock.RLock()
+	factory, ok := factories[config.Type]
+	lock.RUnlock()
+	if ok {
+		return factory(config)
+	}
+	return NewOAuth2Provider(config)
+}
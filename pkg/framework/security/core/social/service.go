@@ -0,0 +1,23 @@
+package social
+
+import "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+
+// 请求参数
+const (
+	ParameterType = "social_type"
+	ParameterCode = "code"
+)
+
+// UserDetailsService 根据第三方平台的授权码加载已绑定的用户
+type UserDetailsService interface {
+	// 使用授权码换取第三方用户信息并加载绑定的用户，未绑定时返回 nil
+	LoadUserBySocial(socialType, code string, details interface{}) (userdetails.UserDetails, error)
+}
+
+// NilUserDetailsService 空实现，不支持第三方登录
+type NilUserDetailsService struct{}
+
+// LoadUserBySocial 加载用户
+func (*NilUserDetailsService) LoadUserBySocial(string, string, interface{}) (userdetails.UserDetails, error) {
+	return nil, nil
+}
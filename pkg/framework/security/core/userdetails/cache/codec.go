@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/authority"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+)
+
+func init() {
+	RegisterType(&userdetails.User{})
+	RegisterType(&authority.SimpleGrantedAuthority{})
+}
+
+// RegisterType 注册缓存的用户具体类型，
+// 自定义 UserDetails 需要先注册才能保存在缓存中
+func RegisterType(value interface{}) {
+	gob.Register(value)
+}
+
+// Codec 用户信息编解码
+type Codec interface {
+	Encode(userdetails.UserDetails) ([]byte, error)
+	Decode([]byte) (userdetails.UserDetails, error)
+}
+
+// GobCodec 使用 gob 编解码
+type GobCodec struct {
+}
+
+// gob 只能通过接口字段保存具体类型
+type entry struct {
+	User userdetails.UserDetails
+}
+
+// Encode 编码
+func (GobCodec) Encode(user userdetails.UserDetails) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&entry{User: user}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode 解码
+func (GobCodec) Decode(data []byte) (userdetails.UserDetails, error) {
+	var e entry
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&e); err != nil {
+		return nil, err
+	}
+	return e.User, nil
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
+)
+
+// 缓存方式
+const (
+	TypeNone   = "none"
+	TypeMemory = "memory"
+	TypeRedis  = "redis"
+)
+
+// Config 用户缓存配置
+type Config struct {
+	// 缓存方式 none, memory, redis，默认不缓存
+	Type string `yaml:"type"`
+	// 缓存有效期，单位秒
+	TTLSeconds int `yaml:"ttlSeconds"`
+	// 内存缓存最多保存的用户数
+	MaxSize int `yaml:"maxSize"`
+}
+
+// GetTTL 缓存有效期
+func (c Config) GetTTL() time.Duration {
+	if c.TTLSeconds <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(c.TTLSeconds) * time.Second
+}
+
+// GetMaxSize 内存缓存最多保存的用户数
+func (c Config) GetMaxSize() int {
+	if c.MaxSize <= 0 {
+		return 1000
+	}
+	return c.MaxSize
+}
+
+// NewUserCache 根据配置创建用户缓存
+func NewUserCache(config Config, redisClient *store.RedisClient) userdetails.UserCache {
+	switch config.Type {
+	case TypeMemory:
+		return NewLRUUserCache(config.GetMaxSize(), config.GetTTL(), GobCodec{})
+	case TypeRedis:
+		return NewRedisUserCache(redisClient, config.GetTTL(), GobCodec{})
+	default:
+		return NewNilUserCache()
+	}
+}
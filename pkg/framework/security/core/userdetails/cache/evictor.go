@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+)
+
+// Clearer 支持清空的用户缓存
+type Clearer interface {
+	ClearCache() error
+}
+
+// Evictor 用户或角色信息变更后清理缓存，供管理端更新用户、角色后调用
+type Evictor struct {
+	UserCache userdetails.UserCache
+}
+
+// NewEvictor 实例化
+func NewEvictor(userCache userdetails.UserCache) *Evictor {
+	return &Evictor{
+		UserCache: userCache,
+	}
+}
+
+// EvictUser 用户信息变更后移除对应缓存
+func (e *Evictor) EvictUser(usernames ...string) error {
+	for _, username := range usernames {
+		if err := e.UserCache.RemoveUserFromCache(username); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EvictAll 角色或权限变更后影响的用户无法确定，清空所有缓存
+func (e *Evictor) EvictAll() error {
+	if clearer, ok := e.UserCache.(Clearer); ok {
+		return clearer.ClearCache()
+	}
+	return nil
+}
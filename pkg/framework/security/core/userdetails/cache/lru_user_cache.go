@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+)
+
+// LRUUserCache 内存缓存，超过容量时淘汰最久未使用的用户，只适用于单实例
+type LRUUserCache struct {
+	lock    sync.Mutex
+	maxSize int
+	ttl     time.Duration
+	codec   Codec
+	order   *list.List
+	items   map[string]*list.Element
+}
+
+type lruItem struct {
+	username  string
+	data      []byte
+	expiresAt time.Time
+}
+
+// NewLRUUserCache 实例化
+func NewLRUUserCache(maxSize int, ttl time.Duration, codec Codec) *LRUUserCache {
+	return &LRUUserCache{
+		maxSize: maxSize,
+		ttl:     ttl,
+		codec:   codec,
+		order:   list.New(),
+		items:   make(map[string]*list.Element),
+	}
+}
+
+// GetUserFromCache 从缓存中获取用户信息，每次返回新的副本，
+// 避免擦除凭证等修改影响缓存中的数据
+func (c *LRUUserCache) GetUserFromCache(username string) (userdetails.UserDetails, error) {
+	c.lock.Lock()
+	element, ok := c.items[username]
+	if !ok {
+		c.lock.Unlock()
+		return nil, nil
+	}
+	item := element.Value.(*lruItem)
+	if time.Now().After(item.expiresAt) {
+		c.remove(element)
+		c.lock.Unlock()
+		return nil, nil
+	}
+	c.order.MoveToFront(element)
+	data := item.data
+	c.lock.Unlock()
+	return c.codec.Decode(data)
+}
+
+// PutUserInCache 增加缓存
+func (c *LRUUserCache) PutUserInCache(user userdetails.UserDetails) error {
+	data, err := c.codec.Encode(user)
+	if err != nil {
+		return err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	item := &lruItem{
+		username:  user.GetUsername(),
+		data:      data,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+	if element, ok := c.items[item.username]; ok {
+		element.Value = item
+		c.order.MoveToFront(element)
+		return nil
+	}
+	c.items[item.username] = c.order.PushFront(item)
+	for c.order.Len() > c.maxSize {
+		c.remove(c.order.Back())
+	}
+	return nil
+}
+
+// RemoveUserFromCache 移除缓存
+func (c *LRUUserCache) RemoveUserFromCache(username string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if element, ok := c.items[username]; ok {
+		c.remove(element)
+	}
+	return nil
+}
+
+// ClearCache 清空缓存
+func (c *LRUUserCache) ClearCache() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.order.Init()
+	c.items = make(map[string]*list.Element)
+	return nil
+}
+
+func (c *LRUUserCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.items, element.Value.(*lruItem).username)
+}
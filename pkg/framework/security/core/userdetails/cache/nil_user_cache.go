@@ -25,3 +25,8 @@ func (*NilUserCache) PutUserInCache(user userdetails.UserDetails) error {
 func (*NilUserCache) RemoveUserFromCache(username string) error {
 	return nil
 }
+
+// ClearCache 清空缓存
+func (*NilUserCache) ClearCache() error {
+	return nil
+}
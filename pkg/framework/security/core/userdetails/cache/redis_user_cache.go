@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
+)
+
+// RedisUserCache redis 缓存，适用于多实例部署
+type RedisUserCache struct {
+	client *store.RedisClient
+	ttl    time.Duration
+	codec  Codec
+}
+
+// NewRedisUserCache 实例化
+func NewRedisUserCache(client *store.RedisClient, ttl time.Duration, codec Codec) *RedisUserCache {
+	return &RedisUserCache{
+		client: client,
+		ttl:    ttl,
+		codec:  codec,
+	}
+}
+
+// GetUserFromCache 从缓存中获取用户信息
+func (c *RedisUserCache) GetUserFromCache(username string) (userdetails.UserDetails, error) {
+	data, err := c.client.Cli.Get(c.key(username)).Bytes()
+	if err == store.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c.codec.Decode(data)
+}
+
+// PutUserInCache 增加缓存
+func (c *RedisUserCache) PutUserInCache(user userdetails.UserDetails) error {
+	data, err := c.codec.Encode(user)
+	if err != nil {
+		return err
+	}
+	return c.client.Cli.Set(c.key(user.GetUsername()), data, c.ttl).Err()
+}
+
+// RemoveUserFromCache 移除缓存
+func (c *RedisUserCache) RemoveUserFromCache(username string) error {
+	return c.client.Cli.Del(c.key(username)).Err()
+}
+
+// ClearCache 清空缓存
+func (c *RedisUserCache) ClearCache() error {
+	var cursor uint64
+	for {
+		keys, next, err := c.client.Cli.Scan(cursor, c.key("*"), 100).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if err := c.client.Cli.Del(keys...).Err(); err != nil {
+				return err
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func (c *RedisUserCache) key(username string) string {
+	return c.client.KeyPrefix + "user:cache:" + username
+}
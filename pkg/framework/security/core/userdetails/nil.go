@@ -6,3 +6,11 @@ type NilUserDetailsService struct{}
 func (*NilUserDetailsService) LoadUserByUsername(username string) (UserDetails, error) {
 	return nil, nil
 }
+
+// NilPasswordService 空实现，不升级密码编码
+type NilPasswordService struct{}
+
+// UpdatePassword 保存新编码的密码
+func (*NilPasswordService) UpdatePassword(user UserDetails, newPassword string) (UserDetails, error) {
+	return user, nil
+}
@@ -26,6 +26,27 @@ type Service interface {
 	LoadUserByUsername(username string) (UserDetails, error)
 }
 
+// DetailsService 根据认证请求的附加信息加载用户，例如多租户时用户名只在租户内唯一
+type DetailsService interface {
+	// 根据用户名和认证请求的附加信息加载用户
+	LoadUserByUsernameAndDetails(username string, details interface{}) (UserDetails, error)
+	// 缓存中的用户是否属于当前认证请求
+	MatchesDetails(user UserDetails, details interface{}) bool
+}
+
+// PasswordService 更新用户密码，用于登录成功后升级密码编码
+type PasswordService interface {
+	// 保存新编码的密码，返回更新后的用户
+	UpdatePassword(user UserDetails, newPassword string) (UserDetails, error)
+}
+
+// DetailsPasswordService 根据认证请求的附加信息更新用户密码，
+// 用户需要按认证请求区分时实现，例如多租户
+type DetailsPasswordService interface {
+	// 保存新编码的密码，返回更新后的用户
+	UpdatePasswordWithDetails(user UserDetails, newPassword string, details interface{}) (UserDetails, error)
+}
+
 // Checker 检查加载 UserDetails 的状态
 type Checker interface {
 	// 检测用户状态
@@ -65,3 +65,8 @@ func (u *User) IsCredentialsNonExpired() bool {
 func (u *User) IsEnabled() bool {
 	return u.Enabled
 }
+
+// EraseCredentials 擦除密码
+func (u *User) EraseCredentials() {
+	u.Password = ""
+}
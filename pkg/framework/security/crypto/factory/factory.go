@@ -1,15 +1,86 @@
 package factory
 
-import "github.com/ingot-cloud/ingot-go/pkg/framework/security/crypto/password"
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/crypto/password"
+)
+
+// 编码器ID
+const (
+	EncoderNoop   = "noop"
+	EncoderSha1   = "sha1"
+	EncoderBcrypt = "bcrypt"
+	EncoderPbkdf2 = "pbkdf2"
+	EncoderScrypt = "scrypt"
+	EncoderArgon2 = "argon2"
+)
+
+// Config 密码编码配置
+type Config struct {
+	// 新密码使用的编码器ID，默认 bcrypt
+	EncodeID string       `yaml:"encodeId"`
+	Bcrypt   BcryptConfig `yaml:"bcrypt"`
+	Pbkdf2   Pbkdf2Config `yaml:"pbkdf2"`
+	Scrypt   ScryptConfig `yaml:"scrypt"`
+	Argon2   Argon2Config `yaml:"argon2"`
+}
+
+// BcryptConfig bcrypt 参数
+type BcryptConfig struct {
+	Cost int `yaml:"cost"`
+}
+
+// Pbkdf2Config PBKDF2 参数
+type Pbkdf2Config struct {
+	Iterations int `yaml:"iterations"`
+}
+
+// ScryptConfig scrypt 参数，分别对应 N、r、p，
+// 配置项不使用 n 作为 key，yaml 会将其解析为布尔值
+type ScryptConfig struct {
+	CPUCost     int `yaml:"cpuCost"`
+	BlockSize   int `yaml:"blockSize"`
+	Parallelism int `yaml:"parallelism"`
+}
+
+// Argon2Config Argon2id 参数，内存单位 KiB
+type Argon2Config struct {
+	Memory      uint32 `yaml:"memory"`
+	Iterations  uint32 `yaml:"iterations"`
+	Parallelism uint8  `yaml:"parallelism"`
+}
+
+// GetEncodeID 新密码使用的编码器ID
+func (c Config) GetEncodeID() string {
+	if c.EncodeID == "" {
+		return EncoderBcrypt
+	}
+	return c.EncodeID
+}
 
 // CreateDelegatingPasswordEncoder Creates a DelegatingPasswordEncoder with default mappings.
 // Additional mappings may be added and the encoding will be updated to conform with best practices.
 // However, due to the nature of DelegatingPasswordEncoder the updates should not impact users
 func CreateDelegatingPasswordEncoder() password.Encoder {
-	idForEncode := "noop"
+	return CreateDelegatingPasswordEncoderWithConfig(Config{})
+}
+
+// CreateDelegatingPasswordEncoderWithConfig 根据配置创建 DelegatingPasswordEncoder，
+// 已存储的密码根据 {id} 前缀选择编码器，新密码使用 EncodeID 对应的编码器
+func CreateDelegatingPasswordEncoderWithConfig(config Config) password.Encoder {
 	encoders := make(map[string]password.Encoder)
-	encoders["noop"] = &password.NoopEncoder{}
-	encoders["sha1"] = &password.Sha1Encoder{}
+	encoders[EncoderNoop] = &password.NoopEncoder{}
+	encoders[EncoderSha1] = &password.Sha1Encoder{}
+	encoders[EncoderBcrypt] = password.NewBcryptEncoder(config.Bcrypt.Cost)
+	encoders[EncoderPbkdf2] = password.NewPbkdf2Encoder(config.Pbkdf2.Iterations)
+	encoders[EncoderScrypt] = password.NewScryptEncoder(config.Scrypt.CPUCost, config.Scrypt.BlockSize, config.Scrypt.Parallelism)
+	encoders[EncoderArgon2] = password.NewArgon2idEncoder(config.Argon2.Memory, config.Argon2.Iterations, config.Argon2.Parallelism)
+
+	idForEncode := config.GetEncodeID()
+	if _, ok := encoders[idForEncode]; !ok {
+		log.Warnf("Password encoder %s is not supported, use %s", idForEncode, EncoderBcrypt)
+		idForEncode = EncoderBcrypt
+	}
 	return &password.DelegatingEncoder{
 		IDForEncode:         idForEncode,
 		IDToPasswordEncoder: encoders,
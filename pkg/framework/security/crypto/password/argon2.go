@@ -0,0 +1,88 @@
+package password
+
+import (
+	"crypto/subtle"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Argon2id 默认参数，内存单位 KiB
+const (
+	DefaultArgon2Memory      = 19456
+	DefaultArgon2Iterations  = 2
+	DefaultArgon2Parallelism = 1
+	DefaultArgon2SaltLength  = 16
+	DefaultArgon2KeyLength   = 32
+)
+
+var argon2Prefix = fmt.Sprintf("$argon2id$v=%d$", argon2.Version)
+
+// NewArgon2idEncoder return Encoder，参数为 0 时使用默认值
+func NewArgon2idEncoder(memory, iterations uint32, parallelism uint8) *Argon2idEncoder {
+	if memory == 0 {
+		memory = DefaultArgon2Memory
+	}
+	if iterations == 0 {
+		iterations = DefaultArgon2Iterations
+	}
+	if parallelism == 0 {
+		parallelism = DefaultArgon2Parallelism
+	}
+	return &Argon2idEncoder{
+		Memory:      memory,
+		Iterations:  iterations,
+		Parallelism: parallelism,
+		SaltLength:  DefaultArgon2SaltLength,
+		KeyLength:   DefaultArgon2KeyLength,
+	}
+}
+
+// Argon2idEncoder impl Encoder，编码结果为 PHC 格式 $argon2id$v=19$m=memory,t=iterations,p=parallelism$salt$hash
+type Argon2idEncoder struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  int
+	KeyLength   uint32
+}
+
+// Encode the raw password
+func (e *Argon2idEncoder) Encode(raw string) (string, error) {
+	salt, err := newSalt(e.SaltLength)
+	if err != nil {
+		return "", err
+	}
+	hash := argon2.IDKey([]byte(raw), salt, e.Iterations, e.Memory, e.Parallelism, e.KeyLength)
+	return fmt.Sprintf("%sm=%d,t=%d,p=%d$%s$%s", argon2Prefix, e.Memory, e.Iterations, e.Parallelism, encoding.EncodeToString(salt), encoding.EncodeToString(hash)), nil
+}
+
+// Matches Verify the encoded password obtained from storage matches the submitted raw
+// password after it too is encoded. Returns true if the passwords match, false if
+// they do not
+func (e *Argon2idEncoder) Matches(raw string, encodedPassword string) (bool, error) {
+	value, salt, hash, ok := splitEncoded(encodedPassword, argon2Prefix)
+	if !ok {
+		return false, nil
+	}
+	params, ok := parseParams(value)
+	if !ok || params["m"] == 0 || params["t"] == 0 || params["p"] == 0 || params["p"] > 255 {
+		return false, nil
+	}
+	actual := argon2.IDKey([]byte(raw), salt, uint32(params["t"]), uint32(params["m"]), uint8(params["p"]), uint32(len(hash)))
+	return subtle.ConstantTimeCompare(actual, hash) == 1, nil
+}
+
+// UpgradeEncoding Returns true if the encoded password should be encoded again for better security，
+// 已存储密码的参数低于当前配置时需要升级
+func (e *Argon2idEncoder) UpgradeEncoding(encodedPassword string) bool {
+	value, _, hash, ok := splitEncoded(encodedPassword, argon2Prefix)
+	if !ok {
+		return false
+	}
+	params, ok := parseParams(value)
+	if !ok {
+		return false
+	}
+	return params["m"] < int(e.Memory) || params["t"] < int(e.Iterations) || params["p"] < int(e.Parallelism) || len(hash) < int(e.KeyLength)
+}
@@ -0,0 +1,56 @@
+package password
+
+import (
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DefaultBcryptCost 默认 bcrypt 强度
+const DefaultBcryptCost = 10
+
+// NewBcryptEncoder return Encoder，cost 取值范围 4-31，超出范围时使用默认值
+func NewBcryptEncoder(cost int) *BcryptEncoder {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = DefaultBcryptCost
+	}
+	return &BcryptEncoder{
+		Cost: cost,
+	}
+}
+
+// BcryptEncoder impl Encoder，编码结果为 $2a$cost$salt+hash，盐和强度包含在结果中
+type BcryptEncoder struct {
+	Cost int
+}
+
+// Encode the raw password
+func (e *BcryptEncoder) Encode(raw string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(raw), e.Cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+// Matches Verify the encoded password obtained from storage matches the submitted raw
+// password after it too is encoded. Returns true if the passwords match, false if
+// they do not
+func (e *BcryptEncoder) Matches(raw string, encodedPassword string) (bool, error) {
+	if encodedPassword == "" {
+		return false, nil
+	}
+	err := bcrypt.CompareHashAndPassword([]byte(encodedPassword), []byte(raw))
+	if err == bcrypt.ErrMismatchedHashAndPassword {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// UpgradeEncoding Returns true if the encoded password should be encoded again for better security，
+// 已存储密码的强度低于当前配置时需要升级
+func (e *BcryptEncoder) UpgradeEncoding(encodedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(encodedPassword))
+	if err != nil {
+		return false
+	}
+	return cost < e.Cost
+}
@@ -51,6 +51,19 @@ func (e *DelegatingEncoder) Matches(raw string, encodedPassword string) (bool, e
 	return encoder.Matches(raw, password)
 }
 
+// UpgradeEncoding 编码器ID与当前编码器ID不一致时需要升级，否则由对应的编码器判断
+func (e *DelegatingEncoder) UpgradeEncoding(encodedPassword string) bool {
+	id := e.extractID(encodedPassword)
+	if id != e.IDForEncode {
+		return true
+	}
+	encoder, ok := e.IDToPasswordEncoder[id]
+	if !ok {
+		return false
+	}
+	return encoder.UpgradeEncoding(e.extractEncodedPassword(encodedPassword))
+}
+
 func (e *DelegatingEncoder) extractID(encodedPassword string) string {
 	if encodedPassword == "" {
 		return ""
@@ -6,4 +6,6 @@ type Encoder interface {
 	Encode(raw string) (string, error)
 	// 验证原始密码和编码后的密码是否相等
 	Matches(raw string, encodedPassword string) (bool, error)
+	// 编码后的密码是否需要重新编码以提高安全性
+	UpgradeEncoding(encodedPassword string) bool
 }
@@ -19,3 +19,8 @@ func (e *NoopEncoder) Encode(raw string) (string, error) {
 func (e *NoopEncoder) Matches(raw string, encodedPassword string) (bool, error) {
 	return strings.Compare(raw, encodedPassword) == 0, nil
 }
+
+// UpgradeEncoding Returns true if the encoded password should be encoded again for better security
+func (e *NoopEncoder) UpgradeEncoding(encodedPassword string) bool {
+	return false
+}
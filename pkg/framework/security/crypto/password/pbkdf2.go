@@ -0,0 +1,78 @@
+package password
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// PBKDF2 默认参数
+const (
+	DefaultPbkdf2Iterations = 310000
+	DefaultPbkdf2SaltLength = 16
+	DefaultPbkdf2KeyLength  = 32
+)
+
+const pbkdf2Prefix = "$pbkdf2-sha256$"
+
+// NewPbkdf2Encoder return Encoder，iterations 小于等于 0 时使用默认值
+func NewPbkdf2Encoder(iterations int) *Pbkdf2Encoder {
+	if iterations <= 0 {
+		iterations = DefaultPbkdf2Iterations
+	}
+	return &Pbkdf2Encoder{
+		Iterations: iterations,
+		SaltLength: DefaultPbkdf2SaltLength,
+		KeyLength:  DefaultPbkdf2KeyLength,
+	}
+}
+
+// Pbkdf2Encoder impl Encoder，使用 PBKDF2-HMAC-SHA256，
+// 编码结果为 $pbkdf2-sha256$i=iterations$salt$hash
+type Pbkdf2Encoder struct {
+	Iterations int
+	SaltLength int
+	KeyLength  int
+}
+
+// Encode the raw password
+func (e *Pbkdf2Encoder) Encode(raw string) (string, error) {
+	salt, err := newSalt(e.SaltLength)
+	if err != nil {
+		return "", err
+	}
+	hash := pbkdf2.Key([]byte(raw), salt, e.Iterations, e.KeyLength, sha256.New)
+	return fmt.Sprintf("%si=%d$%s$%s", pbkdf2Prefix, e.Iterations, encoding.EncodeToString(salt), encoding.EncodeToString(hash)), nil
+}
+
+// Matches Verify the encoded password obtained from storage matches the submitted raw
+// password after it too is encoded. Returns true if the passwords match, false if
+// they do not
+func (e *Pbkdf2Encoder) Matches(raw string, encodedPassword string) (bool, error) {
+	value, salt, hash, ok := splitEncoded(encodedPassword, pbkdf2Prefix)
+	if !ok {
+		return false, nil
+	}
+	params, ok := parseParams(value)
+	if !ok || params["i"] == 0 {
+		return false, nil
+	}
+	actual := pbkdf2.Key([]byte(raw), salt, params["i"], len(hash), sha256.New)
+	return subtle.ConstantTimeCompare(actual, hash) == 1, nil
+}
+
+// UpgradeEncoding Returns true if the encoded password should be encoded again for better security，
+// 已存储密码的迭代次数或摘要长度低于当前配置时需要升级
+func (e *Pbkdf2Encoder) UpgradeEncoding(encodedPassword string) bool {
+	value, _, hash, ok := splitEncoded(encodedPassword, pbkdf2Prefix)
+	if !ok {
+		return false
+	}
+	params, ok := parseParams(value)
+	if !ok {
+		return false
+	}
+	return params["i"] < e.Iterations || len(hash) < e.KeyLength
+}
@@ -0,0 +1,56 @@
+package password
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strconv"
+	"strings"
+)
+
+// 编码结果中的盐和摘要使用不带填充的标准 base64
+var encoding = base64.RawStdEncoding
+
+func newSalt(length int) ([]byte, error) {
+	salt := make([]byte, length)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return salt, nil
+}
+
+// 解析 k=v,k=v 形式的参数
+func parseParams(value string) (map[string]int, bool) {
+	params := make(map[string]int)
+	for _, item := range strings.Split(value, ",") {
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 {
+			return nil, false
+		}
+		n, err := strconv.Atoi(kv[1])
+		if err != nil || n <= 0 {
+			return nil, false
+		}
+		params[kv[0]] = n
+	}
+	return params, true
+}
+
+// 解析 $id$params$salt$hash 形式的编码结果，prefix 为 params 之前的部分
+func splitEncoded(encodedPassword string, prefix string) (params string, salt []byte, hash []byte, ok bool) {
+	if !strings.HasPrefix(encodedPassword, prefix) {
+		return "", nil, nil, false
+	}
+	parts := strings.Split(encodedPassword[len(prefix):], "$")
+	if len(parts) != 3 {
+		return "", nil, nil, false
+	}
+	salt, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, nil, false
+	}
+	hash, err = encoding.DecodeString(parts[2])
+	if err != nil || len(hash) == 0 {
+		return "", nil, nil, false
+	}
+	return parts[0], salt, hash, true
+}
@@ -0,0 +1,95 @@
+package password
+
+import (
+	"crypto/subtle"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// scrypt 默认参数
+const (
+	DefaultScryptN          = 32768
+	DefaultScryptR          = 8
+	DefaultScryptP          = 1
+	DefaultScryptSaltLength = 16
+	DefaultScryptKeyLength  = 32
+)
+
+const scryptPrefix = "$scrypt$"
+
+// NewScryptEncoder return Encoder，n 为 CPU/内存开销，必须是大于 1 的 2 的幂，
+// 参数不合法时使用默认值
+func NewScryptEncoder(n, r, p int) *ScryptEncoder {
+	if n <= 1 || n&(n-1) != 0 {
+		n = DefaultScryptN
+	}
+	if r <= 0 {
+		r = DefaultScryptR
+	}
+	if p <= 0 {
+		p = DefaultScryptP
+	}
+	return &ScryptEncoder{
+		N:          n,
+		R:          r,
+		P:          p,
+		SaltLength: DefaultScryptSaltLength,
+		KeyLength:  DefaultScryptKeyLength,
+	}
+}
+
+// ScryptEncoder impl Encoder，编码结果为 $scrypt$n=N,r=R,p=P$salt$hash
+type ScryptEncoder struct {
+	N          int
+	R          int
+	P          int
+	SaltLength int
+	KeyLength  int
+}
+
+// Encode the raw password
+func (e *ScryptEncoder) Encode(raw string) (string, error) {
+	salt, err := newSalt(e.SaltLength)
+	if err != nil {
+		return "", err
+	}
+	hash, err := scrypt.Key([]byte(raw), salt, e.N, e.R, e.P, e.KeyLength)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%sn=%d,r=%d,p=%d$%s$%s", scryptPrefix, e.N, e.R, e.P, encoding.EncodeToString(salt), encoding.EncodeToString(hash)), nil
+}
+
+// Matches Verify the encoded password obtained from storage matches the submitted raw
+// password after it too is encoded. Returns true if the passwords match, false if
+// they do not
+func (e *ScryptEncoder) Matches(raw string, encodedPassword string) (bool, error) {
+	value, salt, hash, ok := splitEncoded(encodedPassword, scryptPrefix)
+	if !ok {
+		return false, nil
+	}
+	params, ok := parseParams(value)
+	if !ok || params["n"] == 0 || params["r"] == 0 || params["p"] == 0 {
+		return false, nil
+	}
+	actual, err := scrypt.Key([]byte(raw), salt, params["n"], params["r"], params["p"], len(hash))
+	if err != nil {
+		return false, nil
+	}
+	return subtle.ConstantTimeCompare(actual, hash) == 1, nil
+}
+
+// UpgradeEncoding Returns true if the encoded password should be encoded again for better security，
+// 已存储密码的参数低于当前配置时需要升级
+func (e *ScryptEncoder) UpgradeEncoding(encodedPassword string) bool {
+	value, _, hash, ok := splitEncoded(encodedPassword, scryptPrefix)
+	if !ok {
+		return false
+	}
+	params, ok := parseParams(value)
+	if !ok {
+		return false
+	}
+	return params["n"] < e.N || params["r"] < e.R || params["p"] < e.P || len(hash) < e.KeyLength
+}
@@ -30,3 +30,8 @@ func (s *Sha1Encoder) Matches(raw string, encodedPassword string) (bool, error)
 	}
 	return strings.Compare(encoderRaw, encodedPassword) == 0, nil
 }
+
+// UpgradeEncoding Returns true if the encoded password should be encoded again for better security
+func (s *Sha1Encoder) UpgradeEncoding(encodedPassword string) bool {
+	return false
+}
@@ -8,4 +8,16 @@ const (
 	BadCredentialsCode             = "S0004"
 	CredentialsExpiredCode         = "S0005"
 	InsufficientAuthenticationCode = "S0006"
+	MaximumSessionsExceededCode    = "S0007"
+	MfaCodeInvalidCode             = "S0008"
+	MfaTokenInvalidCode            = "S0009"
+	SmsCodeInvalidCode             = "S0010"
+	SmsCodeTooFrequentCode         = "S0011"
+	SocialAuthFailedCode           = "S0012"
+	SocialUserNotBoundCode         = "S0013"
+)
+
+// 多因素认证
+const (
+	MfaRequiredCode = "mfa_required"
 )
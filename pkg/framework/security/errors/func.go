@@ -54,3 +54,52 @@ func InsufficientAuthentication(args ...string) error {
 	message := utils.StringCombine(args...)
 	return errors.New(http.StatusForbidden, InsufficientAuthenticationCode, message)
 }
+
+// MaximumSessionsExceeded 超出最大会话数量
+func MaximumSessionsExceeded(args ...string) error {
+	message := utils.StringCombine(args...)
+	return errors.New(http.StatusUnauthorized, MaximumSessionsExceededCode, message)
+}
+
+// MfaRequired 需要多因素认证，响应数据中携带 mfa_token
+func MfaRequired(mfaToken string) error {
+	return errors.NewWithData(http.StatusForbidden, MfaRequiredCode, "Multi-factor authentication required", map[string]string{
+		"mfa_token": mfaToken,
+	})
+}
+
+// MfaCodeInvalid 动态码错误
+func MfaCodeInvalid(args ...string) error {
+	message := utils.StringCombine(args...)
+	return errors.New(http.StatusUnauthorized, MfaCodeInvalidCode, message)
+}
+
+// MfaTokenInvalid mfa_token 无效或已过期
+func MfaTokenInvalid(args ...string) error {
+	message := utils.StringCombine(args...)
+	return errors.New(http.StatusUnauthorized, MfaTokenInvalidCode, message)
+}
+
+// SmsCodeInvalid 短信验证码错误或已过期
+func SmsCodeInvalid(args ...string) error {
+	message := utils.StringCombine(args...)
+	return errors.New(http.StatusUnauthorized, SmsCodeInvalidCode, message)
+}
+
+// SmsCodeTooFrequent 短信验证码发送过于频繁
+func SmsCodeTooFrequent(args ...string) error {
+	message := utils.StringCombine(args...)
+	return errors.New(http.StatusTooManyRequests, SmsCodeTooFrequentCode, message)
+}
+
+// SocialAuthFailed 第三方平台认证失败
+func SocialAuthFailed(args ...string) error {
+	message := utils.StringCombine(args...)
+	return errors.New(http.StatusUnauthorized, SocialAuthFailedCode, message)
+}
+
+// SocialUserNotBound 第三方账号未绑定用户
+func SocialUserNotBound(args ...string) error {
+	message := utils.StringCombine(args...)
+	return errors.New(http.StatusUnauthorized, SocialUserNotBoundCode, message)
+}
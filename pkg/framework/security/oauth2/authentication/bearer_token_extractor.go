@@ -21,7 +21,9 @@ func (e *BearerTokenExtractor) Extract(ctx *ingot.Context) core.Authentication {
 	token := ginwrapper.GetBearerToken(ctx.Context)
 	if token != "" {
 		// 返回 preauth token
-		return preauth.NewAuthenticationToken(token, "", nil)
+		auth := preauth.NewAuthenticationToken(token, "", nil)
+		auth.SetDetails(NewOAuth2AuthenticationDetails(ctx, token))
+		return auth
 	}
 	return nil
 }
@@ -0,0 +1,47 @@
+package authentication
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	coreAuth "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/mtls"
+)
+
+// OAuth2AuthenticationDetails 资源请求的详细信息
+type OAuth2AuthenticationDetails struct {
+	TokenValue string
+	// 客户端在 TLS 握手中提交的证书指纹，未提交证书时为空
+	CertificateThumbprint string
+	// 客户端地址
+	RemoteAddress string
+	// 请求跟踪ID
+	TraceID string
+}
+
+// NewOAuth2AuthenticationDetails 实例化
+func NewOAuth2AuthenticationDetails(ctx *ingot.Context, tokenValue string) *OAuth2AuthenticationDetails {
+	details := &OAuth2AuthenticationDetails{
+		TokenValue:    tokenValue,
+		RemoteAddress: coreAuth.RemoteAddress(ctx.Request),
+		TraceID:       log.FromTraceIDContext(ctx.Request.Context()),
+	}
+	if cert := mtls.ClientCertificate(ctx.Request); cert != nil {
+		details.CertificateThumbprint = mtls.Thumbprint(cert)
+	}
+	return details
+}
+
+// GetClientID 客户端ID，需要读取令牌后才能确定
+func (d *OAuth2AuthenticationDetails) GetClientID() string {
+	return ""
+}
+
+// GetRemoteAddress 客户端地址
+func (d *OAuth2AuthenticationDetails) GetRemoteAddress() string {
+	return d.RemoteAddress
+}
+
+// GetTraceID 跟踪ID
+func (d *OAuth2AuthenticationDetails) GetTraceID() string {
+	return d.TraceID
+}
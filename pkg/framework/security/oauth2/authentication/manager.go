@@ -1,10 +1,14 @@
 package authentication
 
 import (
+	"crypto/subtle"
+
+	coreAuth "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/errors"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/authentication"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/mtls"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token"
 )
 
@@ -35,7 +39,7 @@ func (manager *OAuth2AuthenticationManager) Authenticate(auth core.Authenticatio
 		return nil, errors.ErrInvalidToken
 	}
 
-	oauth2Auth, err := manager.ResourceServerTokenServices.LoadAuthentication(token)
+	accessToken, oauth2Auth, err := manager.loadAuthentication(token)
 	if err != nil {
 		return nil, err
 	}
@@ -59,14 +63,54 @@ func (manager *OAuth2AuthenticationManager) Authenticate(auth core.Authenticatio
 		return nil, err
 	}
 
+	err = manager.checkCertificateBinding(accessToken, auth)
+	if err != nil {
+		return nil, err
+	}
+
 	oauth2Auth.SetDetails(auth.GetDetails())
 	oauth2Auth.SetAuthenticated(true)
 	return oauth2Auth, nil
 }
 
+// 加载访问令牌和身份验证信息，token 服务不支持同时加载时单独读取访问令牌
+func (manager *OAuth2AuthenticationManager) loadAuthentication(tokenValue string) (token.OAuth2AccessToken, *authentication.OAuth2Authentication, error) {
+	if loader, ok := manager.ResourceServerTokenServices.(token.AccessTokenAuthenticationLoader); ok {
+		return loader.LoadAccessTokenAuthentication(tokenValue)
+	}
+	oauth2Auth, err := manager.ResourceServerTokenServices.LoadAuthentication(tokenValue)
+	if err != nil {
+		return nil, nil, err
+	}
+	accessToken, err := manager.ResourceServerTokenServices.ReadAccessToken(tokenValue)
+	if err != nil {
+		return nil, nil, err
+	}
+	return accessToken, oauth2Auth, nil
+}
+
+// 证书绑定的令牌只能由持有对应证书的客户端使用，RFC 8705 3
+func (manager *OAuth2AuthenticationManager) checkCertificateBinding(accessToken token.OAuth2AccessToken, auth core.Authentication) error {
+	if accessToken == nil {
+		return nil
+	}
+	bound := mtls.BoundThumbprint(accessToken.GetAdditionalInformation())
+	if bound == "" {
+		return nil
+	}
+	var presented string
+	if details, ok := auth.GetDetails().(*OAuth2AuthenticationDetails); ok {
+		presented = details.CertificateThumbprint
+	}
+	if subtle.ConstantTimeCompare([]byte(bound), []byte(presented)) != 1 {
+		return errors.InvalidToken("Certificate thumbprint does not match the certificate bound access token")
+	}
+	return nil
+}
+
 func (manager *OAuth2AuthenticationManager) checkClientDetails(auth *authentication.OAuth2Authentication) error {
 	if manager.ClientDetailsService != nil {
-		client, err := manager.ClientDetailsService.LoadClientByClientID(auth.GetOAuth2Request().ClientID)
+		client, err := clientdetails.LoadClient(manager.ClientDetailsService, auth.GetOAuth2Request().ClientID, coreAuth.PrincipalTenantID(auth))
 		if err != nil {
 			return err
 		}
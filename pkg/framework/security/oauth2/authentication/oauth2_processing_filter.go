@@ -4,20 +4,25 @@ import (
 	"github.com/ingot-cloud/ingot-go/pkg/framework/core/web/filter"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/constants"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/event"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	oauth2Authentication "github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/authentication"
 )
 
 // OAuth2ProcessingFilter OAuth2处理
 type OAuth2ProcessingFilter struct {
 	TokenExtractor        TokenExtractor
 	AuthenticationManager authentication.Manager
+	EventPublisher        event.Publisher
 }
 
 // NewOAuth2ProcessingFilter 实例化
-func NewOAuth2ProcessingFilter(extractor TokenExtractor, manager authentication.Manager) *OAuth2ProcessingFilter {
+func NewOAuth2ProcessingFilter(extractor TokenExtractor, manager authentication.Manager, publisher event.Publisher) *OAuth2ProcessingFilter {
 	return &OAuth2ProcessingFilter{
 		TokenExtractor:        extractor,
 		AuthenticationManager: manager,
+		EventPublisher:        publisher,
 	}
 }
 
@@ -38,10 +43,37 @@ func (filter *OAuth2ProcessingFilter) DoFilter(context *ingot.Context, chain fil
 	if auth != nil {
 		authResult, err := filter.AuthenticationManager.Authenticate(auth)
 		if err != nil {
+			filter.publish(newFailureEvent(auth, err))
 			return err
 		}
+		filter.publish(newSuccessEvent(authResult))
 		context.SetAuthentication(authResult)
 	}
 
 	return chain.DoFilter(context)
 }
+
+func (filter *OAuth2ProcessingFilter) publish(e *event.Event) {
+	if filter.EventPublisher != nil {
+		filter.EventPublisher.Publish(e)
+	}
+}
+
+// 认证失败时 auth 的 principal 为令牌原文，事件中只记录令牌指纹
+func newFailureEvent(auth core.Authentication, err error) *event.Event {
+	e := event.NewFailureEvent(nil, err)
+	if token, ok := auth.GetPrincipal().(string); ok {
+		e.Principal = event.TokenFingerprint(token)
+	}
+	e.SetDetails(auth.GetDetails())
+	return e
+}
+
+// 令牌认证成功后才能确定客户端ID
+func newSuccessEvent(auth core.Authentication) *event.Event {
+	e := event.NewEvent(event.TokenAuthenticated, auth, nil)
+	if oauth2Auth, ok := auth.(*oauth2Authentication.OAuth2Authentication); ok {
+		e.ClientID = oauth2Auth.GetOAuth2Request().GetClientID()
+	}
+	return e
+}
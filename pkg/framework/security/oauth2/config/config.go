@@ -28,4 +28,23 @@ type AuthorizationServer struct {
 	SupportRefreshToken bool `yaml:"supportRefreshToken"`
 	// 是否重复使用RefreshToken
 	ReuseRefreshToken bool `yaml:"reuseRefreshToken"`
+	// 是否支持客户端证书认证，RFC 8705 2
+	TLSClientAuth bool `yaml:"tlsClientAuth"`
+	// 是否签发绑定客户端证书的访问令牌，RFC 8705 3
+	CertificateBoundAccessTokens bool `yaml:"certificateBoundAccessTokens"`
+	// 动态注册客户端，RFC 7591/7592
+	Registration Registration `yaml:"registration"`
+}
+
+// Registration 动态注册客户端配置
+type Registration struct {
+	Enable bool `yaml:"enable"`
+	// 初始访问令牌，注册客户端需要携带该令牌，为空时不允许注册
+	InitialAccessToken string `yaml:"initialAccessToken"`
+	// 允许注册的授权类型，只能注册列表中的授权类型
+	GrantTypes []string `yaml:"grantTypes"`
+	// 允许注册的 scope，只能注册列表中的 scope
+	Scopes []string `yaml:"scopes"`
+	// 可信代理，CIDR 格式，只信任可信代理设置的 X-Forwarded-Proto
+	TrustedProxies []string `yaml:"trustedProxies"`
 }
@@ -4,11 +4,15 @@ import (
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
 	coreAuth "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
 	securityAuth "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
-	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/constants"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/configurer/tlsclient"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/endpoint"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/config"
 	anonymous "github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/anoymous"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/basic"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/cors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/headers"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/utils"
 )
 
 // AuthorizationServerConfigurerAdapter 授权服务器配置
@@ -16,14 +20,21 @@ type AuthorizationServerConfigurerAdapter struct {
 	*config.WebSecurityConfigurerAdapter
 
 	authenticationManager securityAuth.Manager
+	corsSource            cors.ConfigurationSource
+	headersConfig         headers.Config
+	tlsClientConfigurer   *tlsclient.SecurityConfigurer
 }
 
 // NewAuthorizationServerConfigurer 实例化
-func NewAuthorizationServerConfigurer(authenticationManager coreAuth.Manager) security.AuthorizationServerConfigurer {
+func NewAuthorizationServerConfigurer(authenticationManager coreAuth.Manager, corsSource cors.ConfigurationSource, headersConfig headers.Config, tlsClientConfigurer *tlsclient.SecurityConfigurer) security.AuthorizationServerConfigurer {
 	instance := &AuthorizationServerConfigurerAdapter{
 		authenticationManager: authenticationManager,
+		corsSource:            corsSource,
+		headersConfig:         headersConfig,
+		tlsClientConfigurer:   tlsClientConfigurer,
 	}
 	instance.WebSecurityConfigurerAdapter = config.NewWebSecurityConfigurerAdapter(instance)
+	instance.SetOrder(constants.OrderWebSecurityAuthorizationServer)
 	return instance
 }
 
@@ -31,19 +42,17 @@ func (*AuthorizationServerConfigurerAdapter) Authorization() {}
 
 // HTTPConfigure 配置
 func (a *AuthorizationServerConfigurerAdapter) HTTPConfigure(http security.HTTPSecurityBuilder) error {
-	http.RequestMatcher(a.RequestMatcher)
+	http.RequestMatcher(a.RequestMatcher())
+	// token 响应禁止缓存，RFC 6749 5.1
+	http.Apply(headers.NewSecurityConfigurer(a.headersConfig.WithCacheControl()))
+	http.Apply(cors.NewSecurityConfigurer(a.corsSource))
+	http.Apply(a.tlsClientConfigurer)
 	http.Apply(basic.NewSecurityConfigurer(a.authenticationManager))
 	http.Apply(anonymous.NewSecurityConfigurer())
 	return nil
 }
 
-// RequestMatcher 请求匹配器
-func (a *AuthorizationServerConfigurerAdapter) RequestMatcher(ctx *ingot.Context) bool {
-	current := ctx.Request.RequestURI
-	for _, p := range endpoint.Paths {
-		if p == current {
-			return true
-		}
-	}
-	return false
+// RequestMatcher 请求匹配器，匹配所有授权端点
+func (a *AuthorizationServerConfigurerAdapter) RequestMatcher() utils.RequestMatcher {
+	return utils.NewAntPathRequestMatchers(endpoint.Paths...)
 }
@@ -2,6 +2,7 @@ package oauth
 
 import (
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/event"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/authentication"
 
 	coreAuth "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
@@ -11,18 +12,20 @@ import (
 type SecurityConfigurer struct {
 	tokenExtractor        authentication.TokenExtractor
 	authenticationManager coreAuth.Manager
+	eventPublisher        event.Publisher
 }
 
 // NewSecurityConfigurer 配置
-func NewSecurityConfigurer(tokenExtractor authentication.TokenExtractor, authenticationManager coreAuth.Manager) *SecurityConfigurer {
+func NewSecurityConfigurer(tokenExtractor authentication.TokenExtractor, authenticationManager coreAuth.Manager, eventPublisher event.Publisher) *SecurityConfigurer {
 	return &SecurityConfigurer{
 		tokenExtractor:        tokenExtractor,
 		authenticationManager: authenticationManager,
+		eventPublisher:        eventPublisher,
 	}
 }
 
 // HTTPConfigure 配置
 func (c *SecurityConfigurer) HTTPConfigure(http security.HTTPSecurityBuilder) error {
-	http.AddFilter(authentication.NewOAuth2ProcessingFilter(c.tokenExtractor, c.authenticationManager))
+	http.AddFilter(authentication.NewOAuth2ProcessingFilter(c.tokenExtractor, c.authenticationManager, c.eventPublisher))
 	return nil
 }
@@ -3,13 +3,19 @@ package configurer
 import (
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
 	coreAuth "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
-	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/constants"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/event"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/authentication"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/configurer/oauth"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/endpoint"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/config"
 	anonymous "github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/anoymous"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/apikey"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/authresult"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/cors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/headers"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/preauth"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/utils"
 )
 
 // ResourceServerConfigurerAdapter 资源服务器安全配置
@@ -18,16 +24,27 @@ type ResourceServerConfigurerAdapter struct {
 
 	tokenExtractor        authentication.TokenExtractor
 	authenticationManager coreAuth.Manager
+	corsSource            cors.ConfigurationSource
+	headersConfig         headers.Config
+	apiKeyConfigurer      *apikey.SecurityConfigurer
+	preAuthConfigurer     *preauth.SecurityConfigurer
+	eventPublisher        event.Publisher
 }
 
 // NewResourceServerConfigurer 实例化
-func NewResourceServerConfigurer(tokenExtractor authentication.TokenExtractor, authenticationManager coreAuth.Manager) *ResourceServerConfigurerAdapter {
+func NewResourceServerConfigurer(tokenExtractor authentication.TokenExtractor, authenticationManager coreAuth.Manager, corsSource cors.ConfigurationSource, headersConfig headers.Config, apiKeyConfigurer *apikey.SecurityConfigurer, preAuthConfigurer *preauth.SecurityConfigurer, eventPublisher event.Publisher) *ResourceServerConfigurerAdapter {
 	instance := &ResourceServerConfigurerAdapter{
 		tokenExtractor:        tokenExtractor,
 		authenticationManager: authenticationManager,
+		corsSource:            corsSource,
+		headersConfig:         headersConfig,
+		apiKeyConfigurer:      apiKeyConfigurer,
+		preAuthConfigurer:     preAuthConfigurer,
+		eventPublisher:        eventPublisher,
 	}
 
 	instance.WebSecurityConfigurerAdapter = config.NewWebSecurityConfigurerAdapter(instance)
+	instance.SetOrder(constants.OrderWebSecurityResourceServer)
 	return instance
 }
 
@@ -35,21 +52,18 @@ func (*ResourceServerConfigurerAdapter) Resource() {}
 
 // HTTPConfigure 配置
 func (a *ResourceServerConfigurerAdapter) HTTPConfigure(http security.HTTPSecurityBuilder) error {
-	http.RequestMatcher(a.RequestMatcher)
-	http.Apply(oauth.NewSecurityConfigurer(a.tokenExtractor, a.authenticationManager))
+	http.RequestMatcher(a.RequestMatcher())
+	http.Apply(headers.NewSecurityConfigurer(a.headersConfig))
+	http.Apply(cors.NewSecurityConfigurer(a.corsSource))
+	http.Apply(a.preAuthConfigurer)
+	http.Apply(a.apiKeyConfigurer)
+	http.Apply(oauth.NewSecurityConfigurer(a.tokenExtractor, a.authenticationManager, a.eventPublisher))
 	http.Apply(anonymous.NewSecurityConfigurer())
 	http.Apply(authresult.NewSecurityConfigurer())
 	return nil
 }
 
-// RequestMatcher 请求匹配器
-func (a *ResourceServerConfigurerAdapter) RequestMatcher(ctx *ingot.Context) bool {
-	current := ctx.Request.RequestURI
-	// 当前url不能匹配token授权url
-	for _, p := range endpoint.Paths {
-		if p == current {
-			return false
-		}
-	}
-	return true
+// RequestMatcher 请求匹配器，当前请求不能匹配授权端点
+func (a *ResourceServerConfigurerAdapter) RequestMatcher() utils.RequestMatcher {
+	return utils.Not(utils.NewAntPathRequestMatchers(endpoint.Paths...))
 }
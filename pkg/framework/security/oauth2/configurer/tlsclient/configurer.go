@@ -0,0 +1,29 @@
+package tlsclient
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
+)
+
+// SecurityConfigurer 客户端证书认证配置
+type SecurityConfigurer struct {
+	Enable               bool
+	ClientDetailsService clientdetails.Service
+}
+
+// NewSecurityConfigurer 配置
+func NewSecurityConfigurer(enable bool, service clientdetails.Service) *SecurityConfigurer {
+	return &SecurityConfigurer{
+		Enable:               enable,
+		ClientDetailsService: service,
+	}
+}
+
+// HTTPConfigure 配置
+func (c *SecurityConfigurer) HTTPConfigure(http security.HTTPSecurityBuilder) error {
+	if !c.Enable {
+		return nil
+	}
+	http.AddFilter(NewFilter(c.ClientDetailsService))
+	return nil
+}
@@ -0,0 +1,114 @@
+package tlsclient
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/web/filter"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/preauth"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/constants"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/mtls"
+)
+
+// Filter 客户端证书认证，RFC 8705 2
+type Filter struct {
+	ClientDetailsService clientdetails.Service
+}
+
+// NewFilter 实例化
+func NewFilter(service clientdetails.Service) *Filter {
+	return &Filter{
+		ClientDetailsService: service,
+	}
+}
+
+// Name 名字
+func (f *Filter) Name() string {
+	return "TLSClientAuthenticationFilter"
+}
+
+// Order 过滤器排序
+func (f *Filter) Order() int {
+	return constants.OrderFilterTLSClientAuth
+}
+
+// DoFilter 执行过滤器
+func (f *Filter) DoFilter(context *ingot.Context, chain filter.Chain) error {
+	if !f.requiresAuthentication(context) {
+		return chain.DoFilter(context)
+	}
+
+	clientID := context.PostForm("client_id")
+	client, err := clientdetails.LoadClient(f.ClientDetailsService, clientID, authentication.TenantID(context.Request))
+	if err != nil {
+		return err
+	}
+	if client == nil {
+		return errors.InvalidClient("Client not found: ", clientID)
+	}
+
+	additional := client.GetAdditionalInformation()
+	method, _ := additional[mtls.TokenEndpointAuthMethod].(string)
+	switch method {
+	case mtls.AuthMethodTLSClientAuth:
+		err = f.checkSubjectDN(context, additional)
+	case mtls.AuthMethodSelfSignedTLSClientAuth:
+		err = f.checkThumbprint(context, additional)
+	default:
+		// 客户端未注册 mTLS 认证方式，交由其他过滤器处理
+		return chain.DoFilter(context)
+	}
+	if err != nil {
+		return err
+	}
+
+	authorities := client.GetAuthorities()
+	if authorities == nil {
+		authorities = []core.GrantedAuthority{}
+	}
+	context.SetAuthentication(preauth.NewAuthenticationToken(client.GetClientID(), "", authorities))
+	return chain.DoFilter(context)
+}
+
+// 仅处理提交了证书且未通过其他方式认证的客户端
+func (f *Filter) requiresAuthentication(context *ingot.Context) bool {
+	if mtls.ClientCertificate(context.Request) == nil {
+		return false
+	}
+	if context.GetHeader("Authorization") != "" {
+		return false
+	}
+	if auth := context.GetAuthentication(); auth != nil && auth.IsAuthenticated() {
+		return false
+	}
+	return context.PostForm("client_id") != ""
+}
+
+// tls_client_auth 证书需要由受信任的 CA 签发，并且主体 DN 与注册信息一致
+func (f *Filter) checkSubjectDN(context *ingot.Context, additional map[string]interface{}) error {
+	cert := mtls.VerifiedClientCertificate(context.Request)
+	if cert == nil {
+		return errors.InvalidClient("Client certificate is not trusted")
+	}
+	expected, _ := additional[mtls.TLSClientAuthSubjectDN].(string)
+	if expected == "" || cert.Subject.String() != expected {
+		return errors.InvalidClient("Client certificate subject does not match registered subject")
+	}
+	return nil
+}
+
+// self_signed_tls_client_auth 证书指纹需要与注册的指纹之一相同
+func (f *Filter) checkThumbprint(context *ingot.Context, additional map[string]interface{}) error {
+	thumbprint := mtls.Thumbprint(mtls.ClientCertificate(context.Request))
+	for _, registered := range mtls.RegisteredThumbprints(additional) {
+		if subtle.ConstantTimeCompare([]byte(strings.TrimSpace(registered)), []byte(thumbprint)) == 1 {
+			return nil
+		}
+	}
+	return errors.InvalidClient("Client certificate does not match registered certificate")
+}
@@ -13,6 +13,11 @@ const (
 	Username          = "username"
 	Password          = "password"
 	RefreshToken      = "refresh_token"
+	MfaToken          = "mfa_token"
+	Otp               = "otp"
+	Mobile            = "mobile"
+	SmsCode           = "sms_code"
+	SocialType        = "social_type"
 	UserOAuthApproval = "user_oauth_approval"
 	ScopePrefix       = "scope."
 )
@@ -7,4 +7,7 @@ const (
 	GrantTypeImplicit = "implicit"
 	GrantTypeClient   = "client_credentials"
 	GrantTypeRefresh  = "refresh_token"
+	GrantTypeMfa      = "mfa"
+	GrantTypeSms      = "sms"
+	GrantTypeSocial   = "social"
 )
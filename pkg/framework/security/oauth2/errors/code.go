@@ -20,3 +20,14 @@ const (
 	UnsupportedResponseTypeCode = "unsupported_response_type"
 	AccessDeniedCode            = "access_denied"
 )
+
+// 动态注册客户端 error code，RFC 7591 3.2.2
+const (
+	InvalidRedirectURICode    = "invalid_redirect_uri"
+	InvalidClientMetadataCode = "invalid_client_metadata"
+)
+
+// 客户端状态码
+const (
+	NoSuchClientCode = "no_such_client"
+)
@@ -54,3 +54,21 @@ func UnsupportedGrantType(args ...string) error {
 	message := utils.StringCombine(args...)
 	return errors.New(http.StatusBadRequest, UnsupportedGrantTypeCode, message)
 }
+
+// NoSuchClient 客户端不存在或不可用
+func NoSuchClient(args ...string) error {
+	message := utils.StringCombine(args...)
+	return errors.New(http.StatusUnauthorized, NoSuchClientCode, message)
+}
+
+// InvalidRedirectURI 注册的重定向 uri 无效
+func InvalidRedirectURI(args ...string) error {
+	message := utils.StringCombine(args...)
+	return errors.New(http.StatusBadRequest, InvalidRedirectURICode, message)
+}
+
+// InvalidClientMetadata 注册的客户端元数据无效
+func InvalidClientMetadata(args ...string) error {
+	message := utils.StringCombine(args...)
+	return errors.New(http.StatusBadRequest, InvalidClientMetadataCode, message)
+}
@@ -0,0 +1,23 @@
+package model
+
+// ClientMetadata 客户端元数据，RFC 7591 2
+type ClientMetadata struct {
+	RedirectURIs            []string               `json:"redirect_uris,omitempty"`
+	GrantTypes              []string               `json:"grant_types,omitempty"`
+	Scope                   string                 `json:"scope,omitempty"`
+	TokenEndpointAuthMethod string                 `json:"token_endpoint_auth_method,omitempty"`
+	Jwks                    map[string]interface{} `json:"jwks,omitempty"`
+	// tls_client_auth 方式认证的证书主体 DN，RFC 8705 2.1.2
+	TLSClientAuthSubjectDN string `json:"tls_client_auth_subject_dn,omitempty"`
+}
+
+// ClientInformation 客户端信息，RFC 7591 3.2.1，RFC 7592 3
+type ClientInformation struct {
+	ClientID                string `json:"client_id"`
+	ClientSecret            string `json:"client_secret,omitempty"`
+	ClientIDIssuedAt        int64  `json:"client_id_issued_at,omitempty"`
+	ClientSecretExpiresAt   int64  `json:"client_secret_expires_at"`
+	RegistrationAccessToken string `json:"registration_access_token,omitempty"`
+	RegistrationClientURI   string `json:"registration_client_uri,omitempty"`
+	ClientMetadata
+}
@@ -24,11 +24,25 @@ type RequestParameters struct {
 	Password string `form:"password"`
 
 	RefreshToken string `form:"refresh_token"`
+
+	MfaToken string `form:"mfa_token"`
+	Otp      string `form:"otp"`
+
+	Mobile  string `form:"mobile"`
+	SmsCode string `form:"sms_code"`
+
+	SocialType string `form:"social_type"`
+
+	// 其他请求参数，供自定义授权类型使用
+	Additional map[string]string `form:"-"`
 }
 
 // ToMap 转为 map
 func (r RequestParameters) ToMap() map[string]string {
 	result := make(map[string]string)
+	for key, value := range r.Additional {
+		result[key] = value
+	}
 
 	result[constants.GrantType] = r.GrantType
 	result[constants.Scope] = r.Scope
@@ -46,6 +60,14 @@ func (r RequestParameters) ToMap() map[string]string {
 
 	result[constants.RefreshToken] = r.RefreshToken
 
+	result[constants.MfaToken] = r.MfaToken
+	result[constants.Otp] = r.Otp
+
+	result[constants.Mobile] = r.Mobile
+	result[constants.SmsCode] = r.SmsCode
+
+	result[constants.SocialType] = r.SocialType
+
 	return result
 }
 
@@ -0,0 +1,93 @@
+package clientdetails
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+)
+
+type cacheKey struct {
+	clientID string
+	tenantID types.ID
+}
+
+type cacheEntry struct {
+	client    BaseClientDetails
+	expiresAt time.Time
+}
+
+// Cache 客户端内存缓存，只适用于单实例或可以接受短时间不一致的场景
+type Cache struct {
+	ttl     time.Duration
+	lock    sync.RWMutex
+	entries map[cacheKey]cacheEntry
+}
+
+// NewCache 实例化，ttl 小于等于 0 时不缓存
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		entries: make(map[cacheKey]cacheEntry),
+	}
+}
+
+// Get 获取缓存的客户端，返回副本
+func (c *Cache) Get(clientID string, tenantID types.ID) *BaseClientDetails {
+	if c == nil || c.ttl <= 0 {
+		return nil
+	}
+	c.lock.RLock()
+	entry, ok := c.entries[cacheKey{clientID, tenantID}]
+	c.lock.RUnlock()
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil
+	}
+	client := entry.client
+	return &client
+}
+
+// Put 缓存客户端
+func (c *Cache) Put(tenantID types.ID, client *BaseClientDetails) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := time.Now()
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	c.entries[cacheKey{client.ClientID, tenantID}] = cacheEntry{
+		client:    *client,
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+// Evict 清除指定客户端的缓存
+func (c *Cache) Evict(clientIDs ...string) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, clientID := range clientIDs {
+		for key := range c.entries {
+			if key.clientID == clientID {
+				delete(c.entries, key)
+			}
+		}
+	}
+}
+
+// Clear 清除所有缓存
+func (c *Cache) Clear() {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries = make(map[cacheKey]cacheEntry)
+}
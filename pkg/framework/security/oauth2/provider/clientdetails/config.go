@@ -0,0 +1,36 @@
+package clientdetails
+
+import "time"
+
+// Config 客户端详情配置
+type Config struct {
+	// 客户端缓存有效期，单位秒，小于等于 0 时不缓存
+	CacheSeconds int `yaml:"cacheSeconds"`
+	// 配置文件中定义的客户端，配置后使用内存客户端服务
+	Clients []ClientConfig `yaml:"clients"`
+}
+
+// ClientConfig 配置文件中定义的客户端
+type ClientConfig struct {
+	ClientID                    string   `yaml:"clientId"`
+	ClientSecret                string   `yaml:"clientSecret"`
+	ResourceIDs                 []string `yaml:"resourceIds"`
+	Scope                       []string `yaml:"scope"`
+	AuthorizedGrantTypes        []string `yaml:"authorizedGrantTypes"`
+	RedirectURIs                []string `yaml:"redirectUris"`
+	Authorities                 []string `yaml:"authorities"`
+	AccessTokenValiditySeconds  int      `yaml:"accessTokenValiditySeconds"`
+	RefreshTokenValiditySeconds int      `yaml:"refreshTokenValiditySeconds"`
+	// 所有 scope 都不需要用户批准
+	AutoApprove bool `yaml:"autoApprove"`
+	// 不需要用户批准的 scope
+	AutoApproveScopes []string `yaml:"autoApproveScopes"`
+}
+
+// GetCacheTTL 缓存有效期
+func (c Config) GetCacheTTL() time.Duration {
+	if c.CacheSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(c.CacheSeconds) * time.Second
+}
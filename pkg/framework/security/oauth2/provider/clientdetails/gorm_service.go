@@ -0,0 +1,120 @@
+package clientdetails
+
+import (
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/component/id"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/enums"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/errors"
+
+	"gorm.io/gorm"
+)
+
+// GormService 使用 gorm 从数据库加载客户端，只加载可用且未删除的客户端
+type GormService struct {
+	DB          *gorm.DB
+	TableName   string
+	IDGenerator id.Generator
+	Cache       *Cache
+}
+
+// NewGormService 实例化
+func NewGormService(db *gorm.DB, tableName string, generator id.Generator, config Config) *GormService {
+	return &GormService{
+		DB:          db,
+		TableName:   tableName,
+		IDGenerator: generator,
+		Cache:       NewCache(config.GetCacheTTL()),
+	}
+}
+
+// LoadClientByClientID 根据 clientID 获取客户端详细信息
+func (s *GormService) LoadClientByClientID(clientID string) (ClientDetails, error) {
+	return s.LoadClientByClientIDAndTenant(clientID, types.Zero)
+}
+
+// LoadClientByClientIDAndTenant 获取指定租户中的客户端，租户为空时查询所有租户，
+// 客户端不存在或在多个租户中存在时返回 NoSuchClient
+func (s *GormService) LoadClientByClientIDAndTenant(clientID string, tenantID types.ID) (ClientDetails, error) {
+	if client := s.Cache.Get(clientID, tenantID); client != nil {
+		return client, nil
+	}
+
+	db := s.table().Where("client_id = ? AND status = ? AND deleted_at IS NULL", clientID, enums.StatusEnabled)
+	if tenantID != types.Zero {
+		db = db.Where("tenant_id = ?", tenantID)
+	}
+	var list []*BaseClientDetails
+	if err := db.Limit(2).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	if len(list) != 1 {
+		return nil, errors.NoSuchClient("No client with requested id: ", clientID)
+	}
+
+	client := list[0]
+	s.Cache.Put(tenantID, client)
+	return client, nil
+}
+
+// UpdateClientSecret 更新指定租户中的客户端密钥，secret 为编码后的密钥
+func (s *GormService) UpdateClientSecret(clientID string, tenantID types.ID, secret string) error {
+	err := s.table().Where("client_id = ? AND tenant_id = ?", clientID, tenantID).Update("client_secret", secret).Error
+	s.Cache.Evict(clientID)
+	return err
+}
+
+// AddClientDetails 新增客户端，新增的客户端为可用状态
+func (s *GormService) AddClientDetails(client *BaseClientDetails) error {
+	var count int64
+	if err := s.table().Where("client_id = ? AND tenant_id = ? AND deleted_at IS NULL", client.ClientID, client.TenantID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count != 0 {
+		return errors.InvalidClientMetadata("Client already exists: ", client.ClientID)
+	}
+
+	id, err := s.IDGenerator.NextID()
+	if err != nil {
+		return err
+	}
+	client.ID = types.ID(id)
+	client.Status = string(enums.StatusEnabled)
+	return s.table().Create(client).Error
+}
+
+// UpdateClientDetails 更新客户端元数据，只更新客户端所属租户中的客户端
+func (s *GormService) UpdateClientDetails(client *BaseClientDetails) error {
+	err := s.table().Where("client_id = ? AND tenant_id = ? AND deleted_at IS NULL", client.ClientID, client.TenantID).Updates(map[string]interface{}{
+		"resource_ids":            client.ResourceIDs,
+		"scope":                   client.Scope,
+		"authorized_grant_types":  client.AuthorizedGrantTypes,
+		"web_server_redirect_uri": client.WebServerRedirectURI,
+		"additional_information":  client.AdditionalInformation,
+		"updated_at":              time.Now(),
+	}).Error
+	s.Cache.Evict(client.ClientID)
+	return err
+}
+
+// RemoveClientDetails 删除指定租户中的客户端
+func (s *GormService) RemoveClientDetails(clientID string, tenantID types.ID) error {
+	err := s.table().Where("client_id = ? AND tenant_id = ? AND deleted_at IS NULL", clientID, tenantID).Update("deleted_at", time.Now()).Error
+	s.Cache.Evict(clientID)
+	return err
+}
+
+// EvictClient 客户端信息变更后清除缓存
+func (s *GormService) EvictClient(clientIDs ...string) {
+	s.Cache.Evict(clientIDs...)
+}
+
+// EvictAll 清除所有缓存
+func (s *GormService) EvictAll() {
+	s.Cache.Clear()
+}
+
+func (s *GormService) table() *gorm.DB {
+	return s.DB.Table(s.TableName)
+}
@@ -0,0 +1,99 @@
+package clientdetails
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/errors"
+)
+
+// InMemoryClientDetailsService 内存客户端服务，客户端在配置文件中定义
+type InMemoryClientDetailsService struct {
+	lock    sync.RWMutex
+	clients map[string]*BaseClientDetails
+}
+
+// NewInMemoryClientDetailsService 实例化
+func NewInMemoryClientDetailsService(clients []ClientConfig) *InMemoryClientDetailsService {
+	service := &InMemoryClientDetailsService{
+		clients: make(map[string]*BaseClientDetails, len(clients)),
+	}
+	for _, client := range clients {
+		service.clients[client.ClientID] = client.toClientDetails()
+	}
+	return service
+}
+
+// LoadClientByClientID 根据 clientID 获取客户端详细信息
+func (s *InMemoryClientDetailsService) LoadClientByClientID(clientID string) (ClientDetails, error) {
+	s.lock.RLock()
+	client, ok := s.clients[clientID]
+	s.lock.RUnlock()
+	if !ok {
+		return nil, errors.NoSuchClient("No client with requested id: ", clientID)
+	}
+	// 返回副本，避免调用方修改内存中的客户端
+	details := *client
+	return &details, nil
+}
+
+// AddClientDetails 新增客户端，重启后动态注册的客户端丢失
+func (s *InMemoryClientDetailsService) AddClientDetails(client *BaseClientDetails) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.clients[client.ClientID]; ok {
+		return errors.InvalidClientMetadata("Client already exists: ", client.ClientID)
+	}
+	details := *client
+	s.clients[client.ClientID] = &details
+	return nil
+}
+
+// UpdateClientDetails 更新客户端元数据，只更新动态注册可以修改的字段
+func (s *InMemoryClientDetailsService) UpdateClientDetails(client *BaseClientDetails) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	old, ok := s.clients[client.ClientID]
+	if !ok {
+		return errors.NoSuchClient("No client with requested id: ", client.ClientID)
+	}
+	details := *old
+	details.ResourceIDs = client.ResourceIDs
+	details.Scope = client.Scope
+	details.AuthorizedGrantTypes = client.AuthorizedGrantTypes
+	details.WebServerRedirectURI = client.WebServerRedirectURI
+	details.AdditionalInformation = client.AdditionalInformation
+	s.clients[client.ClientID] = &details
+	return nil
+}
+
+// RemoveClientDetails 删除指定租户中的客户端
+func (s *InMemoryClientDetailsService) RemoveClientDetails(clientID string, tenantID types.ID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if client, ok := s.clients[clientID]; !ok || types.ID(client.TenantID) != tenantID {
+		return errors.NoSuchClient("No client with requested id: ", clientID)
+	}
+	delete(s.clients, clientID)
+	return nil
+}
+
+func (c ClientConfig) toClientDetails() *BaseClientDetails {
+	autoapprove := c.AutoApproveScopes
+	if c.AutoApprove {
+		autoapprove = []string{"true"}
+	}
+	return &BaseClientDetails{
+		ClientID:             c.ClientID,
+		ClientSecret:         c.ClientSecret,
+		ResourceIDs:          strings.Join(c.ResourceIDs, ","),
+		Scope:                strings.Join(c.Scope, ","),
+		AuthorizedGrantTypes: strings.Join(c.AuthorizedGrantTypes, ","),
+		WebServerRedirectURI: strings.Join(c.RedirectURIs, ","),
+		Authorities:          strings.Join(c.Authorities, ","),
+		AccessTokenValidity:  c.AccessTokenValiditySeconds,
+		RefreshTokenValidity: c.RefreshTokenValiditySeconds,
+		Autoapprove:          strings.Join(autoapprove, ","),
+	}
+}
@@ -0,0 +1,42 @@
+package clientdetails
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+)
+
+// SecretService 保存客户端密钥
+type SecretService interface {
+	// 更新指定租户中的客户端密钥，secret 为编码后的密钥
+	UpdateClientSecret(clientID string, tenantID types.ID, secret string) error
+}
+
+// PasswordService 客户端认证成功后，使用旧编码方式保存的密钥重新编码后保存
+type PasswordService struct {
+	SecretService SecretService
+}
+
+// NewPasswordService 实例化
+func NewPasswordService(service SecretService) *PasswordService {
+	return &PasswordService{
+		SecretService: service,
+	}
+}
+
+// UpdatePassword 未知客户端所属租户，不升级密钥
+func (s *PasswordService) UpdatePassword(user userdetails.UserDetails, newPassword string) (userdetails.UserDetails, error) {
+	return user, nil
+}
+
+// UpdatePasswordWithDetails 保存新编码的密钥，客户端ID只在租户内唯一，认证详情中未指定租户时不升级密钥
+func (s *PasswordService) UpdatePasswordWithDetails(user userdetails.UserDetails, newPassword string, details interface{}) (userdetails.UserDetails, error) {
+	tenantID := types.NewIDFromString(authentication.DetailsTenantID(details))
+	if tenantID == types.Zero {
+		return user, nil
+	}
+	if err := s.SecretService.UpdateClientSecret(user.GetUsername(), tenantID, newPassword); err != nil {
+		return nil, err
+	}
+	return userdetails.NewUser(user.GetUsername(), newPassword, user.GetAuthorities()), nil
+}
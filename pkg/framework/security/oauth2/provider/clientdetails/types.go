@@ -1,6 +1,9 @@
 package clientdetails
 
-import "github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+)
 
 // ClientDetails 客户端详情
 type ClientDetails interface {
@@ -37,3 +40,29 @@ type Service interface {
 	// 根据 clientID 获取客户端详细信息
 	LoadClientByClientID(string) (ClientDetails, error)
 }
+
+// TenantService 多租户时客户端ID只在租户内唯一，可以根据租户获取客户端
+type TenantService interface {
+	Service
+	// 获取指定租户中的客户端，租户为空时查询所有租户
+	LoadClientByClientIDAndTenant(clientID string, tenantID types.ID) (ClientDetails, error)
+}
+
+// LoadClient 获取客户端，指定租户且客户端服务支持租户时在该租户中获取
+func LoadClient(service Service, clientID, tenantID string) (ClientDetails, error) {
+	if s, ok := service.(TenantService); ok && tenantID != "" {
+		return s.LoadClientByClientIDAndTenant(clientID, types.NewIDFromString(tenantID))
+	}
+	return service.LoadClientByClientID(clientID)
+}
+
+// RegistrationService 可以保存客户端的客户端服务，用于动态注册客户端，RFC 7591/7592
+type RegistrationService interface {
+	Service
+	// 新增客户端，客户端密钥为编码后的密钥
+	AddClientDetails(*BaseClientDetails) error
+	// 更新客户端元数据，只更新资源、scope、授权类型、重定向地址和附加信息
+	UpdateClientDetails(*BaseClientDetails) error
+	// 删除指定租户中的客户端
+	RemoveClientDetails(clientID string, tenantID types.ID) error
+}
@@ -1,10 +1,10 @@
 package clientdetails
 
 import (
-	"reflect"
-
-	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	coreErrors "github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/errors"
 )
 
 // UserDetailsService client实现
@@ -21,11 +21,27 @@ func NewClientDetailsUserDetailsService(service Service) *UserDetailsService {
 
 // LoadUserByUsername 加载指定 username 的用户
 func (s *UserDetailsService) LoadUserByUsername(username string) (userdetails.UserDetails, error) {
-	log.Errorf("LoadUserByUsername clientDetailsService=%s", reflect.TypeOf(s.ClientDetailsService))
-	clientDetails, err := s.ClientDetailsService.LoadClientByClientID(username)
+	return s.LoadUserByUsernameAndDetails(username, nil)
+}
+
+// LoadUserByUsernameAndDetails 在认证详情指定的租户中加载客户端
+func (s *UserDetailsService) LoadUserByUsernameAndDetails(username string, details interface{}) (userdetails.UserDetails, error) {
+	clientDetails, err := LoadClient(s.ClientDetailsService, username, authentication.DetailsTenantID(details))
+	// 客户端不存在时按用户不存在处理，返回统一的认证失败信息
+	if e, ok := err.(*coreErrors.E); ok && e.Code == errors.NoSuchClientCode {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
+	if clientDetails == nil {
+		return nil, nil
+	}
 
 	return userdetails.NewUser(username, clientDetails.GetClientSecret(), clientDetails.GetAuthorities()), nil
 }
+
+// MatchesDetails 客户端认证不使用缓存
+func (s *UserDetailsService) MatchesDetails(userdetails.UserDetails, interface{}) bool {
+	return true
+}
@@ -7,26 +7,64 @@ import (
 
 // API
 const (
-	APIOAuthToken = "/oauth/token"
+	APIOAuthToken   = "/oauth/token"
+	APIOAuthSmsCode = "/oauth/sms/code"
+	// 动态注册客户端，管理端点为 /oauth/register/:clientId
+	APIOAuthRegister = "/oauth/register"
 )
 
 // Paths 所有端点
 var Paths = []string{
 	APIOAuthToken,
+	APIOAuthSmsCode,
+	APIOAuthRegister,
+	APIOAuthRegister + "/*",
 }
 
 // OAuth2Api 端点
 type OAuth2Api struct {
-	TokenEndpoint *TokenEndpoint
+	TokenEndpoint              *TokenEndpoint
+	SmsCodeEndpoint            *SmsCodeEndpoint
+	ClientRegistrationEndpoint *ClientRegistrationEndpoint
 }
 
 // Apply api配置
 func (a *OAuth2Api) Apply(app *ingot.Router) {
 	router := app.Group("oauth")
 	router.POST("/token", a.AccessToken)
+	router.POST("/sms/code", a.SmsCode)
+	router.POST("/register", a.RegisterClient)
+	router.GET("/register/:clientId", a.ReadClient)
+	router.PUT("/register/:clientId", a.UpdateClient)
+	router.DELETE("/register/:clientId", a.DeleteClient)
 }
 
 // AccessToken 获取Token
 func (a *OAuth2Api) AccessToken(ctx *gin.Context) (interface{}, error) {
 	return a.TokenEndpoint.AccessToken(ctx)
 }
+
+// SmsCode 发送短信验证码
+func (a *OAuth2Api) SmsCode(ctx *gin.Context) (interface{}, error) {
+	return nil, a.SmsCodeEndpoint.SendCode(ctx)
+}
+
+// RegisterClient 动态注册客户端
+func (a *OAuth2Api) RegisterClient(ctx *gin.Context) (interface{}, error) {
+	return a.ClientRegistrationEndpoint.Register(ctx)
+}
+
+// ReadClient 获取动态注册的客户端
+func (a *OAuth2Api) ReadClient(ctx *gin.Context) (interface{}, error) {
+	return a.ClientRegistrationEndpoint.Read(ctx)
+}
+
+// UpdateClient 更新动态注册的客户端
+func (a *OAuth2Api) UpdateClient(ctx *gin.Context) (interface{}, error) {
+	return a.ClientRegistrationEndpoint.Update(ctx)
+}
+
+// DeleteClient 删除动态注册的客户端
+func (a *OAuth2Api) DeleteClient(ctx *gin.Context) (interface{}, error) {
+	return nil, a.ClientRegistrationEndpoint.Delete(ctx)
+}
@@ -11,10 +11,12 @@ type OAuth2ApiConfig struct {
 }
 
 // NewOAuth2ApiConfig 实例化
-func NewOAuth2ApiConfig(token *TokenEndpoint) *OAuth2ApiConfig {
+func NewOAuth2ApiConfig(token *TokenEndpoint, smsCode *SmsCodeEndpoint, registration *ClientRegistrationEndpoint) *OAuth2ApiConfig {
 	return &OAuth2ApiConfig{
 		OAuth2Api: &OAuth2Api{
-			TokenEndpoint: token,
+			TokenEndpoint:              token,
+			SmsCodeEndpoint:            smsCode,
+			ClientRegistrationEndpoint: registration,
 		},
 	}
 }
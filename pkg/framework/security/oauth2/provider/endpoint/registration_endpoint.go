@@ -0,0 +1,380 @@
+package endpoint
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	coreErrors "github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/enums"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+	ginwrapper "github.com/ingot-cloud/ingot-go/pkg/framework/core/wrapper/gin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/crypto/password"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/config"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/constants"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/model"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/mtls"
+)
+
+// AuthMethodClientSecretBasic 使用 basic 认证客户端，动态注册的默认认证方式
+const AuthMethodClientSecretBasic = "client_secret_basic"
+
+// 客户端附加信息中的动态注册信息
+const (
+	// 注册访问令牌摘要
+	RegistrationAccessTokenHash = "registration_access_token_hash"
+	// 客户端 JWK Set
+	Jwks = "jwks"
+)
+
+const (
+	clientIDLength                = 32
+	clientSecretLength            = 64
+	registrationAccessTokenLength = 64
+)
+
+// ClientRegistrationEndpoint 动态注册客户端端点，RFC 7591/7592，
+// 管理端点使用注册时签发的注册访问令牌认证
+type ClientRegistrationEndpoint struct {
+	Config              config.Registration
+	RegistrationService clientdetails.RegistrationService
+	PasswordEncoder     password.Encoder
+	trustedProxies      []*net.IPNet
+}
+
+// NewClientRegistrationEndpoint 实例化，service 为空时不支持动态注册
+func NewClientRegistrationEndpoint(config config.Registration, service clientdetails.RegistrationService, encoder password.Encoder) *ClientRegistrationEndpoint {
+	endpoint := &ClientRegistrationEndpoint{
+		Config:              config,
+		RegistrationService: service,
+		PasswordEncoder:     encoder,
+	}
+	for _, item := range config.TrustedProxies {
+		_, network, err := net.ParseCIDR(item)
+		if err != nil {
+			log.Warnf("Ignore invalid registration trusted proxy %s: %v", item, err)
+			continue
+		}
+		endpoint.trustedProxies = append(endpoint.trustedProxies, network)
+	}
+	if config.Enable && config.InitialAccessToken == "" {
+		log.Warnf("Client registration is enabled without initial access token, registration is rejected")
+	}
+	return endpoint
+}
+
+// Register POST /oauth/register
+func (e *ClientRegistrationEndpoint) Register(ctx *gin.Context) (*model.ClientInformation, error) {
+	if !e.enabled() {
+		return nil, coreErrors.NoRoute(ctx.Request.URL.Path)
+	}
+	// 未配置初始访问令牌时不允许开放注册
+	token := ginwrapper.GetBearerToken(ctx)
+	if e.Config.InitialAccessToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(e.Config.InitialAccessToken)) != 1 {
+		return nil, errors.InvalidToken("Invalid initial access token")
+	}
+
+	var metadata model.ClientMetadata
+	if err := ctx.ShouldBindJSON(&metadata); err != nil {
+		return nil, errors.InvalidClientMetadata("Error parsing client metadata - ", err.Error())
+	}
+	if err := e.validate(&metadata); err != nil {
+		return nil, err
+	}
+
+	// 多租户时客户端注册到请求指定的租户
+	tenantID := authentication.TenantID(ctx.Request)
+	if _, ok := e.RegistrationService.(clientdetails.TenantService); ok && types.NewIDFromString(tenantID) == types.Zero {
+		return nil, errors.InvalidClientMetadata("Tenant ID is required")
+	}
+
+	clientID, err := randomString(clientIDLength)
+	if err != nil {
+		return nil, err
+	}
+	client := &clientdetails.BaseClientDetails{
+		TenantID: int(types.NewIDFromString(tenantID)),
+		ClientID: clientID,
+		AuthType: enums.AuthTypeStandard,
+	}
+	var secret string
+	if metadata.TokenEndpointAuthMethod == AuthMethodClientSecretBasic {
+		if secret, err = randomString(clientSecretLength); err != nil {
+			return nil, err
+		}
+		if client.ClientSecret, err = e.PasswordEncoder.Encode(secret); err != nil {
+			return nil, err
+		}
+	}
+	registrationToken, err := randomString(registrationAccessTokenLength)
+	if err != nil {
+		return nil, err
+	}
+	if err = applyMetadata(client, metadata, hashToken(registrationToken)); err != nil {
+		return nil, err
+	}
+	if err = e.RegistrationService.AddClientDetails(client); err != nil {
+		return nil, err
+	}
+
+	info := e.clientInformation(ctx, clientID, metadata)
+	info.ClientSecret = secret
+	info.ClientIDIssuedAt = time.Now().Unix()
+	info.RegistrationAccessToken = registrationToken
+	return info, nil
+}
+
+// Read GET /oauth/register/:clientId，注册访问令牌摘要保存，响应中不包含注册访问令牌和客户端密钥
+func (e *ClientRegistrationEndpoint) Read(ctx *gin.Context) (*model.ClientInformation, error) {
+	client, err := e.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return e.clientInformation(ctx, client.GetClientID(), clientMetadata(client)), nil
+}
+
+// Update PUT /oauth/register/:clientId，使用请求中的元数据替换客户端元数据，并签发新的注册访问令牌，
+// 请求中包含客户端密钥时必须与当前密钥一致，不允许修改客户端密钥，RFC 7592 2.2
+func (e *ClientRegistrationEndpoint) Update(ctx *gin.Context) (*model.ClientInformation, error) {
+	client, err := e.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var request model.ClientInformation
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		return nil, errors.InvalidClientMetadata("Error parsing client metadata - ", err.Error())
+	}
+	if request.ClientID != client.GetClientID() {
+		return nil, errors.InvalidClientMetadata("Client id does not match")
+	}
+	if request.ClientSecret != "" {
+		matches, err := e.PasswordEncoder.Matches(request.ClientSecret, client.GetClientSecret())
+		if err != nil || !matches {
+			return nil, errors.InvalidClientMetadata("Client secret does not match")
+		}
+	}
+	metadata := request.ClientMetadata
+	if err := e.validate(&metadata); err != nil {
+		return nil, err
+	}
+	// 认证方式为 client_secret_basic 时客户端必须已经有密钥
+	if metadata.TokenEndpointAuthMethod == AuthMethodClientSecretBasic && !client.IsSecretRequired() {
+		return nil, errors.InvalidClientMetadata("Client has no secret, token_endpoint_auth_method can not be changed to ", AuthMethodClientSecretBasic)
+	}
+
+	registrationToken, err := randomString(registrationAccessTokenLength)
+	if err != nil {
+		return nil, err
+	}
+	updated := &clientdetails.BaseClientDetails{
+		TenantID:    client.TenantID,
+		ClientID:    client.ClientID,
+		ResourceIDs: client.ResourceIDs,
+	}
+	if err = applyMetadata(updated, metadata, hashToken(registrationToken)); err != nil {
+		return nil, err
+	}
+	if err = e.RegistrationService.UpdateClientDetails(updated); err != nil {
+		return nil, err
+	}
+
+	info := e.clientInformation(ctx, updated.ClientID, metadata)
+	info.RegistrationAccessToken = registrationToken
+	return info, nil
+}
+
+// Delete DELETE /oauth/register/:clientId，只删除已认证客户端所属租户中的客户端
+func (e *ClientRegistrationEndpoint) Delete(ctx *gin.Context) error {
+	client, err := e.authenticate(ctx)
+	if err != nil {
+		return err
+	}
+	return e.RegistrationService.RemoveClientDetails(client.ClientID, types.ID(client.TenantID))
+}
+
+func (e *ClientRegistrationEndpoint) enabled() bool {
+	return e.Config.Enable && e.RegistrationService != nil
+}
+
+// authenticate 使用注册访问令牌认证，客户端不存在时同样返回无效令牌，RFC 7592 2
+func (e *ClientRegistrationEndpoint) authenticate(ctx *gin.Context) (*clientdetails.BaseClientDetails, error) {
+	if !e.enabled() {
+		return nil, coreErrors.NoRoute(ctx.Request.URL.Path)
+	}
+	token := ginwrapper.GetBearerToken(ctx)
+	if token == "" {
+		return nil, errors.InvalidToken("Registration access token is required")
+	}
+	client, err := clientdetails.LoadClient(e.RegistrationService, ctx.Param("clientId"), authentication.TenantID(ctx.Request))
+	if err != nil {
+		if ce, ok := err.(*coreErrors.E); ok && ce.Code == errors.NoSuchClientCode {
+			return nil, errors.InvalidToken("Invalid registration access token")
+		}
+		return nil, err
+	}
+	hashed, _ := client.GetAdditionalInformation()[RegistrationAccessTokenHash].(string)
+	if hashed == "" || subtle.ConstantTimeCompare([]byte(hashToken(token)), []byte(hashed)) != 1 {
+		return nil, errors.InvalidToken("Invalid registration access token")
+	}
+	// 动态注册的客户端使用 BaseClientDetails 保存，管理时需要客户端所属租户
+	base, ok := client.(*clientdetails.BaseClientDetails)
+	if !ok {
+		return nil, errors.InvalidClientMetadata("Client can not be managed: ", client.GetClientID())
+	}
+	return base, nil
+}
+
+// validate 校验元数据并填充默认值
+func (e *ClientRegistrationEndpoint) validate(metadata *model.ClientMetadata) error {
+	if len(metadata.GrantTypes) == 0 {
+		metadata.GrantTypes = []string{constants.GrantTypeCode}
+	}
+	for _, grantType := range metadata.GrantTypes {
+		if !contains(e.Config.GrantTypes, grantType) {
+			return errors.InvalidClientMetadata("Unsupported grant type: ", grantType)
+		}
+	}
+	for _, scope := range strings.Fields(metadata.Scope) {
+		if !contains(e.Config.Scopes, scope) {
+			return errors.InvalidClientMetadata("Unsupported scope: ", scope)
+		}
+	}
+
+	if contains(metadata.GrantTypes, constants.GrantTypeCode) && len(metadata.RedirectURIs) == 0 {
+		return errors.InvalidRedirectURI("Redirect uris are required for grant type ", constants.GrantTypeCode)
+	}
+	for _, redirectURI := range metadata.RedirectURIs {
+		// 重定向 uri 必须为绝对地址且不能包含 fragment，RFC 6749 3.1.2
+		u, err := url.Parse(redirectURI)
+		if err != nil || u.Scheme == "" || u.Host == "" || u.Fragment != "" {
+			return errors.InvalidRedirectURI("Invalid redirect uri: ", redirectURI)
+		}
+	}
+
+	if metadata.TokenEndpointAuthMethod == "" {
+		metadata.TokenEndpointAuthMethod = AuthMethodClientSecretBasic
+	}
+	switch metadata.TokenEndpointAuthMethod {
+	case AuthMethodClientSecretBasic:
+	case mtls.AuthMethodTLSClientAuth:
+		if metadata.TLSClientAuthSubjectDN == "" {
+			return errors.InvalidClientMetadata("tls_client_auth_subject_dn is required for ", mtls.AuthMethodTLSClientAuth)
+		}
+	case mtls.AuthMethodSelfSignedTLSClientAuth:
+		if len(jwksThumbprints(metadata.Jwks)) == 0 {
+			return errors.InvalidClientMetadata("jwks with x5t#S256 is required for ", mtls.AuthMethodSelfSignedTLSClientAuth)
+		}
+	default:
+		return errors.InvalidClientMetadata("Unsupported token_endpoint_auth_method: ", metadata.TokenEndpointAuthMethod)
+	}
+	return nil
+}
+
+// applyMetadata 将元数据保存到客户端，认证方式和证书信息使用 mtls 中定义的附加信息
+func applyMetadata(client *clientdetails.BaseClientDetails, metadata model.ClientMetadata, registrationTokenHash string) error {
+	additional := map[string]interface{}{
+		mtls.TokenEndpointAuthMethod: metadata.TokenEndpointAuthMethod,
+		RegistrationAccessTokenHash:  registrationTokenHash,
+	}
+	if metadata.TLSClientAuthSubjectDN != "" {
+		additional[mtls.TLSClientAuthSubjectDN] = metadata.TLSClientAuthSubjectDN
+	}
+	if metadata.Jwks != nil {
+		additional[Jwks] = metadata.Jwks
+		if thumbprints := jwksThumbprints(metadata.Jwks); len(thumbprints) != 0 {
+			additional[mtls.TLSClientCertificateThumbprints] = strings.Join(thumbprints, ",")
+		}
+	}
+	info, err := json.Marshal(additional)
+	if err != nil {
+		return err
+	}
+
+	client.Scope = strings.Join(strings.Fields(metadata.Scope), ",")
+	client.AuthorizedGrantTypes = strings.Join(metadata.GrantTypes, ",")
+	client.WebServerRedirectURI = strings.Join(metadata.RedirectURIs, ",")
+	client.AdditionalInformation = string(info)
+	return nil
+}
+
+// clientMetadata 从客户端中读取元数据
+func clientMetadata(client clientdetails.ClientDetails) model.ClientMetadata {
+	additional := client.GetAdditionalInformation()
+	metadata := model.ClientMetadata{
+		RedirectURIs: client.GetRegisteredRedirectURI(),
+		GrantTypes:   client.GetAuthorizedGrantTypes(),
+		Scope:        strings.Join(client.GetScope(), " "),
+	}
+	metadata.TokenEndpointAuthMethod, _ = additional[mtls.TokenEndpointAuthMethod].(string)
+	metadata.TLSClientAuthSubjectDN, _ = additional[mtls.TLSClientAuthSubjectDN].(string)
+	metadata.Jwks, _ = additional[Jwks].(map[string]interface{})
+	return metadata
+}
+
+// clientInformation 客户端信息，只使用可信代理设置的 X-Forwarded-Proto 生成客户端管理地址
+func (e *ClientRegistrationEndpoint) clientInformation(ctx *gin.Context, clientID string, metadata model.ClientMetadata) *model.ClientInformation {
+	scheme := "http"
+	if ctx.Request.TLS != nil {
+		scheme = "https"
+	} else if e.isTrustedProxy(ctx) && strings.EqualFold(ctx.GetHeader("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	return &model.ClientInformation{
+		ClientID:              clientID,
+		RegistrationClientURI: scheme + "://" + ctx.Request.Host + APIOAuthRegister + "/" + clientID,
+		ClientMetadata:        metadata,
+	}
+}
+
+// isTrustedProxy 使用连接的远端地址判断，不能使用可被伪造的 X-Forwarded-For
+func (e *ClientRegistrationEndpoint) isTrustedProxy(ctx *gin.Context) bool {
+	ip := net.ParseIP(authentication.RemoteAddress(ctx.Request))
+	if ip == nil {
+		return false
+	}
+	for _, network := range e.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// jwksThumbprints 获取 JWK Set 中证书的 SHA-256 指纹
+func jwksThumbprints(jwks map[string]interface{}) []string {
+	keys, _ := jwks["keys"].([]interface{})
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		jwk, _ := key.(map[string]interface{})
+		if thumbprint, ok := jwk[mtls.X5tS256].(string); ok && thumbprint != "" {
+			result = append(result, thumbprint)
+		}
+	}
+	return result
+}
+
+// hashToken 注册访问令牌为高熵随机串，使用 SHA-256 即可
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(length int) (string, error) {
+	buf := make([]byte, length/2)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,54 @@
+package endpoint
+
+import (
+	"github.com/gin-gonic/gin"
+	coreAuth "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/sms"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/constants"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
+)
+
+// SmsCodeEndpoint 短信验证码端点，只有允许 sms 授权类型的客户端可以发送验证码
+type SmsCodeEndpoint struct {
+	CodeService          *sms.CodeService
+	ClientDetailsService clientdetails.Service
+}
+
+// NewSmsCodeEndpoint 实例化
+func NewSmsCodeEndpoint(codeService *sms.CodeService, clientDetailsService clientdetails.Service) *SmsCodeEndpoint {
+	return &SmsCodeEndpoint{
+		CodeService:          codeService,
+		ClientDetailsService: clientDetailsService,
+	}
+}
+
+// SendCode /oauth/sms/code
+func (e *SmsCodeEndpoint) SendCode(ctx *gin.Context) error {
+	auth := ingot.GetAuthentication(ctx)
+	if auth == nil {
+		return errors.InsufficientAuthentication("There is no client authentication. Try adding an appropriate authentication filter.")
+	}
+	clientID, err := getClientID(auth)
+	if err != nil {
+		return err
+	}
+	client, err := clientdetails.LoadClient(e.ClientDetailsService, clientID, coreAuth.TenantID(ctx.Request))
+	if err != nil {
+		return err
+	}
+	if !contains(client.GetAuthorizedGrantTypes(), constants.GrantTypeSms) {
+		return errors.InvalidClient("Unauthorized grant type: ", constants.GrantTypeSms)
+	}
+	return e.CodeService.Send(ctx.PostForm(constants.Mobile))
+}
+
+func contains(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -1,17 +1,23 @@
 package endpoint
 
 import (
+	"net/url"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	coreAuth "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/attempt"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/event"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/constants"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/errors"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/model"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/authentication"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/mtls"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/request"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token"
 )
@@ -40,13 +46,14 @@ func (t *TokenEndpoint) AccessToken(ctx *gin.Context) (token.OAuth2AccessToken,
 	if err := ctx.ShouldBindWith(&parameters, binding.Form); err != nil {
 		return nil, errors.InsufficientAuthentication("Error parsing request parameters - ", err.Error())
 	}
+	parameters.Additional = additionalParameters(ctx.Request.PostForm)
 
-	clientID, err := t.getClientID(auth)
+	clientID, err := getClientID(auth)
 	if err != nil {
 		return nil, err
 	}
 
-	authenticatedClient, err := t.ClientDetailsService.LoadClientByClientID(clientID)
+	authenticatedClient, err := clientdetails.LoadClient(t.ClientDetailsService, clientID, coreAuth.TenantID(ctx.Request))
 	if err != nil {
 		return nil, err
 	}
@@ -56,6 +63,20 @@ func (t *TokenEndpoint) AccessToken(ctx *gin.Context) (token.OAuth2AccessToken,
 		return nil, err
 	}
 
+	// 记录客户端证书指纹，用于签发证书绑定的访问令牌
+	if cert := mtls.ClientCertificate(ctx.Request); cert != nil {
+		tokenRequest.GetRequestParameters()[mtls.RequestParameterThumbprint] = mtls.Thumbprint(cert)
+	}
+
+	// 记录客户端、客户端地址和跟踪ID，用于登录失败锁定和审计
+	tokenRequest.GetRequestParameters()[constants.ClientID] = tokenRequest.GetClientID()
+	tokenRequest.GetRequestParameters()[attempt.ParameterRemoteAddress] = coreAuth.RemoteAddress(ctx.Request)
+	tokenRequest.GetRequestParameters()[event.ParameterTraceID] = log.FromTraceIDContext(ctx.Request.Context())
+	// 请求头中指定租户时覆盖请求参数
+	if tenantID := coreAuth.TenantID(ctx.Request); tenantID != "" {
+		tokenRequest.GetRequestParameters()[coreAuth.ParameterTenantID] = tenantID
+	}
+
 	if clientID != "" && clientID != tokenRequest.GetClientID() {
 		return nil, errors.InvalidClient("Given client ID does not match authenticated client")
 	}
@@ -97,7 +118,7 @@ func (t *TokenEndpoint) AccessToken(ctx *gin.Context) (token.OAuth2AccessToken,
 	return accessToken, nil
 }
 
-func (t *TokenEndpoint) getClientID(auth core.Authentication) (string, error) {
+func getClientID(auth core.Authentication) (string, error) {
 	if !auth.IsAuthenticated() {
 		return "", errors.InsufficientAuthentication("The client is not authenticated.")
 	}
@@ -164,3 +185,15 @@ func (t *TokenEndpoint) isRefreshTokenRequest(parameters model.RequestParameters
 func (t *TokenEndpoint) isAuthCodeRequest(parameters model.RequestParameters) bool {
 	return parameters.GrantType == constants.GrantTypeCode && parameters.Code != ""
 }
+
+// 保留未绑定的表单参数，由服务端写入的参数不能由请求指定
+func additionalParameters(form url.Values) map[string]string {
+	result := make(map[string]string)
+	for key := range form {
+		if key == mtls.RequestParameterThumbprint {
+			continue
+		}
+		result[key] = form.Get(key)
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package mtls
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token"
+)
+
+// RequestParameterThumbprint 令牌端点将客户端证书指纹保存在请求参数中，供 Enhancer 使用
+const RequestParameterThumbprint = "client_certificate_x5t#S256"
+
+// CertificateBoundEnhancer 将客户端证书指纹作为 cnf.x5t#S256 写入访问令牌，
+// 需要排在 jwt enhancer 之前
+type CertificateBoundEnhancer struct {
+}
+
+// NewCertificateBoundEnhancer 实例化
+func NewCertificateBoundEnhancer() *CertificateBoundEnhancer {
+	return &CertificateBoundEnhancer{}
+}
+
+// Enhance 增强
+func (e *CertificateBoundEnhancer) Enhance(accessToken token.OAuth2AccessToken, auth *authentication.OAuth2Authentication) (token.OAuth2AccessToken, error) {
+	request := auth.GetOAuth2Request()
+	parameters := request.GetRequestParameters()
+	// 刷新令牌时绑定本次请求提交的证书
+	if request.IsRefresh() {
+		parameters = request.GetRefreshTokenRequest().GetRequestParameters()
+	}
+	thumbprint := parameters[RequestParameterThumbprint]
+	if thumbprint == "" {
+		return accessToken, nil
+	}
+
+	accessToken.GetAdditionalInformation()[Confirmation] = map[string]interface{}{
+		X5tS256: thumbprint,
+	}
+	return accessToken, nil
+}
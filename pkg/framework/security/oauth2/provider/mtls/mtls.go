@@ -0,0 +1,86 @@
+package mtls
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"net/http"
+	"strings"
+)
+
+// 客户端认证方式，RFC 8705 2
+const (
+	AuthMethodTLSClientAuth           = "tls_client_auth"
+	AuthMethodSelfSignedTLSClientAuth = "self_signed_tls_client_auth"
+)
+
+// 客户端附加信息中的 mTLS 配置
+const (
+	// 客户端认证方式
+	TokenEndpointAuthMethod = "token_endpoint_auth_method"
+	// tls_client_auth 方式注册的证书主体 DN
+	TLSClientAuthSubjectDN = "tls_client_auth_subject_dn"
+	// self_signed_tls_client_auth 方式注册的证书指纹，多个指纹使用逗号分隔
+	TLSClientCertificateThumbprints = "tls_client_certificate_x5t#S256"
+)
+
+// 证书绑定令牌，RFC 8705 3.1
+const (
+	Confirmation = "cnf"
+	X5tS256      = "x5t#S256"
+)
+
+// ClientCertificate 获取客户端在 TLS 握手中提交的证书
+func ClientCertificate(req *http.Request) *x509.Certificate {
+	if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+		return nil
+	}
+	return req.TLS.PeerCertificates[0]
+}
+
+// VerifiedClientCertificate 获取已经通过 CA 验证的客户端证书
+func VerifiedClientCertificate(req *http.Request) *x509.Certificate {
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return req.TLS.VerifiedChains[0][0]
+}
+
+// Thumbprint 证书 SHA-256 指纹，base64url 编码
+func Thumbprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// BoundThumbprint 获取令牌附加信息中绑定的证书指纹，未绑定时返回空字符串
+func BoundThumbprint(info map[string]interface{}) string {
+	switch cnf := info[Confirmation].(type) {
+	case map[string]interface{}:
+		value, _ := cnf[X5tS256].(string)
+		return value
+	case map[string]string:
+		return cnf[X5tS256]
+	default:
+		return ""
+	}
+}
+
+// RegisteredThumbprints 获取客户端注册的证书指纹
+func RegisteredThumbprints(additional map[string]interface{}) []string {
+	switch value := additional[TLSClientCertificateThumbprints].(type) {
+	case string:
+		return strings.Split(value, ",")
+	case []string:
+		return value
+	case []interface{}:
+		result := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
@@ -38,6 +38,9 @@ func (r *TokenRequest) CreateOAuth2Request(clientDetails clientdetails.ClientDet
 	// Remove password if present to prevent leaks
 	delete(modifiable, constants.Password)
 	delete(modifiable, constants.ClientSecret)
+	delete(modifiable, constants.Otp)
+	delete(modifiable, constants.SmsCode)
+	delete(modifiable, constants.Code)
 	// Add grant type so it can be retrieved from OAuth2Request
 	modifiable[constants.GrantType] = r.GetGrantType()
 
@@ -7,7 +7,8 @@ import (
 	"github.com/ingot-cloud/ingot-go/pkg/framework/core/utils/uuid"
 	securityAuthentication "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/preauth"
-	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/event"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/session"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/constants"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/errors"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/authentication"
@@ -30,6 +31,10 @@ type DefaultTokenServices struct {
 	ClientDetailsService  clientdetails.Service
 	TokenEnhancer         Enhancer
 	AuthenticationManager securityAuthentication.Manager
+	// 并发控制，限制每个用户签发的有效令牌数量
+	ConcurrentControl *session.ConcurrentControl
+	// 发布令牌签发、刷新和撤销事件
+	EventPublisher event.Publisher
 }
 
 // NewDefaultTokenServices 实例化默认 TokenServices
@@ -64,6 +69,7 @@ func (service *DefaultTokenServices) CreateAccessToken(auth *authentication.OAut
 			service.TokenStore.RemoveAccessToken(existingAccessToken)
 		} else {
 			service.TokenStore.StoreAccessToken(existingAccessToken, auth)
+			service.publish(event.TokenIssued, auth)
 			return existingAccessToken, nil
 		}
 	}
@@ -84,6 +90,9 @@ func (service *DefaultTokenServices) CreateAccessToken(auth *authentication.OAut
 	if err != nil {
 		return nil, err
 	}
+	if err := service.registerAccessToken(accessToken, auth); err != nil {
+		return nil, err
+	}
 	service.TokenStore.StoreAccessToken(accessToken, auth)
 
 	refreshToken = accessToken.GetRefreshToken()
@@ -91,6 +100,7 @@ func (service *DefaultTokenServices) CreateAccessToken(auth *authentication.OAut
 		service.TokenStore.StoreRefreshToken(refreshToken, auth)
 	}
 
+	service.publish(event.TokenIssued, auth)
 	return accessToken, nil
 }
 
@@ -113,9 +123,10 @@ func (service *DefaultTokenServices) RefreshAccessToken(refreshTokenValue string
 		return nil, err
 	}
 	if service.AuthenticationManager != nil && !auth.IsClientOnly() {
-		var user core.Authentication
-		user = preauth.NewAuthenticationToken(auth.UserAuthentication, "", auth.GetAuthorities())
-		user, err := service.AuthenticationManager.Authenticate(user)
+		userAuth := preauth.NewAuthenticationToken(auth.UserAuthentication, "", auth.GetAuthorities())
+		// 使用当前请求的客户端和租户，租户与原始认证不一致时拒绝刷新
+		userAuth.SetDetails(tokenRequest.GetRequestParameters())
+		user, err := service.AuthenticationManager.Authenticate(userAuth)
 		if err != nil {
 			return nil, err
 		}
@@ -128,8 +139,12 @@ func (service *DefaultTokenServices) RefreshAccessToken(refreshTokenValue string
 		return nil, errors.InvalidGrant("Wrong client for this refresh token: ", refreshTokenValue)
 	}
 
-	// 清楚当前存储的访问令牌
+	// 清楚当前存储的访问令牌，同时移除旧令牌的并发会话
+	refreshedTokenID := service.getRefreshedTokenID(refreshToken, auth)
 	service.TokenStore.RemoveAccessTokenUsingRefreshToken(refreshToken)
+	if refreshedTokenID != "" {
+		service.ConcurrentControl.Remove(refreshedTokenID)
+	}
 
 	if service.isExpired(refreshToken) {
 		service.TokenStore.RemoveRefreshToken(refreshToken)
@@ -137,6 +152,9 @@ func (service *DefaultTokenServices) RefreshAccessToken(refreshTokenValue string
 	}
 
 	auth, err = service.createRefreshedAuthentication(auth, tokenRequest)
+	if err != nil {
+		return nil, err
+	}
 
 	// 判断是否再次使用当前 RefreshToken，如果不再使用当前RefreshToken，那么创建一个新的
 	if !service.ReuseRefreshToken {
@@ -151,12 +169,16 @@ func (service *DefaultTokenServices) RefreshAccessToken(refreshTokenValue string
 	if err != nil {
 		return nil, err
 	}
+	if err := service.registerAccessToken(accessToken, auth); err != nil {
+		return nil, err
+	}
 	service.TokenStore.StoreAccessToken(accessToken, auth)
 	// 如果不在使用当前RefreshToken，那么保存新的
 	if !service.ReuseRefreshToken {
 		service.TokenStore.StoreRefreshToken(accessToken.GetRefreshToken(), auth)
 	}
 
+	service.publish(event.TokenRefreshed, auth)
 	return accessToken, nil
 }
 
@@ -169,35 +191,50 @@ func (service *DefaultTokenServices) GetAccessToken(auth *authentication.OAuth2A
 
 // LoadAuthentication 通过access token加载身份验证信息
 func (service *DefaultTokenServices) LoadAuthentication(accessTokenValue string) (*authentication.OAuth2Authentication, error) {
+	_, result, err := service.LoadAccessTokenAuthentication(accessTokenValue)
+	return result, err
+}
+
+// LoadAccessTokenAuthentication 通过access token加载访问令牌和身份验证信息
+func (service *DefaultTokenServices) LoadAccessTokenAuthentication(accessTokenValue string) (OAuth2AccessToken, *authentication.OAuth2Authentication, error) {
 	accessToken, err := service.TokenStore.ReadAccessToken(accessTokenValue)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if accessToken == nil {
-		return nil, errors.InvalidToken("Invalid access token: ", accessTokenValue)
+		return nil, nil, errors.InvalidToken("Invalid access token")
 	} else if accessToken.IsExpired() {
 		service.TokenStore.RemoveAccessToken(accessToken)
-		return nil, errors.InvalidToken("Access token expired: ", accessTokenValue)
+		return nil, nil, errors.InvalidToken("Access token expired")
 	}
 
 	result, err := service.TokenStore.ReadAuthentication(accessToken)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if result == nil {
-		return nil, errors.InvalidToken("Invalid access token: ", accessTokenValue)
+		return nil, nil, errors.InvalidToken("Invalid access token")
+	}
+
+	expired, err := service.ConcurrentControl.IsExpired(getTokenID(accessToken))
+	if err != nil {
+		return nil, nil, err
+	}
+	if expired {
+		return nil, nil, errors.InvalidToken("Access token has been expired by concurrent session control")
 	}
 
 	if service.ClientDetailsService != nil {
 		clientID := result.GetOAuth2Request().GetClientID()
 
-		_, err := service.ClientDetailsService.LoadClientByClientID(clientID)
+		// 客户端与用户属于同一租户
+		_, err := clientdetails.LoadClient(service.ClientDetailsService, clientID, securityAuthentication.PrincipalTenantID(result))
 		if err != nil {
-			return nil, errors.InvalidToken("Client not valid: ", clientID, ", original error = ", err.Error())
+			return nil, nil, errors.InvalidToken("Client not valid: ", clientID, ", original error = ", err.Error())
 		}
 	}
 
-	return result, nil
+	return accessToken, result, nil
 }
 
 // ReadAccessToken 读取指定access token详细信息
@@ -213,14 +250,65 @@ func (service *DefaultTokenServices) RevokeToken(tokenValue string) bool {
 	if err != nil || accessToken == nil {
 		return false
 	}
+	auth, _ := service.TokenStore.ReadAuthentication(accessToken)
 
 	if accessToken.GetRefreshToken() != nil {
 		service.TokenStore.RemoveRefreshToken(accessToken.GetRefreshToken())
 	}
 	service.TokenStore.RemoveAccessToken(accessToken)
+	service.ConcurrentControl.Remove(getTokenID(accessToken))
+	if auth != nil {
+		service.publish(event.TokenRevoked, auth)
+	}
 	return true
 }
 
+// 发布令牌事件，审计信息来自令牌请求参数
+func (service *DefaultTokenServices) publish(t event.Type, auth *authentication.OAuth2Authentication) {
+	if service.EventPublisher == nil {
+		return
+	}
+	e := event.NewEvent(t, auth, nil)
+	e.SetDetails(auth.GetOAuth2Request().GetRequestParameters())
+	e.ClientID = auth.GetOAuth2Request().GetClientID()
+	service.EventPublisher.Publish(e)
+}
+
+// 在会话注册表中登记新签发的访问令牌，仅客户端的令牌不做限制
+func (service *DefaultTokenServices) registerAccessToken(accessToken OAuth2AccessToken, auth *authentication.OAuth2Authentication) error {
+	if !service.ConcurrentControl.Enabled() || auth.IsClientOnly() {
+		return nil
+	}
+	return service.ConcurrentControl.OnAuthentication(&session.Information{
+		SessionID: getTokenID(accessToken),
+		Principal: session.PrincipalName(auth),
+		ClientID:  auth.GetOAuth2Request().GetClientID(),
+		ExpiresAt: accessToken.GetExpiration(),
+	})
+}
+
+// 获取刷新令牌关联的访问令牌ID
+func (service *DefaultTokenServices) getRefreshedTokenID(refreshToken OAuth2RefreshToken, auth *authentication.OAuth2Authentication) string {
+	if reader, ok := service.TokenStore.(AccessTokenIDReader); ok {
+		if id, err := reader.ReadAccessTokenID(refreshToken); err == nil && id != "" {
+			return id
+		}
+	}
+	accessToken, err := service.TokenStore.GetAccessToken(auth)
+	if err != nil || accessToken == nil {
+		return ""
+	}
+	return getTokenID(accessToken)
+}
+
+// 获取令牌ID，存在 jti 时使用 jti
+func getTokenID(accessToken OAuth2AccessToken) string {
+	if jti, ok := accessToken.GetAdditionalInformation()[string(constants.TokenJti)].(string); ok && jti != "" {
+		return jti
+	}
+	return accessToken.GetValue()
+}
+
 func (service *DefaultTokenServices) createRefreshToken(auth *authentication.OAuth2Authentication) (OAuth2RefreshToken, error) {
 	support, err := service.isSupportRefreshToken(auth.GetOAuth2Request())
 	if err != nil {
@@ -294,7 +382,7 @@ func (service *DefaultTokenServices) isExpired(refreshToken OAuth2RefreshToken)
 }
 
 func (service *DefaultTokenServices) getAccessTokenValiditySeconds(clientAuth *request.OAuth2Request) (int, error) {
-	client, err := service.getClientDetails(clientAuth.ClientID)
+	client, err := service.getClientDetails(clientAuth.ClientID, clientAuth.GetRequestParameters()[securityAuthentication.ParameterTenantID])
 	if err != nil {
 		return 0, err
 	}
@@ -309,7 +397,7 @@ func (service *DefaultTokenServices) getAccessTokenValiditySeconds(clientAuth *r
 }
 
 func (service *DefaultTokenServices) getRefreshTokenValiditySeconds(clientAuth *request.OAuth2Request) (int, error) {
-	client, err := service.getClientDetails(clientAuth.ClientID)
+	client, err := service.getClientDetails(clientAuth.ClientID, clientAuth.GetRequestParameters()[securityAuthentication.ParameterTenantID])
 	if err != nil {
 		return 0, err
 	}
@@ -324,7 +412,7 @@ func (service *DefaultTokenServices) getRefreshTokenValiditySeconds(clientAuth *
 }
 
 func (service *DefaultTokenServices) isSupportRefreshToken(clientAuth *request.OAuth2Request) (bool, error) {
-	client, err := service.getClientDetails(clientAuth.ClientID)
+	client, err := service.getClientDetails(clientAuth.ClientID, clientAuth.GetRequestParameters()[securityAuthentication.ParameterTenantID])
 	if err != nil {
 		return false, err
 	}
@@ -339,9 +427,10 @@ func (service *DefaultTokenServices) isSupportRefreshToken(clientAuth *request.O
 	return service.SupportRefreshToken, nil
 }
 
-func (service *DefaultTokenServices) getClientDetails(clientID string) (clientdetails.ClientDetails, error) {
+// 多租户时在令牌请求的租户中获取客户端
+func (service *DefaultTokenServices) getClientDetails(clientID, tenantID string) (clientdetails.ClientDetails, error) {
 	if service.ClientDetailsService != nil {
-		return service.ClientDetailsService.LoadClientByClientID(clientID)
+		return clientdetails.LoadClient(service.ClientDetailsService, clientID, tenantID)
 	}
 	return nil, nil
 }
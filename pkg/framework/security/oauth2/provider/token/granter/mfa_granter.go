@@ -0,0 +1,77 @@
+package granter
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/utils/maputil"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/preauth"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/attempt"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/mfa"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/constants"
+	oauth "github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/request"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token"
+)
+
+// MfaTokenGranter 多因素认证授予器，使用密码模式返回的 mfa_token 和动态码换取令牌
+type MfaTokenGranter struct {
+	*BaseTokenGranter
+	tokenServices         token.AuthorizationServerTokenServices
+	authenticationManager authentication.Manager
+	mfaManager            *mfa.Manager
+}
+
+// NewMfaTokenGranter 实例化
+func NewMfaTokenGranter(tokenServices token.AuthorizationServerTokenServices, manager authentication.Manager, mfaManager *mfa.Manager) *MfaTokenGranter {
+	return &MfaTokenGranter{
+		BaseTokenGranter:      &BaseTokenGranter{},
+		tokenServices:         tokenServices,
+		authenticationManager: manager,
+		mfaManager:            mfaManager,
+	}
+}
+
+// Grant 授予
+func (g *MfaTokenGranter) Grant(grantType string, client clientdetails.ClientDetails, tokenRequest *request.TokenRequest) (token.OAuth2AccessToken, error) {
+	if grantType != constants.GrantTypeMfa {
+		return nil, nil
+	}
+
+	err := g.ValidateGrantType(grantType, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.getAccessToken(client, tokenRequest)
+}
+
+func (g *MfaTokenGranter) getAccessToken(client clientdetails.ClientDetails, tokenRequest *request.TokenRequest) (token.OAuth2AccessToken, error) {
+	parameters := maputil.CopyStringStringMap(tokenRequest.GetRequestParameters())
+	mfaToken := parameters[constants.MfaToken]
+	otp := parameters[constants.Otp]
+	delete(parameters, constants.MfaToken)
+	delete(parameters, constants.Otp)
+
+	challenge, err := g.mfaManager.Verify(mfaToken, client.GetClientID(), otp, attempt.RemoteAddress(parameters))
+	if err != nil {
+		return nil, err
+	}
+
+	// 第一步已经校验过密码，这里在第一步的租户中重新加载用户并校验账户状态
+	details, err := authentication.WithTenantID(parameters, challenge.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	userAuth := preauth.NewAuthenticationToken(challenge.Username, "", nil)
+	userAuth.SetDetails(details)
+
+	postUserAuth, err := g.authenticationManager.Authenticate(userAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	storedOAuth2Request := tokenRequest.CreateOAuth2Request(client)
+
+	oauth2Auth := oauth.NewOAuth2Authentication(storedOAuth2Request, postUserAuth)
+	return g.tokenServices.CreateAccessToken(oauth2Auth)
+}
@@ -3,6 +3,7 @@ package granter
 import (
 	"github.com/ingot-cloud/ingot-go/pkg/framework/core/utils/maputil"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/mfa"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/constants"
 	oauth "github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/authentication"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
@@ -15,6 +16,8 @@ type PasswordTokenGranter struct {
 	*BaseTokenGranter
 	tokenServices         token.AuthorizationServerTokenServices
 	authenticationManager authentication.Manager
+	// 用户需要多因素认证时返回 mfa_token，使用 mfa 授权类型换取令牌
+	MfaManager *mfa.Manager
 }
 
 // NewPasswordTokenGranter 实例化
@@ -54,6 +57,11 @@ func (g *PasswordTokenGranter) getAccessToken(client clientdetails.ClientDetails
 		return nil, err
 	}
 
+	err = g.MfaManager.Challenge(postUserAuth, client.GetClientID())
+	if err != nil {
+		return nil, err
+	}
+
 	storedOAuth2Request := tokenRequest.CreateOAuth2Request(client)
 
 	oauth2Auth := oauth.NewOAuth2Authentication(storedOAuth2Request, postUserAuth)
@@ -0,0 +1,61 @@
+package granter
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/utils/maputil"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/constants"
+	oauth "github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/request"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token"
+)
+
+// SmsTokenGranter 短信验证码授予器，使用手机号和短信验证码换取令牌
+type SmsTokenGranter struct {
+	*BaseTokenGranter
+	tokenServices         token.AuthorizationServerTokenServices
+	authenticationManager authentication.Manager
+}
+
+// NewSmsTokenGranter 实例化
+func NewSmsTokenGranter(tokenServices token.AuthorizationServerTokenServices, manager authentication.Manager) *SmsTokenGranter {
+	return &SmsTokenGranter{
+		BaseTokenGranter:      &BaseTokenGranter{},
+		tokenServices:         tokenServices,
+		authenticationManager: manager,
+	}
+}
+
+// Grant 授予
+func (g *SmsTokenGranter) Grant(grantType string, client clientdetails.ClientDetails, tokenRequest *request.TokenRequest) (token.OAuth2AccessToken, error) {
+	if grantType != constants.GrantTypeSms {
+		return nil, nil
+	}
+
+	err := g.ValidateGrantType(grantType, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.getAccessToken(client, tokenRequest)
+}
+
+func (g *SmsTokenGranter) getAccessToken(client clientdetails.ClientDetails, tokenRequest *request.TokenRequest) (token.OAuth2AccessToken, error) {
+	parameters := maputil.CopyStringStringMap(tokenRequest.GetRequestParameters())
+	mobile := parameters[constants.Mobile]
+	code := parameters[constants.SmsCode]
+	delete(parameters, constants.SmsCode)
+
+	userAuth := authentication.NewUnauthenticatedSmsCodeAuthToken(mobile, code)
+	userAuth.SetDetails(parameters)
+
+	postUserAuth, err := g.authenticationManager.Authenticate(userAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	storedOAuth2Request := tokenRequest.CreateOAuth2Request(client)
+
+	oauth2Auth := oauth.NewOAuth2Authentication(storedOAuth2Request, postUserAuth)
+	return g.tokenServices.CreateAccessToken(oauth2Auth)
+}
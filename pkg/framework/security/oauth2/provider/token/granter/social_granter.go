@@ -0,0 +1,61 @@
+package granter
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/utils/maputil"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/constants"
+	oauth "github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/request"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token"
+)
+
+// SocialTokenGranter 第三方登录授予器，使用第三方平台的授权码换取令牌
+type SocialTokenGranter struct {
+	*BaseTokenGranter
+	tokenServices         token.AuthorizationServerTokenServices
+	authenticationManager authentication.Manager
+}
+
+// NewSocialTokenGranter 实例化
+func NewSocialTokenGranter(tokenServices token.AuthorizationServerTokenServices, manager authentication.Manager) *SocialTokenGranter {
+	return &SocialTokenGranter{
+		BaseTokenGranter:      &BaseTokenGranter{},
+		tokenServices:         tokenServices,
+		authenticationManager: manager,
+	}
+}
+
+// Grant 授予
+func (g *SocialTokenGranter) Grant(grantType string, client clientdetails.ClientDetails, tokenRequest *request.TokenRequest) (token.OAuth2AccessToken, error) {
+	if grantType != constants.GrantTypeSocial {
+		return nil, nil
+	}
+
+	err := g.ValidateGrantType(grantType, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.getAccessToken(client, tokenRequest)
+}
+
+func (g *SocialTokenGranter) getAccessToken(client clientdetails.ClientDetails, tokenRequest *request.TokenRequest) (token.OAuth2AccessToken, error) {
+	parameters := maputil.CopyStringStringMap(tokenRequest.GetRequestParameters())
+	socialType := parameters[constants.SocialType]
+	code := parameters[constants.Code]
+	delete(parameters, constants.Code)
+
+	userAuth := authentication.NewUnauthenticatedSocialAuthToken(socialType, code)
+	userAuth.SetDetails(parameters)
+
+	postUserAuth, err := g.authenticationManager.Authenticate(userAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	storedOAuth2Request := tokenRequest.CreateOAuth2Request(client)
+
+	oauth2Auth := oauth.NewOAuth2Authentication(storedOAuth2Request, postUserAuth)
+	return g.tokenServices.CreateAccessToken(oauth2Auth)
+}
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/constants"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/errors"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/authentication"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token"
@@ -100,6 +101,16 @@ func (store *JwtTokenStore) FindTokensByClientID(clientID string) ([]token.OAuth
 	return nil, nil
 }
 
+// ReadAccessTokenID 通过刷新令牌中的 ati 读取关联的访问令牌ID
+func (store *JwtTokenStore) ReadAccessTokenID(refreshToken token.OAuth2RefreshToken) (string, error) {
+	info, err := store.JwtTokenEnhancer.Decode(refreshToken.GetRefreshTokenValue())
+	if err != nil {
+		return "", err
+	}
+	ati, _ := info[string(constants.TokenAti)].(string)
+	return ati, nil
+}
+
 func (store *JwtTokenStore) convertAccessToken(tokenValue string) (token.OAuth2AccessToken, error) {
 	info, err := store.JwtTokenEnhancer.Decode(tokenValue)
 	if err != nil {
@@ -31,3 +31,9 @@ type Store interface {
 	// 通过clientID获取所有访问令牌
 	FindTokensByClientID(string) ([]OAuth2AccessToken, error)
 }
+
+// AccessTokenIDReader 通过刷新令牌读取关联的访问令牌ID，不保存令牌的存储可以实现该接口，
+// 刷新令牌时用于移除旧的并发会话
+type AccessTokenIDReader interface {
+	ReadAccessTokenID(OAuth2RefreshToken) (string, error)
+}
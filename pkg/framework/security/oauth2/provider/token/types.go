@@ -59,6 +59,11 @@ type ResourceServerTokenServices interface {
 	ReadAccessToken(string) (OAuth2AccessToken, error)
 }
 
+// AccessTokenAuthenticationLoader 加载身份验证信息时同时返回访问令牌，避免重复读取令牌
+type AccessTokenAuthenticationLoader interface {
+	LoadAccessTokenAuthentication(string) (OAuth2AccessToken, *authentication.OAuth2Authentication, error)
+}
+
 // ConsumerTokenServices token 消费者服务
 type ConsumerTokenServices interface {
 	// 撤销令牌
@@ -9,6 +9,8 @@ import (
 // WebSecurityConfigurer Web security 配置
 type WebSecurityConfigurer interface {
 	WebConfigure(WebSecurityBuilder) error
+	// 配置排序，升序构建 SecurityFilterChain，先匹配的链优先执行
+	Order() int
 }
 
 // HTTPSecurityConfigurer HTTP security 配置
@@ -50,3 +52,9 @@ type AuthorizationServerConfigurer interface {
 	WebSecurityConfigurer
 	Authorization()
 }
+
+// FormLoginConfigurer 表单登录配置
+type FormLoginConfigurer interface {
+	WebSecurityConfigurer
+	FormLogin()
+}
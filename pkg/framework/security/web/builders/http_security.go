@@ -13,15 +13,17 @@ import (
 // HTTPSecurity http 安全配置
 type HTTPSecurity struct {
 	requestMatcher utils.RequestMatcher
-	filters        map[string]filter.Filter
-	configurers    map[string]security.HTTPSecurityConfigurer
+	filters        filter.Filters
+	configurers    []security.HTTPSecurityConfigurer
+	filterTypes    map[string]bool
+	configurerType map[string]bool
 }
 
 // NewHTTPSecurity 创建 HTTPSecurity
 func NewHTTPSecurity() *HTTPSecurity {
 	return &HTTPSecurity{
-		filters:     make(map[string]filter.Filter),
-		configurers: make(map[string]security.HTTPSecurityConfigurer),
+		filterTypes:    make(map[string]bool),
+		configurerType: make(map[string]bool),
 	}
 }
 
@@ -47,23 +49,25 @@ func (security *HTTPSecurity) RequestMatcher(requestMatcher utils.RequestMatcher
 // AddFilter 添加 Filter
 func (security *HTTPSecurity) AddFilter(filter filter.Filter) {
 	typeStr := coreUtils.GetType(filter)
-	if _, ok := security.filters[typeStr]; !ok {
-		security.filters[typeStr] = filter
+	if !security.filterTypes[typeStr] {
+		security.filterTypes[typeStr] = true
+		security.filters = append(security.filters, filter)
 	}
 }
 
 // Apply 应用配置
 func (security *HTTPSecurity) Apply(configurer security.HTTPSecurityConfigurer) {
 	typeStr := coreUtils.GetType(configurer)
-	if _, ok := security.configurers[typeStr]; !ok {
-		security.configurers[typeStr] = configurer
+	if !security.configurerType[typeStr] {
+		security.configurerType[typeStr] = true
+		security.configurers = append(security.configurers, configurer)
 	}
 }
 
 func (security *HTTPSecurity) configure() error {
-	// 执行配置
-	for _, item := range security.configurers {
-		if err := item.HTTPConfigure(security); err != nil {
+	// 按应用顺序执行配置，配置过程中新应用的配置同样会被执行
+	for i := 0; i < len(security.configurers); i++ {
+		if err := security.configurers[i].HTTPConfigure(security); err != nil {
 			return err
 		}
 	}
@@ -71,14 +75,11 @@ func (security *HTTPSecurity) configure() error {
 }
 
 func (security *HTTPSecurity) performBuild() securityFilter.SecurityFilterChain {
-	size := len(security.filters)
-	filters := make(filter.Filters, 0, size)
-	for _, filter := range security.filters {
-		filters = append(filters, filter)
-	}
+	filters := make(filter.Filters, len(security.filters))
+	copy(filters, security.filters)
 
-	// 使用升序进行filter排序
-	sort.Sort(filters)
+	// 使用升序进行filter排序，相同排序保持添加顺序
+	sort.Stable(filters)
 	return &securityFilter.DefaultSecurityFilterChain{
 		RequestMatcher: security.requestMatcher,
 		Filters:        filters,
@@ -1,6 +1,10 @@
 package builders
 
 import (
+	"fmt"
+	"sort"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
 	coreUtils "github.com/ingot-cloud/ingot-go/pkg/framework/core/utils"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/core/web/filter"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
@@ -13,13 +17,14 @@ import (
 type WebSecurity struct {
 	securityFilterChainBuilders []security.HTTPSecurityBuilder
 	ignoredRequests             []utils.RequestMatcher
-	webSecurityConfigurers      map[string]security.WebSecurityConfigurer
+	webSecurityConfigurers      []security.WebSecurityConfigurer
+	appliedConfigurers          map[string]bool
 }
 
 // NewWebSecurity 创建 WebSecurity
 func NewWebSecurity() *WebSecurity {
 	return &WebSecurity{
-		webSecurityConfigurers: make(map[string]security.WebSecurityConfigurer),
+		appliedConfigurers: make(map[string]bool),
 	}
 }
 
@@ -45,14 +50,18 @@ func (w *WebSecurity) AddIgnoreRequestMatcher(matcher utils.RequestMatcher) {
 // Apply 应用Web安全配置
 func (w *WebSecurity) Apply(configurer security.WebSecurityConfigurer) {
 	typeStr := coreUtils.GetType(configurer)
-	log.Debugf("web security apply config = %s", typeStr)
-	if _, ok := w.webSecurityConfigurers[typeStr]; !ok {
-		w.webSecurityConfigurers[typeStr] = configurer
+	log.Debugf("web security apply config = %s, order = %d", typeStr, configurer.Order())
+	if !w.appliedConfigurers[typeStr] {
+		w.appliedConfigurers[typeStr] = true
+		w.webSecurityConfigurers = append(w.webSecurityConfigurers, configurer)
 	}
 }
 
 func (w *WebSecurity) configure() error {
-	// 执行配置
+	// 按照 Order 升序执行配置，相同排序保持应用顺序
+	sort.SliceStable(w.webSecurityConfigurers, func(i, j int) bool {
+		return w.webSecurityConfigurers[i].Order() < w.webSecurityConfigurers[j].Order()
+	})
 	for _, item := range w.webSecurityConfigurers {
 		if err := item.WebConfigure(w); err != nil {
 			return err
@@ -65,7 +74,7 @@ func (w *WebSecurity) performBuild() (filter.Filter, error) {
 	chainSize := len(w.ignoredRequests) + len(w.securityFilterChainBuilders)
 	securityFilterChains := make([]securityFilter.SecurityFilterChain, 0, chainSize)
 
-	// 忽略的请求
+	// 忽略的请求
 	for _, ignoredRequest := range w.ignoredRequests {
 		securityFilterChains = append(securityFilterChains, &securityFilter.DefaultSecurityFilterChain{
 			RequestMatcher: ignoredRequest,
@@ -80,8 +89,28 @@ func (w *WebSecurity) performBuild() (filter.Filter, error) {
 		securityFilterChains = append(securityFilterChains, chain)
 	}
 
+	if err := validateFilterChains(securityFilterChains); err != nil {
+		return nil, err
+	}
+
 	filterChainProxy := &securityFilter.ChainProxy{
 		FilterChains: securityFilterChains,
 	}
 	return filterChainProxy, nil
 }
+
+// 匹配所有请求的过滤器链只能位于最后，否则之后的过滤器链永远不会执行
+func validateFilterChains(chains []securityFilter.SecurityFilterChain) error {
+	for index, chain := range chains {
+		if index == len(chains)-1 {
+			break
+		}
+		if utils.IsAnyRequestMatcher(chain.GetRequestMatcher()) {
+			shadowed := chains[index+1]
+			return errors.InternalServer(fmt.Sprintf(
+				"A filter chain that matches any request [index=%d] has already been configured, which means that this filter chain [index=%d, matcher=%s] will never get invoked. Please check the order of WebSecurityConfigurer",
+				index, index+1, shadowed.GetRequestMatcher()))
+		}
+	}
+	return nil
+}
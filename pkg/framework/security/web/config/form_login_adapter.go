@@ -0,0 +1,114 @@
+package config
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/constants"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/mfa"
+	coreSession "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/session"
+	anonymous "github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/anoymous"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/authresult"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/cors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/csrf"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/formlogin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/headers"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/logout"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/rememberme"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/securitycontext"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/session"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/utils"
+)
+
+// FormLoginConfigurerAdapter 表单登录安全配置，基于服务端会话保存身份信息
+type FormLoginConfigurerAdapter struct {
+	*WebSecurityConfigurerAdapter
+
+	config                formlogin.Config
+	authenticationManager authentication.Manager
+	sessionStore          session.Store
+	csrfConfigurer        *csrf.SecurityConfigurer
+	rememberMeConfigurer  *rememberme.SecurityConfigurer
+	corsSource            cors.ConfigurationSource
+	headersConfig         headers.Config
+	concurrentControl     *coreSession.ConcurrentControl
+	mfaManager            *mfa.Manager
+}
+
+// NewFormLoginConfigurer 实例化
+func NewFormLoginConfigurer(config formlogin.Config, manager authentication.Manager, store session.Store, csrfConfigurer *csrf.SecurityConfigurer, rememberMeConfigurer *rememberme.SecurityConfigurer, corsSource cors.ConfigurationSource, headersConfig headers.Config, concurrentControl *coreSession.ConcurrentControl, mfaManager *mfa.Manager) *FormLoginConfigurerAdapter {
+	instance := &FormLoginConfigurerAdapter{
+		config:                config,
+		authenticationManager: manager,
+		sessionStore:          store,
+		csrfConfigurer:        csrfConfigurer,
+		rememberMeConfigurer:  rememberMeConfigurer,
+		corsSource:            corsSource,
+		headersConfig:         headersConfig,
+		concurrentControl:     concurrentControl,
+		mfaManager:            mfaManager,
+	}
+
+	instance.WebSecurityConfigurerAdapter = NewWebSecurityConfigurerAdapter(instance)
+	instance.SetOrder(constants.OrderWebSecurityFormLogin)
+	return instance
+}
+
+// FormLogin 标识
+func (*FormLoginConfigurerAdapter) FormLogin() {}
+
+// HTTPConfigure 配置
+func (a *FormLoginConfigurerAdapter) HTTPConfigure(http security.HTTPSecurityBuilder) error {
+	http.RequestMatcher(a.RequestMatcher())
+	http.Apply(headers.NewSecurityConfigurer(a.headersConfig))
+	http.Apply(cors.NewSecurityConfigurer(a.corsSource))
+	http.Apply(a.securityContextConfigurer())
+	http.Apply(a.csrfConfigurer)
+	http.Apply(a.logoutConfigurer())
+	http.Apply(a.formLoginConfigurer())
+	http.Apply(a.rememberMeConfigurer)
+	http.Apply(anonymous.NewSecurityConfigurer())
+	http.Apply(authresult.NewSecurityConfigurer())
+	return nil
+}
+
+// RequestMatcher 匹配表单登录保护的路径以及登录、注销路径
+func (a *FormLoginConfigurerAdapter) RequestMatcher() utils.RequestMatcher {
+	patterns := []string{a.config.Pattern, a.config.LoginProcessingURL}
+	if a.config.LogoutURL != "" {
+		patterns = append(patterns, a.config.LogoutURL)
+	}
+	if a.mfaManager.Enabled() {
+		patterns = append(patterns, a.config.GetMfaProcessingURL())
+	}
+	return utils.NewAntPathRequestMatchers(patterns...)
+}
+
+func (a *FormLoginConfigurerAdapter) logoutConfigurer() *logout.SecurityConfigurer {
+	configurer := logout.NewSecurityConfigurer(a.config.LogoutURL)
+	if a.csrfConfigurer.Config.Enable {
+		configurer.AddHandler(csrf.NewLogoutHandler(a.csrfConfigurer.TokenRepository))
+	}
+	if handler, ok := a.rememberMeConfigurer.Services.(logout.Handler); ok && a.rememberMeConfigurer.Config.Enable {
+		configurer.AddHandler(handler)
+	}
+	sessionHandler := logout.NewSessionHandler(a.sessionStore)
+	sessionHandler.ConcurrentControl = a.concurrentControl
+	configurer.AddHandler(sessionHandler)
+	return configurer
+}
+
+func (a *FormLoginConfigurerAdapter) securityContextConfigurer() *securitycontext.SecurityConfigurer {
+	configurer := securitycontext.NewSecurityConfigurer(a.sessionStore)
+	configurer.ConcurrentControl = a.concurrentControl
+	return configurer
+}
+
+func (a *FormLoginConfigurerAdapter) formLoginConfigurer() *formlogin.SecurityConfigurer {
+	configurer := formlogin.NewSecurityConfigurer(a.config, a.authenticationManager, a.sessionStore)
+	if a.rememberMeConfigurer.Config.Enable {
+		configurer.RememberMeServices = a.rememberMeConfigurer.Services
+	}
+	configurer.ConcurrentControl = a.concurrentControl
+	configurer.MfaManager = a.mfaManager
+	return configurer
+}
@@ -2,21 +2,35 @@ package config
 
 import (
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/constants"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/builders"
 )
 
 // WebSecurityConfigurerAdapter 安全配置适配器
 type WebSecurityConfigurerAdapter struct {
 	AdditionalHTTPSecurityConfigurer security.HTTPSecurityConfigurer
+
+	order int
 }
 
 // NewWebSecurityConfigurerAdapter 实例化
 func NewWebSecurityConfigurerAdapter(httpSecurity security.HTTPSecurityConfigurer) *WebSecurityConfigurerAdapter {
 	return &WebSecurityConfigurerAdapter{
 		AdditionalHTTPSecurityConfigurer: httpSecurity,
+		order:                            constants.OrderWebSecurityDefault,
 	}
 }
 
+// Order 配置排序
+func (adapter *WebSecurityConfigurerAdapter) Order() int {
+	return adapter.order
+}
+
+// SetOrder 设置配置排序
+func (adapter *WebSecurityConfigurerAdapter) SetOrder(order int) {
+	adapter.order = order
+}
+
 // WebConfigure Web安全配置
 func (adapter *WebSecurityConfigurerAdapter) WebConfigure(web security.WebSecurityBuilder) error {
 	http, err := adapter.getHTTP()
@@ -0,0 +1,30 @@
+package apikey
+
+// 默认名称
+const (
+	DefaultHeaderName    = "X-API-KEY"
+	DefaultParameterName = "api_key"
+)
+
+// Config API key 配置
+type Config struct {
+	Enable bool `yaml:"enable"`
+	// 读取 key 的请求头
+	HeaderName string `yaml:"headerName"`
+	// 读取 key 的查询参数，请求头中不存在时使用
+	ParameterName string `yaml:"parameterName"`
+}
+
+func (c Config) headerName() string {
+	if c.HeaderName == "" {
+		return DefaultHeaderName
+	}
+	return c.HeaderName
+}
+
+func (c Config) parameterName() string {
+	if c.ParameterName == "" {
+		return DefaultParameterName
+	}
+	return c.ParameterName
+}
@@ -0,0 +1,29 @@
+package apikey
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+)
+
+// SecurityConfigurer API key 验证
+type SecurityConfigurer struct {
+	Config                Config
+	AuthenticationManager authentication.Manager
+}
+
+// NewSecurityConfigurer 配置
+func NewSecurityConfigurer(config Config, manager authentication.Manager) *SecurityConfigurer {
+	return &SecurityConfigurer{
+		Config:                config,
+		AuthenticationManager: manager,
+	}
+}
+
+// HTTPConfigure 配置
+func (c *SecurityConfigurer) HTTPConfigure(http security.HTTPSecurityBuilder) error {
+	if !c.Config.Enable {
+		return nil
+	}
+	http.AddFilter(NewFilter(c.Config, c.AuthenticationManager))
+	return nil
+}
@@ -0,0 +1,54 @@
+package apikey
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/web/filter"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/constants"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+)
+
+// Filter API key 验证，从请求头或查询参数中读取 key
+type Filter struct {
+	Config                Config
+	AuthenticationManager authentication.Manager
+}
+
+// NewFilter 实例化
+func NewFilter(config Config, manager authentication.Manager) *Filter {
+	return &Filter{
+		Config:                config,
+		AuthenticationManager: manager,
+	}
+}
+
+// Name 名字
+func (f *Filter) Name() string {
+	return "ApiKeyAuthenticationFilter"
+}
+
+// Order 过滤器排序
+func (f *Filter) Order() int {
+	return constants.OrderFilterApiKey
+}
+
+// DoFilter 执行过滤器
+func (f *Filter) DoFilter(context *ingot.Context, chain filter.Chain) error {
+	key := f.obtainKey(context)
+	if key == "" {
+		return chain.DoFilter(context)
+	}
+
+	authResult, err := f.AuthenticationManager.Authenticate(authentication.NewUnauthenticatedApiKeyAuthToken(key))
+	if err != nil {
+		return err
+	}
+	context.SetAuthentication(authResult)
+	return chain.DoFilter(context)
+}
+
+func (f *Filter) obtainKey(context *ingot.Context) string {
+	if key := context.GetHeader(f.Config.headerName()); key != "" {
+		return key
+	}
+	return context.Query(f.Config.parameterName())
+}
@@ -40,5 +40,7 @@ func (c *AuthenticationConverter) Converter(ctx *ingot.Context) (*authentication
 	token := strings.Split(rawString, ":")
 
 	result := authentication.NewClientUsernamePasswordAuthToken(token[0], token[1])
+	// 多租户时在请求头指定的租户中加载客户端
+	result.SetDetails(authentication.NewWebAuthenticationDetails(ctx))
 	return result, nil
 }
@@ -0,0 +1,220 @@
+package cors
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/utils"
+)
+
+// All 匹配所有值
+const All = "*"
+
+// 未配置请求方法时默认允许的方法
+var defaultAllowedMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+
+// Config 跨域配置
+type Config struct {
+	Enable bool `yaml:"enable"`
+	// 按顺序匹配路径，使用第一个匹配的配置
+	Mappings []Mapping `yaml:"mappings"`
+}
+
+// Mapping 路径和跨域配置映射
+type Mapping struct {
+	// Ant 风格路径
+	Pattern       string `yaml:"pattern"`
+	Configuration `yaml:",inline" mapstructure:",squash"`
+}
+
+// Configuration 跨域配置
+type Configuration struct {
+	// 允许的来源，* 代表所有来源，允许携带凭证时不能使用 *
+	AllowedOrigins []string `yaml:"allowedOrigins"`
+	// 允许的来源模式，例如 https://*.ingot.cloud
+	AllowedOriginPatterns []string `yaml:"allowedOriginPatterns"`
+	// 允许的请求方法，为空时允许 GET, HEAD, POST
+	AllowedMethods []string `yaml:"allowedMethods"`
+	// 允许的请求头，* 代表所有请求头
+	AllowedHeaders []string `yaml:"allowedHeaders"`
+	// 暴露给浏览器的响应头
+	ExposedHeaders []string `yaml:"exposedHeaders"`
+	// 是否允许携带凭证
+	AllowCredentials bool `yaml:"allowCredentials"`
+	// 预检请求缓存时间，单位秒
+	MaxAge int64 `yaml:"maxAge"`
+
+	originPatterns []*regexp.Regexp
+}
+
+// Validate 校验配置，允许携带凭证时必须指定明确的来源或来源模式，
+// 否则任意站点都可以携带凭证跨域读取响应
+func (c *Configuration) Validate() error {
+	if !c.AllowCredentials {
+		return nil
+	}
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == All {
+			return errors.IllegalArgument("When allowCredentials is true, allowedOrigins cannot contain the special value \"*\", use allowedOriginPatterns or explicit origins instead")
+		}
+	}
+	for _, pattern := range c.AllowedOriginPatterns {
+		if strings.Trim(pattern, All) == "" {
+			return errors.IllegalArgument("When allowCredentials is true, allowedOriginPatterns cannot match all origins")
+		}
+	}
+	return nil
+}
+
+// CheckOrigin 校验来源，返回允许的来源，不允许时返回空字符串
+func (c *Configuration) CheckOrigin(origin string) string {
+	if origin == "" {
+		return ""
+	}
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == All {
+			// 携带凭证时 * 无效
+			if c.AllowCredentials {
+				continue
+			}
+			return All
+		}
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return origin
+		}
+	}
+	for _, pattern := range c.compileOriginPatterns() {
+		if pattern.MatchString(origin) {
+			return origin
+		}
+	}
+	return ""
+}
+
+// CheckMethod 校验请求方法，返回允许的方法，不允许时返回nil
+func (c *Configuration) CheckMethod(method string) []string {
+	if method == "" {
+		return nil
+	}
+	allowed := c.AllowedMethods
+	if len(allowed) == 0 {
+		allowed = defaultAllowedMethods
+	}
+	for _, m := range allowed {
+		if m == All {
+			return []string{method}
+		}
+	}
+	for _, m := range allowed {
+		if strings.EqualFold(m, method) {
+			return allowed
+		}
+	}
+	return nil
+}
+
+// CheckHeaders 校验请求头，返回允许的请求头，存在不允许的请求头时返回false
+func (c *Configuration) CheckHeaders(headers []string) ([]string, bool) {
+	if len(headers) == 0 {
+		return nil, true
+	}
+	if len(c.AllowedHeaders) == 0 {
+		return nil, false
+	}
+	allowAll := false
+	for _, h := range c.AllowedHeaders {
+		if h == All {
+			allowAll = true
+			break
+		}
+	}
+
+	result := make([]string, 0, len(headers))
+	for _, header := range headers {
+		if allowAll {
+			result = append(result, header)
+			continue
+		}
+		for _, h := range c.AllowedHeaders {
+			if strings.EqualFold(h, header) {
+				result = append(result, header)
+				break
+			}
+		}
+	}
+	return result, len(result) == len(headers)
+}
+
+func (c *Configuration) compileOriginPatterns() []*regexp.Regexp {
+	if c.originPatterns != nil || len(c.AllowedOriginPatterns) == 0 {
+		return c.originPatterns
+	}
+	patterns := make([]*regexp.Regexp, 0, len(c.AllowedOriginPatterns))
+	for _, p := range c.AllowedOriginPatterns {
+		expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(strings.TrimSuffix(p, "/")), `\*`, ".*") + "$"
+		patterns = append(patterns, regexp.MustCompile("(?i)"+expr))
+	}
+	c.originPatterns = patterns
+	return patterns
+}
+
+// ConfigurationSource 跨域配置源
+type ConfigurationSource interface {
+	// 获取当前请求的跨域配置，返回nil代表不处理跨域
+	GetCorsConfiguration(*ingot.Context) *Configuration
+}
+
+// URLBasedConfigurationSource 基于请求路径的跨域配置源
+type URLBasedConfigurationSource struct {
+	matchers       []utils.RequestMatcher
+	configurations []*Configuration
+}
+
+// NewURLBasedConfigurationSource 实例化
+func NewURLBasedConfigurationSource() *URLBasedConfigurationSource {
+	return &URLBasedConfigurationSource{}
+}
+
+// NewConfigurationSource 通过配置创建跨域配置源，未开启时不匹配任何路径
+func NewConfigurationSource(config Config) *URLBasedConfigurationSource {
+	source := NewURLBasedConfigurationSource()
+	if !config.Enable {
+		return source
+	}
+	for _, mapping := range config.Mappings {
+		configuration := mapping.Configuration
+		source.RegisterCorsConfiguration(mapping.Pattern, &configuration)
+	}
+	return source
+}
+
+// RegisterCorsConfiguration 注册路径对应的跨域配置，按注册顺序匹配
+func (s *URLBasedConfigurationSource) RegisterCorsConfiguration(pattern string, configuration *Configuration) {
+	configuration.compileOriginPatterns()
+	s.matchers = append(s.matchers, utils.NewAntPathRequestMatcher(pattern))
+	s.configurations = append(s.configurations, configuration)
+}
+
+// Validate 校验所有跨域配置
+func (s *URLBasedConfigurationSource) Validate() error {
+	for index, configuration := range s.configurations {
+		if err := configuration.Validate(); err != nil {
+			return errors.IllegalArgument(fmt.Sprintf("Invalid cors configuration [%s]: %s", s.matchers[index], err.Error()))
+		}
+	}
+	return nil
+}
+
+// GetCorsConfiguration 获取当前请求的跨域配置
+func (s *URLBasedConfigurationSource) GetCorsConfiguration(ctx *ingot.Context) *Configuration {
+	for index, matcher := range s.matchers {
+		if matcher.Matches(ctx) {
+			return s.configurations[index]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package cors
+
+import "github.com/ingot-cloud/ingot-go/pkg/framework/security"
+
+// SecurityConfigurer 跨域配置
+type SecurityConfigurer struct {
+	ConfigurationSource ConfigurationSource
+}
+
+// NewSecurityConfigurer 配置
+func NewSecurityConfigurer(source ConfigurationSource) *SecurityConfigurer {
+	return &SecurityConfigurer{
+		ConfigurationSource: source,
+	}
+}
+
+// HTTPConfigure 配置
+func (c *SecurityConfigurer) HTTPConfigure(http security.HTTPSecurityBuilder) error {
+	if source, ok := c.ConfigurationSource.(*URLBasedConfigurationSource); ok {
+		if err := source.Validate(); err != nil {
+			return err
+		}
+	}
+	http.AddFilter(NewFilter(c.ConfigurationSource))
+	return nil
+}
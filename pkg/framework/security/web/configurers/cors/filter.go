@@ -0,0 +1,161 @@
+package cors
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/web/filter"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/constants"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+)
+
+// 跨域相关请求头
+const (
+	HeaderOrigin                        = "Origin"
+	HeaderAccessControlRequestMethod    = "Access-Control-Request-Method"
+	HeaderAccessControlRequestHeaders   = "Access-Control-Request-Headers"
+	HeaderAccessControlAllowOrigin      = "Access-Control-Allow-Origin"
+	HeaderAccessControlAllowMethods     = "Access-Control-Allow-Methods"
+	HeaderAccessControlAllowHeaders     = "Access-Control-Allow-Headers"
+	HeaderAccessControlExposeHeaders    = "Access-Control-Expose-Headers"
+	HeaderAccessControlAllowCredentials = "Access-Control-Allow-Credentials"
+	HeaderAccessControlMaxAge           = "Access-Control-Max-Age"
+	HeaderVary                          = "Vary"
+)
+
+// Filter 跨域过滤器，预检请求在认证之前直接响应
+type Filter struct {
+	ConfigurationSource ConfigurationSource
+}
+
+// NewFilter 实例化
+func NewFilter(source ConfigurationSource) *Filter {
+	return &Filter{
+		ConfigurationSource: source,
+	}
+}
+
+// Name 名字
+func (f *Filter) Name() string {
+	return "CorsFilter"
+}
+
+// Order 过滤器排序
+func (f *Filter) Order() int {
+	return constants.OrderFilterCors
+}
+
+// DoFilter 执行过滤器
+func (f *Filter) DoFilter(context *ingot.Context, chain filter.Chain) error {
+	if !isCorsRequest(context) {
+		return chain.DoFilter(context)
+	}
+
+	config := f.ConfigurationSource.GetCorsConfiguration(context)
+	if config == nil {
+		return chain.DoFilter(context)
+	}
+
+	preFlight := isPreFlightRequest(context)
+	if !f.processRequest(context, config, preFlight) {
+		log.Debugf("Reject cors request, origin=%s, url=%s", context.GetHeader(HeaderOrigin), context.Request.URL.Path)
+		return errors.Forbidden("Invalid CORS request")
+	}
+
+	if preFlight {
+		context.AbortWithStatus(http.StatusOK)
+		return nil
+	}
+
+	return chain.DoFilter(context)
+}
+
+// 处理跨域请求，写入响应头，返回是否允许该请求
+func (f *Filter) processRequest(context *ingot.Context, config *Configuration, preFlight bool) bool {
+	header := context.Writer.Header()
+	header.Add(HeaderVary, HeaderOrigin)
+	header.Add(HeaderVary, HeaderAccessControlRequestMethod)
+	header.Add(HeaderVary, HeaderAccessControlRequestHeaders)
+
+	// 已经处理过跨域
+	if header.Get(HeaderAccessControlAllowOrigin) != "" {
+		return true
+	}
+
+	allowOrigin := config.CheckOrigin(context.GetHeader(HeaderOrigin))
+	if allowOrigin == "" {
+		return false
+	}
+
+	method := context.Request.Method
+	if preFlight {
+		method = context.GetHeader(HeaderAccessControlRequestMethod)
+	}
+	allowMethods := config.CheckMethod(method)
+	if allowMethods == nil {
+		return false
+	}
+
+	var allowHeaders []string
+	if preFlight {
+		var ok bool
+		allowHeaders, ok = config.CheckHeaders(splitHeaderValues(context.GetHeader(HeaderAccessControlRequestHeaders)))
+		if !ok {
+			return false
+		}
+	}
+
+	header.Set(HeaderAccessControlAllowOrigin, allowOrigin)
+	if preFlight {
+		header.Set(HeaderAccessControlAllowMethods, strings.Join(allowMethods, ","))
+		if len(allowHeaders) != 0 {
+			header.Set(HeaderAccessControlAllowHeaders, strings.Join(allowHeaders, ","))
+		}
+		if config.MaxAge > 0 {
+			header.Set(HeaderAccessControlMaxAge, strconv.FormatInt(config.MaxAge, 10))
+		}
+	}
+	if len(config.ExposedHeaders) != 0 {
+		header.Set(HeaderAccessControlExposeHeaders, strings.Join(config.ExposedHeaders, ","))
+	}
+	if config.AllowCredentials {
+		header.Set(HeaderAccessControlAllowCredentials, "true")
+	}
+	return true
+}
+
+// 存在 Origin 且不是同源请求
+func isCorsRequest(context *ingot.Context) bool {
+	origin := context.GetHeader(HeaderOrigin)
+	if origin == "" {
+		return false
+	}
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return true
+	}
+	scheme := "http"
+	if context.Request.TLS != nil {
+		scheme = "https"
+	}
+	return !(strings.EqualFold(originURL.Scheme, scheme) && strings.EqualFold(originURL.Host, context.Request.Host))
+}
+
+func isPreFlightRequest(context *ingot.Context) bool {
+	return context.Request.Method == http.MethodOptions &&
+		context.GetHeader(HeaderAccessControlRequestMethod) != ""
+}
+
+func splitHeaderValues(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if trim := strings.TrimSpace(item); trim != "" {
+			result = append(result, trim)
+		}
+	}
+	return result
+}
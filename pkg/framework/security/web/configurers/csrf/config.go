@@ -0,0 +1,48 @@
+package csrf
+
+// CSRF token 存储方式
+const (
+	RepositorySession = "session"
+	RepositoryCookie  = "cookie"
+)
+
+// 默认名称
+const (
+	DefaultHeaderName    = "X-CSRF-TOKEN"
+	DefaultParameterName = "_csrf"
+	DefaultCookieName    = "XSRF-TOKEN"
+)
+
+// Config CSRF 配置
+type Config struct {
+	Enable bool `yaml:"enable"`
+	// token 存储方式 session, cookie(double-submit)
+	Repository    string `yaml:"repository"`
+	HeaderName    string `yaml:"headerName"`
+	ParameterName string `yaml:"parameterName"`
+	// cookie 存储时的 cookie 名称
+	CookieName string `yaml:"cookieName"`
+	// 忽略校验的路径，Ant 风格
+	IgnoreURLs []string `yaml:"ignoreUrls"`
+}
+
+func (c Config) headerName() string {
+	if c.HeaderName == "" {
+		return DefaultHeaderName
+	}
+	return c.HeaderName
+}
+
+func (c Config) parameterName() string {
+	if c.ParameterName == "" {
+		return DefaultParameterName
+	}
+	return c.ParameterName
+}
+
+func (c Config) cookieName() string {
+	if c.CookieName == "" {
+		return DefaultCookieName
+	}
+	return c.CookieName
+}
@@ -0,0 +1,44 @@
+package csrf
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/utils"
+)
+
+// SecurityConfigurer CSRF 配置
+type SecurityConfigurer struct {
+	Config          Config
+	TokenRepository TokenRepository
+	ignoreMatchers  []utils.RequestMatcher
+}
+
+// NewSecurityConfigurer 配置
+func NewSecurityConfigurer(config Config, repository TokenRepository) *SecurityConfigurer {
+	instance := &SecurityConfigurer{
+		Config:          config,
+		TokenRepository: repository,
+	}
+	if len(config.IgnoreURLs) != 0 {
+		instance.IgnoringRequestMatchers(utils.NewAntPathRequestMatchers(config.IgnoreURLs...))
+	}
+	return instance
+}
+
+// IgnoringRequestMatchers 追加忽略 CSRF 校验的请求
+func (c *SecurityConfigurer) IgnoringRequestMatchers(matchers ...utils.RequestMatcher) *SecurityConfigurer {
+	c.ignoreMatchers = append(c.ignoreMatchers, matchers...)
+	return c
+}
+
+// HTTPConfigure 配置
+func (c *SecurityConfigurer) HTTPConfigure(http security.HTTPSecurityBuilder) error {
+	if !c.Config.Enable {
+		return nil
+	}
+	f := NewFilter(c.TokenRepository)
+	if len(c.ignoreMatchers) != 0 {
+		f.IgnoreMatcher = utils.Or(c.ignoreMatchers...)
+	}
+	http.AddFilter(f)
+	return nil
+}
@@ -0,0 +1,93 @@
+package csrf
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/web/filter"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/constants"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/utils"
+)
+
+// Filter CSRF 过滤器，非安全方法需要提交与存储一致的 token
+type Filter struct {
+	TokenRepository TokenRepository
+	// 忽略校验的请求
+	IgnoreMatcher utils.RequestMatcher
+}
+
+// NewFilter 实例化
+func NewFilter(repository TokenRepository) *Filter {
+	return &Filter{
+		TokenRepository: repository,
+	}
+}
+
+// Name 名字
+func (f *Filter) Name() string {
+	return "CsrfFilter"
+}
+
+// Order 过滤器排序
+func (f *Filter) Order() int {
+	return constants.OrderFilterCsrf
+}
+
+// DoFilter 执行过滤器，没有 token 时只在需要签发时保存新的 token，
+// 安全请求携带 fetch 请求头或调用 GetToken 时签发
+func (f *Filter) DoFilter(context *ingot.Context, chain filter.Chain) error {
+	token, err := f.TokenRepository.LoadToken(context)
+	if err != nil {
+		return err
+	}
+	missingToken := token == nil
+	deferred := &deferredToken{
+		token:      token,
+		repository: f.TokenRepository,
+		ctx:        context,
+		saved:      !missingToken,
+	}
+	if missingToken {
+		deferred.token = f.TokenRepository.GenerateToken(context)
+	} else {
+		// 通过响应头返回 token，前端无法读取会话时使用
+		context.Header(token.HeaderName, token.Token)
+	}
+	setToken(context, deferred)
+
+	if !f.requiresProtection(context) {
+		if missingToken && strings.EqualFold(context.GetHeader(deferred.token.HeaderName), FetchTokenValue) {
+			if _, err := deferred.get(); err != nil {
+				return err
+			}
+		}
+		return chain.DoFilter(context)
+	}
+	token = deferred.token
+
+	actual := context.GetHeader(token.HeaderName)
+	if actual == "" {
+		actual = context.PostForm(token.ParameterName)
+	}
+	if missingToken || subtle.ConstantTimeCompare([]byte(token.Token), []byte(actual)) != 1 {
+		log.Debugf("Invalid CSRF token found for %s", context.Request.URL.Path)
+		return errors.Forbidden("Invalid CSRF token")
+	}
+
+	return chain.DoFilter(context)
+}
+
+func (f *Filter) requiresProtection(context *ingot.Context) bool {
+	switch context.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodTrace, http.MethodOptions:
+		return false
+	}
+	if f.IgnoreMatcher != nil && f.IgnoreMatcher.Matches(context) {
+		return false
+	}
+	return true
+}
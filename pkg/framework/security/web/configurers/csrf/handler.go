@@ -0,0 +1,20 @@
+package csrf
+
+import "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+
+// LogoutHandler 注销时清除 token
+type LogoutHandler struct {
+	TokenRepository TokenRepository
+}
+
+// NewLogoutHandler 实例化
+func NewLogoutHandler(repository TokenRepository) *LogoutHandler {
+	return &LogoutHandler{
+		TokenRepository: repository,
+	}
+}
+
+// Logout 注销
+func (h *LogoutHandler) Logout(ctx *ingot.Context) error {
+	return h.TokenRepository.SaveToken(ctx, nil)
+}
@@ -0,0 +1,133 @@
+package csrf
+
+import (
+	"net/http"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/utils/uuid"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/session"
+)
+
+// SessionAttributeKey 会话中保存 token 的属性名
+const SessionAttributeKey = "INGOT_CSRF_TOKEN"
+
+// NewTokenRepository 根据配置创建 token 存储
+func NewTokenRepository(config Config, store session.Store) TokenRepository {
+	if config.Repository == RepositoryCookie {
+		return NewCookieTokenRepository(config)
+	}
+	return NewSessionTokenRepository(config, store)
+}
+
+// SessionTokenRepository 同步器 token 模式，token 保存在服务端会话中
+type SessionTokenRepository struct {
+	Config       Config
+	SessionStore session.Store
+}
+
+// NewSessionTokenRepository 实例化
+func NewSessionTokenRepository(config Config, store session.Store) *SessionTokenRepository {
+	return &SessionTokenRepository{
+		Config:       config,
+		SessionStore: store,
+	}
+}
+
+// GenerateToken 生成 token
+func (r *SessionTokenRepository) GenerateToken(ctx *ingot.Context) *Token {
+	return newToken(r.Config)
+}
+
+// SaveToken 保存 token
+func (r *SessionTokenRepository) SaveToken(ctx *ingot.Context, token *Token) error {
+	if token == nil {
+		current, err := r.SessionStore.GetSession(ctx, false)
+		if err != nil || current == nil {
+			return err
+		}
+		current.RemoveAttribute(SessionAttributeKey)
+		return r.SessionStore.Save(ctx, current)
+	}
+	current, err := r.SessionStore.GetSession(ctx, true)
+	if err != nil {
+		return err
+	}
+	current.SetAttribute(SessionAttributeKey, token.Token)
+	return r.SessionStore.Save(ctx, current)
+}
+
+// LoadToken 加载 token
+func (r *SessionTokenRepository) LoadToken(ctx *ingot.Context) (*Token, error) {
+	current, err := r.SessionStore.GetSession(ctx, false)
+	if err != nil || current == nil {
+		return nil, err
+	}
+	value, ok := current.GetAttribute(SessionAttributeKey).(string)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	return &Token{
+		HeaderName:    r.Config.headerName(),
+		ParameterName: r.Config.parameterName(),
+		Token:         value,
+	}, nil
+}
+
+// CookieTokenRepository double-submit cookie 模式，token 保存在前端可读取的 cookie 中
+type CookieTokenRepository struct {
+	Config Config
+}
+
+// NewCookieTokenRepository 实例化
+func NewCookieTokenRepository(config Config) *CookieTokenRepository {
+	return &CookieTokenRepository{
+		Config: config,
+	}
+}
+
+// GenerateToken 生成 token
+func (r *CookieTokenRepository) GenerateToken(ctx *ingot.Context) *Token {
+	return newToken(r.Config)
+}
+
+// SaveToken 保存 token
+func (r *CookieTokenRepository) SaveToken(ctx *ingot.Context, token *Token) error {
+	value := ""
+	maxAge := 0
+	if token == nil {
+		maxAge = -1
+	} else {
+		value = token.Token
+	}
+	// 前端需要读取 cookie 并通过请求头提交，不能设置 HttpOnly
+	http.SetCookie(ctx.Writer, &http.Cookie{
+		Name:     r.Config.cookieName(),
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		Secure:   ctx.Request.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return nil
+}
+
+// LoadToken 加载 token
+func (r *CookieTokenRepository) LoadToken(ctx *ingot.Context) (*Token, error) {
+	value, err := ctx.Cookie(r.Config.cookieName())
+	if err != nil || value == "" {
+		return nil, nil
+	}
+	return &Token{
+		HeaderName:    r.Config.headerName(),
+		ParameterName: r.Config.parameterName(),
+		Token:         value,
+	}, nil
+}
+
+func newToken(config Config) *Token {
+	return &Token{
+		HeaderName:    config.headerName(),
+		ParameterName: config.parameterName(),
+		Token:         uuid.MustString(),
+	}
+}
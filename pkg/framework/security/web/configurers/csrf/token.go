@@ -0,0 +1,71 @@
+package csrf
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+)
+
+// 当前请求中缓存 token 的 key
+const contextTokenKey = "ingot.csrf"
+
+// FetchTokenValue 安全请求的 token 请求头为该值时签发 token，例如 X-CSRF-TOKEN: fetch
+const FetchTokenValue = "fetch"
+
+// Token CSRF token
+type Token struct {
+	// 提交 token 的请求头名称
+	HeaderName string
+	// 提交 token 的表单参数名称
+	ParameterName string
+	Token         string
+}
+
+// TokenRepository token 存储
+type TokenRepository interface {
+	// 生成新的 token，不会保存
+	GenerateToken(ctx *ingot.Context) *Token
+	// 保存 token，token 为 nil 时删除
+	SaveToken(ctx *ingot.Context, token *Token) error
+	// 加载当前请求关联的 token，不存在时返回 nil
+	LoadToken(ctx *ingot.Context) (*Token, error)
+}
+
+// GetToken 获取当前请求的 CSRF token，用于渲染页面或返回给前端，
+// 当前请求没有 token 时签发新的 token
+func GetToken(ctx *gin.Context) *Token {
+	value, ok := ctx.Get(contextTokenKey)
+	if !ok {
+		return nil
+	}
+	token, err := value.(*deferredToken).get()
+	if err != nil {
+		log.Errorf("Save CSRF token error: %s", err.Error())
+		return nil
+	}
+	return token
+}
+
+// deferredToken 延迟保存的 token，只有在签发时才保存，避免匿名请求都创建会话
+type deferredToken struct {
+	token      *Token
+	repository TokenRepository
+	ctx        *ingot.Context
+	saved      bool
+	err        error
+}
+
+func (d *deferredToken) get() (*Token, error) {
+	if !d.saved {
+		d.saved = true
+		d.err = d.repository.SaveToken(d.ctx, d.token)
+		if d.err == nil {
+			d.ctx.Header(d.token.HeaderName, d.token.Token)
+		}
+	}
+	return d.token, d.err
+}
+
+func setToken(ctx *ingot.Context, token *deferredToken) {
+	ctx.Set(contextTokenKey, token)
+}
@@ -0,0 +1,44 @@
+package formlogin
+
+import "github.com/ingot-cloud/ingot-go/pkg/framework/security/web/session"
+
+// Config 表单登录配置
+type Config struct {
+	Enable bool `yaml:"enable"`
+	// 表单登录过滤器链匹配的路径，Ant 风格
+	Pattern string `yaml:"pattern"`
+	// 处理登录请求的路径
+	LoginProcessingURL string `yaml:"loginProcessingUrl"`
+	// 开启多因素认证时，使用 mfa_token 和动态码完成登录的路径
+	MfaProcessingURL string `yaml:"mfaProcessingUrl"`
+	// 注销路径
+	LogoutURL         string `yaml:"logoutUrl"`
+	UsernameParameter string `yaml:"usernameParameter"`
+	PasswordParameter string `yaml:"passwordParameter"`
+	// 会话配置
+	Session session.Config `yaml:"session"`
+}
+
+// GetUsernameParameter 用户名参数
+func (c Config) GetUsernameParameter() string {
+	if c.UsernameParameter == "" {
+		return "username"
+	}
+	return c.UsernameParameter
+}
+
+// GetMfaProcessingURL 多因素认证路径
+func (c Config) GetMfaProcessingURL() string {
+	if c.MfaProcessingURL == "" {
+		return "/login/mfa"
+	}
+	return c.MfaProcessingURL
+}
+
+// GetPasswordParameter 密码参数
+func (c Config) GetPasswordParameter() string {
+	if c.PasswordParameter == "" {
+		return "password"
+	}
+	return c.PasswordParameter
+}
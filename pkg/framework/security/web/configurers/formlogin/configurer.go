@@ -0,0 +1,46 @@
+package formlogin
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/mfa"
+	coreSession "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/session"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/rememberme"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/session"
+)
+
+// SecurityConfigurer 表单登录配置
+type SecurityConfigurer struct {
+	Config                Config
+	AuthenticationManager authentication.Manager
+	SessionStore          session.Store
+	SuccessHandler        SuccessHandler
+	FailureHandler        FailureHandler
+	RememberMeServices    rememberme.Services
+	ConcurrentControl     *coreSession.ConcurrentControl
+	MfaManager            *mfa.Manager
+}
+
+// NewSecurityConfigurer 配置
+func NewSecurityConfigurer(config Config, manager authentication.Manager, store session.Store) *SecurityConfigurer {
+	return &SecurityConfigurer{
+		Config:                config,
+		AuthenticationManager: manager,
+		SessionStore:          store,
+		SuccessHandler:        &DefaultSuccessHandler{},
+		FailureHandler:        &DefaultFailureHandler{},
+		RememberMeServices:    &rememberme.NullServices{},
+	}
+}
+
+// HTTPConfigure 配置
+func (c *SecurityConfigurer) HTTPConfigure(http security.HTTPSecurityBuilder) error {
+	f := NewFilter(c.Config, c.AuthenticationManager, c.SessionStore)
+	f.SuccessHandler = c.SuccessHandler
+	f.FailureHandler = c.FailureHandler
+	f.RememberMeServices = c.RememberMeServices
+	f.ConcurrentControl = c.ConcurrentControl
+	f.MfaManager = c.MfaManager
+	http.AddFilter(f)
+	return nil
+}
@@ -0,0 +1,155 @@
+package formlogin
+
+import (
+	"net/http"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/web/filter"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/preauth"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/constants"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/mfa"
+	coreSession "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/session"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/rememberme"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/session"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/utils"
+)
+
+// Filter 表单登录过滤器
+type Filter struct {
+	Config                Config
+	AuthenticationManager authentication.Manager
+	SessionStore          session.Store
+	SuccessHandler        SuccessHandler
+	FailureHandler        FailureHandler
+	RememberMeServices    rememberme.Services
+	ConcurrentControl     *coreSession.ConcurrentControl
+	MfaManager            *mfa.Manager
+	requestMatcher        utils.RequestMatcher
+	mfaRequestMatcher     utils.RequestMatcher
+}
+
+// NewFilter 实例化
+func NewFilter(config Config, manager authentication.Manager, store session.Store) *Filter {
+	return &Filter{
+		Config:                config,
+		AuthenticationManager: manager,
+		SessionStore:          store,
+		SuccessHandler:        &DefaultSuccessHandler{},
+		FailureHandler:        &DefaultFailureHandler{},
+		RememberMeServices:    &rememberme.NullServices{},
+		requestMatcher:        utils.NewAntPathRequestMatcher(config.LoginProcessingURL, http.MethodPost),
+		mfaRequestMatcher:     utils.NewAntPathRequestMatcher(config.GetMfaProcessingURL(), http.MethodPost),
+	}
+}
+
+// Name 名字
+func (f *Filter) Name() string {
+	return "UsernamePasswordAuthenticationFilter"
+}
+
+// Order 过滤器排序
+func (f *Filter) Order() int {
+	return constants.OrderFilterFormLogin
+}
+
+// DoFilter 执行过滤器
+func (f *Filter) DoFilter(context *ingot.Context, chain filter.Chain) error {
+	var authResult core.Authentication
+	var err error
+	switch {
+	case f.requestMatcher.Matches(context):
+		authResult, err = f.attemptAuthentication(context)
+	case f.MfaManager.Enabled() && f.mfaRequestMatcher.Matches(context):
+		authResult, err = f.attemptMfaAuthentication(context)
+	default:
+		return chain.DoFilter(context)
+	}
+	if err != nil {
+		f.RememberMeServices.LoginFail(context)
+		return f.FailureHandler.OnAuthenticationFailure(context, err)
+	}
+
+	// 会话中不保存凭证信息
+	if container, ok := authResult.(core.CredentialsContainer); ok {
+		container.EraseCredentials()
+	}
+	if err := f.saveAuthentication(context, authResult); err != nil {
+		return err
+	}
+	context.SetAuthentication(authResult)
+	if err := f.RememberMeServices.LoginSuccess(context, authResult); err != nil {
+		return err
+	}
+	return f.SuccessHandler.OnAuthenticationSuccess(context, authResult)
+}
+
+func (f *Filter) attemptAuthentication(context *ingot.Context) (core.Authentication, error) {
+	username := context.PostForm(f.Config.GetUsernameParameter())
+	password := context.PostForm(f.Config.GetPasswordParameter())
+	token := authentication.NewUnauthenticatedUsernamePasswordAuthToken(username, password)
+	token.SetDetails(authentication.NewWebAuthenticationDetails(context))
+
+	authResult, err := f.AuthenticationManager.Authenticate(token)
+	if err != nil {
+		log.Debugf("Form login failed, username=%s, err=%s", username, err.Error())
+		return nil, err
+	}
+
+	// 需要多因素认证时不创建会话，返回 mfa_token
+	if err := f.MfaManager.Challenge(authResult, ""); err != nil {
+		return nil, err
+	}
+	return authResult, nil
+}
+
+// 校验 mfa_token 和动态码，通过后重新加载用户
+func (f *Filter) attemptMfaAuthentication(context *ingot.Context) (core.Authentication, error) {
+	details := authentication.NewWebAuthenticationDetails(context)
+	challenge, err := f.MfaManager.Verify(context.PostForm(mfa.ParameterToken), "", context.PostForm(mfa.ParameterCode), details.RemoteAddress)
+	if err != nil {
+		log.Debugf("Form login multi-factor authentication failed, err=%s", err.Error())
+		return nil, err
+	}
+	// 在第一步的租户中重新加载用户
+	tenantDetails, err := authentication.WithTenantID(details, challenge.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	token := preauth.NewAuthenticationToken(challenge.Username, "", nil)
+	token.SetDetails(tenantDetails)
+	return f.AuthenticationManager.Authenticate(token)
+}
+
+// 登录成功后销毁旧会话并创建新会话，防止会话固定攻击
+func (f *Filter) saveAuthentication(context *ingot.Context, auth core.Authentication) error {
+	old, err := f.SessionStore.GetSession(context, false)
+	if err != nil {
+		return err
+	}
+	if old != nil {
+		if err := f.ConcurrentControl.Remove(old.ID); err != nil {
+			return err
+		}
+		if err := f.SessionStore.Invalidate(context, old); err != nil {
+			return err
+		}
+	}
+	current, err := f.SessionStore.GetSession(context, true)
+	if err != nil {
+		return err
+	}
+	err = f.ConcurrentControl.OnAuthentication(&coreSession.Information{
+		SessionID: current.ID,
+		Principal: coreSession.PrincipalName(auth),
+		ExpiresAt: current.ExpireAt(),
+	})
+	if err != nil {
+		f.SessionStore.Invalidate(context, current)
+		return err
+	}
+	session.SetAuthentication(current, auth)
+	return f.SessionStore.Save(context, current)
+}
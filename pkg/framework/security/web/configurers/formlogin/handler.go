@@ -0,0 +1,41 @@
+package formlogin
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/wrapper/response"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/authority"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+)
+
+// SuccessHandler 登录成功处理
+type SuccessHandler interface {
+	OnAuthenticationSuccess(ctx *ingot.Context, auth core.Authentication) error
+}
+
+// FailureHandler 登录失败处理
+type FailureHandler interface {
+	OnAuthenticationFailure(ctx *ingot.Context, err error) error
+}
+
+// DefaultSuccessHandler 响应当前登录用户信息
+type DefaultSuccessHandler struct {
+}
+
+// OnAuthenticationSuccess 登录成功
+func (*DefaultSuccessHandler) OnAuthenticationSuccess(ctx *ingot.Context, auth core.Authentication) error {
+	response.OK(ctx.Context, response.D{
+		"username":    auth.GetName(auth),
+		"authorities": authority.ToStringArray(auth.GetAuthorities()),
+	})
+	ctx.Abort()
+	return nil
+}
+
+// DefaultFailureHandler 直接返回认证异常
+type DefaultFailureHandler struct {
+}
+
+// OnAuthenticationFailure 登录失败
+func (*DefaultFailureHandler) OnAuthenticationFailure(ctx *ingot.Context, err error) error {
+	return err
+}
@@ -0,0 +1,56 @@
+package headers
+
+// Config 安全响应头配置，字段为空时不写入对应响应头
+type Config struct {
+	Enable bool `yaml:"enable"`
+	// X-Content-Type-Options: nosniff
+	ContentTypeOptions bool `yaml:"contentTypeOptions"`
+	// X-Frame-Options，例如 DENY, SAMEORIGIN
+	FrameOptions string `yaml:"frameOptions"`
+	// Strict-Transport-Security，仅在 https 请求中写入
+	HSTS HSTS `yaml:"hsts"`
+	// Content-Security-Policy
+	ContentSecurityPolicy string `yaml:"contentSecurityPolicy"`
+	// Referrer-Policy
+	ReferrerPolicy string `yaml:"referrerPolicy"`
+	// Permissions-Policy
+	PermissionsPolicy string `yaml:"permissionsPolicy"`
+	// 是否写入 Cache-Control: no-store 禁止缓存响应
+	CacheControl bool `yaml:"cacheControl"`
+}
+
+// HSTS 配置
+type HSTS struct {
+	Enable            bool  `yaml:"enable"`
+	MaxAge            int64 `yaml:"maxAge"`
+	IncludeSubDomains bool  `yaml:"includeSubDomains"`
+	Preload           bool  `yaml:"preload"`
+}
+
+// DefaultConfig 默认配置
+func DefaultConfig() Config {
+	return Config{
+		Enable:             true,
+		ContentTypeOptions: true,
+		FrameOptions:       "DENY",
+		HSTS: HSTS{
+			Enable:            true,
+			MaxAge:            31536000,
+			IncludeSubDomains: true,
+		},
+		ReferrerPolicy: "no-referrer",
+	}
+}
+
+// WithCacheControl 返回开启禁止缓存的配置副本，用于单独覆盖某条过滤器链的配置，
+// 未开启安全响应头时只写入禁止缓存响应头，其他响应头保持关闭
+func (c Config) WithCacheControl() Config {
+	if !c.Enable {
+		return Config{
+			Enable:       true,
+			CacheControl: true,
+		}
+	}
+	c.CacheControl = true
+	return c
+}
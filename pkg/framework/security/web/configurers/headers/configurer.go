@@ -0,0 +1,24 @@
+package headers
+
+import "github.com/ingot-cloud/ingot-go/pkg/framework/security"
+
+// SecurityConfigurer 安全响应头配置
+type SecurityConfigurer struct {
+	Config Config
+}
+
+// NewSecurityConfigurer 配置
+func NewSecurityConfigurer(config Config) *SecurityConfigurer {
+	return &SecurityConfigurer{
+		Config: config,
+	}
+}
+
+// HTTPConfigure 配置
+func (c *SecurityConfigurer) HTTPConfigure(http security.HTTPSecurityBuilder) error {
+	if !c.Config.Enable {
+		return nil
+	}
+	http.AddFilter(NewFilter(c.Config))
+	return nil
+}
@@ -0,0 +1,103 @@
+package headers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/web/filter"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/constants"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+)
+
+// 响应头
+const (
+	HeaderContentTypeOptions      = "X-Content-Type-Options"
+	HeaderFrameOptions            = "X-Frame-Options"
+	HeaderStrictTransportSecurity = "Strict-Transport-Security"
+	HeaderContentSecurityPolicy   = "Content-Security-Policy"
+	HeaderReferrerPolicy          = "Referrer-Policy"
+	HeaderPermissionsPolicy       = "Permissions-Policy"
+	HeaderCacheControl            = "Cache-Control"
+	HeaderPragma                  = "Pragma"
+	HeaderExpires                 = "Expires"
+)
+
+// Filter 写入安全响应头，在执行后续过滤器之前写入，保证异常响应同样包含安全响应头
+type Filter struct {
+	Config Config
+	hsts   string
+}
+
+// NewFilter 实例化
+func NewFilter(config Config) *Filter {
+	return &Filter{
+		Config: config,
+		hsts:   buildHSTS(config.HSTS),
+	}
+}
+
+// Name 名字
+func (f *Filter) Name() string {
+	return "HeaderWriterFilter"
+}
+
+// Order 过滤器排序
+func (f *Filter) Order() int {
+	return constants.OrderFilterHeaders
+}
+
+// DoFilter 执行过滤器
+func (f *Filter) DoFilter(context *ingot.Context, chain filter.Chain) error {
+	header := context.Writer.Header()
+	config := f.Config
+
+	if config.ContentTypeOptions {
+		header.Set(HeaderContentTypeOptions, "nosniff")
+	}
+	if config.FrameOptions != "" {
+		header.Set(HeaderFrameOptions, config.FrameOptions)
+	}
+	if f.hsts != "" && isSecure(context) {
+		header.Set(HeaderStrictTransportSecurity, f.hsts)
+	}
+	if config.ContentSecurityPolicy != "" {
+		header.Set(HeaderContentSecurityPolicy, config.ContentSecurityPolicy)
+	}
+	if config.ReferrerPolicy != "" {
+		header.Set(HeaderReferrerPolicy, config.ReferrerPolicy)
+	}
+	if config.PermissionsPolicy != "" {
+		header.Set(HeaderPermissionsPolicy, config.PermissionsPolicy)
+	}
+	if config.CacheControl {
+		header.Set(HeaderCacheControl, "no-store")
+		header.Set(HeaderPragma, "no-cache")
+		header.Set(HeaderExpires, "0")
+	}
+
+	return chain.DoFilter(context)
+}
+
+func buildHSTS(hsts HSTS) string {
+	if !hsts.Enable {
+		return ""
+	}
+	var builder strings.Builder
+	builder.WriteString("max-age=")
+	builder.WriteString(strconv.FormatInt(hsts.MaxAge, 10))
+	if hsts.IncludeSubDomains {
+		builder.WriteString(" ; includeSubDomains")
+	}
+	if hsts.Preload {
+		builder.WriteString(" ; preload")
+	}
+	return builder.String()
+}
+
+// 是否为 https 请求，兼容反向代理
+func isSecure(context *ingot.Context) bool {
+	if context.Request.TLS != nil {
+		return true
+	}
+	return strings.EqualFold(context.GetHeader("X-Forwarded-Proto"), "https")
+}
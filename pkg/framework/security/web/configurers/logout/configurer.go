@@ -0,0 +1,33 @@
+package logout
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
+)
+
+// SecurityConfigurer 注销配置
+type SecurityConfigurer struct {
+	LogoutURL string
+	Handlers  []Handler
+}
+
+// NewSecurityConfigurer 配置
+func NewSecurityConfigurer(logoutURL string, handlers ...Handler) *SecurityConfigurer {
+	return &SecurityConfigurer{
+		LogoutURL: logoutURL,
+		Handlers:  handlers,
+	}
+}
+
+// AddHandler 追加注销处理
+func (c *SecurityConfigurer) AddHandler(handler Handler) {
+	c.Handlers = append(c.Handlers, handler)
+}
+
+// HTTPConfigure 配置
+func (c *SecurityConfigurer) HTTPConfigure(http security.HTTPSecurityBuilder) error {
+	if c.LogoutURL == "" {
+		return nil
+	}
+	http.AddFilter(NewFilter(c.LogoutURL, c.Handlers...))
+	return nil
+}
@@ -0,0 +1,52 @@
+package logout
+
+import (
+	"net/http"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/web/filter"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/wrapper/response"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/constants"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/utils"
+)
+
+// Filter 注销过滤器
+type Filter struct {
+	Handlers       []Handler
+	requestMatcher utils.RequestMatcher
+}
+
+// NewFilter 实例化
+func NewFilter(logoutURL string, handlers ...Handler) *Filter {
+	return &Filter{
+		Handlers:       handlers,
+		requestMatcher: utils.NewAntPathRequestMatcher(logoutURL, http.MethodPost),
+	}
+}
+
+// Name 名字
+func (f *Filter) Name() string {
+	return "LogoutFilter"
+}
+
+// Order 过滤器排序
+func (f *Filter) Order() int {
+	return constants.OrderFilterLogout
+}
+
+// DoFilter 执行过滤器
+func (f *Filter) DoFilter(context *ingot.Context, chain filter.Chain) error {
+	if !f.requestMatcher.Matches(context) {
+		return chain.DoFilter(context)
+	}
+
+	for _, handler := range f.Handlers {
+		if err := handler.Logout(context); err != nil {
+			return err
+		}
+	}
+
+	response.OKWithEmpty(context.Context)
+	context.Abort()
+	return nil
+}
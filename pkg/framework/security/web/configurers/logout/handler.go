@@ -0,0 +1,43 @@
+package logout
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	coreSession "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/session"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/session"
+)
+
+// Handler 注销处理
+type Handler interface {
+	Logout(ctx *ingot.Context) error
+}
+
+// SessionHandler 销毁当前会话
+type SessionHandler struct {
+	SessionStore      session.Store
+	ConcurrentControl *coreSession.ConcurrentControl
+}
+
+// NewSessionHandler 实例化
+func NewSessionHandler(store session.Store) *SessionHandler {
+	return &SessionHandler{
+		SessionStore: store,
+	}
+}
+
+// Logout 注销
+func (h *SessionHandler) Logout(ctx *ingot.Context) error {
+	current, err := h.SessionStore.GetSession(ctx, false)
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		if err := h.ConcurrentControl.Remove(current.ID); err != nil {
+			return err
+		}
+		if err := h.SessionStore.Invalidate(ctx, current); err != nil {
+			return err
+		}
+	}
+	ctx.SetAuthentication(nil)
+	return nil
+}
@@ -0,0 +1,43 @@
+package preauth
+
+// 默认配置
+const (
+	DefaultPrincipalHeader       = "X-Remote-User"
+	DefaultSubjectPrincipalRegex = "CN=(.*?)(?:,|$)"
+)
+
+// Config 预身份验证配置
+type Config struct {
+	Header HeaderConfig `yaml:"header"`
+	X509   X509Config   `yaml:"x509"`
+}
+
+// HeaderConfig 请求头预身份验证配置，主体由入口网关验证后写入请求头
+type HeaderConfig struct {
+	Enable bool `yaml:"enable"`
+	// 读取主体的请求头
+	PrincipalHeader string `yaml:"principalHeader"`
+	// 可信代理地址，CIDR 格式，只有来自可信代理的请求才读取请求头，为空时不信任任何请求
+	TrustedProxies []string `yaml:"trustedProxies"`
+}
+
+// X509Config 客户端证书预身份验证配置
+type X509Config struct {
+	Enable bool `yaml:"enable"`
+	// 从证书主体 DN 中提取用户名的正则，使用第一个分组
+	SubjectPrincipalRegex string `yaml:"subjectPrincipalRegex"`
+}
+
+func (c HeaderConfig) principalHeader() string {
+	if c.PrincipalHeader == "" {
+		return DefaultPrincipalHeader
+	}
+	return c.PrincipalHeader
+}
+
+func (c X509Config) subjectPrincipalRegex() string {
+	if c.SubjectPrincipalRegex == "" {
+		return DefaultSubjectPrincipalRegex
+	}
+	return c.SubjectPrincipalRegex
+}
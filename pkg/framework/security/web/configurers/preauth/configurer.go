@@ -0,0 +1,39 @@
+package preauth
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+)
+
+// SecurityConfigurer 预身份验证配置
+type SecurityConfigurer struct {
+	Config                Config
+	AuthenticationManager authentication.Manager
+}
+
+// NewSecurityConfigurer 配置
+func NewSecurityConfigurer(config Config, manager authentication.Manager) *SecurityConfigurer {
+	return &SecurityConfigurer{
+		Config:                config,
+		AuthenticationManager: manager,
+	}
+}
+
+// HTTPConfigure 配置
+func (c *SecurityConfigurer) HTTPConfigure(http security.HTTPSecurityBuilder) error {
+	if c.Config.X509.Enable {
+		extractor, err := NewX509PrincipalExtractor(c.Config.X509)
+		if err != nil {
+			return err
+		}
+		http.AddFilter(NewX509Filter(extractor, c.AuthenticationManager))
+	}
+	if c.Config.Header.Enable {
+		extractor, err := NewHeaderPrincipalExtractor(c.Config.Header)
+		if err != nil {
+			return err
+		}
+		http.AddFilter(NewRequestHeaderFilter(extractor, c.AuthenticationManager))
+	}
+	return nil
+}
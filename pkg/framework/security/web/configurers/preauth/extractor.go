@@ -0,0 +1,101 @@
+package preauth
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
+)
+
+// PrincipalExtractor 从请求中提取预身份验证主体，不存在时返回空字符串
+type PrincipalExtractor interface {
+	Extract(*ingot.Context) (principal string, credentials string, err error)
+}
+
+// HeaderPrincipalExtractor 从可信代理设置的请求头中提取主体
+type HeaderPrincipalExtractor struct {
+	header         string
+	trustedProxies []*net.IPNet
+}
+
+// NewHeaderPrincipalExtractor 实例化
+func NewHeaderPrincipalExtractor(config HeaderConfig) (*HeaderPrincipalExtractor, error) {
+	extractor := &HeaderPrincipalExtractor{
+		header: config.principalHeader(),
+	}
+	for _, item := range config.TrustedProxies {
+		_, network, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %s: %w", item, err)
+		}
+		extractor.trustedProxies = append(extractor.trustedProxies, network)
+	}
+	return extractor, nil
+}
+
+// Extract 提取主体
+func (e *HeaderPrincipalExtractor) Extract(ctx *ingot.Context) (string, string, error) {
+	principal := ctx.GetHeader(e.header)
+	if principal == "" {
+		return "", "", nil
+	}
+	// 使用连接的远端地址判断，不能使用可被伪造的 X-Forwarded-For
+	host, _, err := net.SplitHostPort(ctx.Request.RemoteAddr)
+	if err != nil {
+		host = ctx.Request.RemoteAddr
+	}
+	if !e.isTrusted(net.ParseIP(host)) {
+		log.Warnf("Ignore pre-authenticated header %s from untrusted address %s", e.header, host)
+		return "", "", nil
+	}
+	return principal, "N/A", nil
+}
+
+func (e *HeaderPrincipalExtractor) isTrusted(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, network := range e.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// X509PrincipalExtractor 从已验证的客户端证书主体 DN 中提取用户名
+type X509PrincipalExtractor struct {
+	subjectPrincipalRegex *regexp.Regexp
+}
+
+// NewX509PrincipalExtractor 实例化
+func NewX509PrincipalExtractor(config X509Config) (*X509PrincipalExtractor, error) {
+	pattern, err := regexp.Compile(config.subjectPrincipalRegex())
+	if err != nil {
+		return nil, err
+	}
+	if pattern.NumSubexp() < 1 {
+		return nil, fmt.Errorf("subject principal regex %s must contain a group", pattern.String())
+	}
+	return &X509PrincipalExtractor{
+		subjectPrincipalRegex: pattern,
+	}, nil
+}
+
+// Extract 提取主体，证书必须已经通过 TLS 握手验证
+func (e *X509PrincipalExtractor) Extract(ctx *ingot.Context) (string, string, error) {
+	state := ctx.Request.TLS
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return "", "", nil
+	}
+	cert := state.VerifiedChains[0][0]
+	subject := cert.Subject.String()
+	matches := e.subjectPrincipalRegex.FindStringSubmatch(subject)
+	if len(matches) < 2 || matches[1] == "" {
+		return "", "", errors.BadCredentials("No matching pattern was found in subject DN: ", subject)
+	}
+	return matches[1], cert.SerialNumber.String(), nil
+}
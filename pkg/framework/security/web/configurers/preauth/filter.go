@@ -0,0 +1,94 @@
+package preauth
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/web/filter"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/preauth"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/constants"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+)
+
+// AbstractFilter 预身份验证过滤器基本实现，主体由 PrincipalExtractor 从请求中提取
+type AbstractFilter struct {
+	PrincipalExtractor    PrincipalExtractor
+	AuthenticationManager authentication.Manager
+}
+
+// DoFilter 执行过滤器
+func (f *AbstractFilter) DoFilter(context *ingot.Context, chain filter.Chain) error {
+	principal, credentials, err := f.PrincipalExtractor.Extract(context)
+	if err != nil {
+		return err
+	}
+	if principal == "" || !f.requiresAuthentication(context, principal) {
+		return chain.DoFilter(context)
+	}
+
+	// 认证详情中包含请求头指定的租户，多租户时在该租户中加载用户
+	token := preauth.NewAuthenticationToken(principal, credentials, nil)
+	token.SetDetails(authentication.NewWebAuthenticationDetails(context))
+	authResult, err := f.AuthenticationManager.Authenticate(token)
+	if err != nil {
+		return err
+	}
+	context.SetAuthentication(authResult)
+	return chain.DoFilter(context)
+}
+
+func (f *AbstractFilter) requiresAuthentication(context *ingot.Context, principal string) bool {
+	current := context.GetAuthentication()
+	if current == nil || !current.IsAuthenticated() {
+		return true
+	}
+	return current.GetName(current) != principal
+}
+
+// RequestHeaderFilter 请求头预身份验证过滤器
+type RequestHeaderFilter struct {
+	*AbstractFilter
+}
+
+// NewRequestHeaderFilter 实例化
+func NewRequestHeaderFilter(extractor *HeaderPrincipalExtractor, manager authentication.Manager) *RequestHeaderFilter {
+	return &RequestHeaderFilter{
+		AbstractFilter: &AbstractFilter{
+			PrincipalExtractor:    extractor,
+			AuthenticationManager: manager,
+		},
+	}
+}
+
+// Name 名字
+func (f *RequestHeaderFilter) Name() string {
+	return "RequestHeaderAuthenticationFilter"
+}
+
+// Order 过滤器排序
+func (f *RequestHeaderFilter) Order() int {
+	return constants.OrderFilterPreAuthenticated
+}
+
+// X509Filter 客户端证书预身份验证过滤器
+type X509Filter struct {
+	*AbstractFilter
+}
+
+// NewX509Filter 实例化
+func NewX509Filter(extractor *X509PrincipalExtractor, manager authentication.Manager) *X509Filter {
+	return &X509Filter{
+		AbstractFilter: &AbstractFilter{
+			PrincipalExtractor:    extractor,
+			AuthenticationManager: manager,
+		},
+	}
+}
+
+// Name 名字
+func (f *X509Filter) Name() string {
+	return "X509AuthenticationFilter"
+}
+
+// Order 过滤器排序
+func (f *X509Filter) Order() int {
+	return constants.OrderFilterX509
+}
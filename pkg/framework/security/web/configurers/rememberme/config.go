@@ -0,0 +1,52 @@
+package rememberme
+
+import "time"
+
+// token 存储方式
+const (
+	RepositoryMemory = "memory"
+	RepositoryRedis  = "redis"
+)
+
+// 默认名称
+const (
+	DefaultCookieName = "remember-me"
+	DefaultParameter  = "remember-me"
+)
+
+// Config 记住我配置
+type Config struct {
+	Enable bool `yaml:"enable"`
+	// token 存储方式 memory, redis，也可以注入自定义 TokenRepository
+	Repository string `yaml:"repository"`
+	CookieName string `yaml:"cookieName"`
+	// 登录时是否记住我的表单参数
+	Parameter string `yaml:"parameter"`
+	// 有效时间，单位秒，默认两周
+	TokenValidity int64 `yaml:"tokenValidity"`
+	// 忽略登录参数，始终记住我
+	AlwaysRemember bool   `yaml:"alwaysRemember"`
+	Domain         string `yaml:"domain"`
+	Secure         bool   `yaml:"secure"`
+}
+
+func (c Config) cookieName() string {
+	if c.CookieName == "" {
+		return DefaultCookieName
+	}
+	return c.CookieName
+}
+
+func (c Config) parameter() string {
+	if c.Parameter == "" {
+		return DefaultParameter
+	}
+	return c.Parameter
+}
+
+func (c Config) tokenValidity() time.Duration {
+	if c.TokenValidity <= 0 {
+		return 14 * 24 * time.Hour
+	}
+	return time.Duration(c.TokenValidity) * time.Second
+}
@@ -0,0 +1,35 @@
+package rememberme
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
+	coreSession "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/session"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/session"
+)
+
+// SecurityConfigurer 记住我配置
+type SecurityConfigurer struct {
+	Config            Config
+	Services          Services
+	SessionStore      session.Store
+	ConcurrentControl *coreSession.ConcurrentControl
+}
+
+// NewSecurityConfigurer 配置
+func NewSecurityConfigurer(config Config, services Services, store session.Store) *SecurityConfigurer {
+	return &SecurityConfigurer{
+		Config:       config,
+		Services:     services,
+		SessionStore: store,
+	}
+}
+
+// HTTPConfigure 配置
+func (c *SecurityConfigurer) HTTPConfigure(http security.HTTPSecurityBuilder) error {
+	if !c.Config.Enable {
+		return nil
+	}
+	f := NewFilter(c.Services, c.SessionStore)
+	f.ConcurrentControl = c.ConcurrentControl
+	http.AddFilter(f)
+	return nil
+}
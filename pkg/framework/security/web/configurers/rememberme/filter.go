@@ -0,0 +1,86 @@
+package rememberme
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/web/filter"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/constants"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	coreSession "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/session"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/session"
+)
+
+// Filter 记住我过滤器，当前请求未认证时尝试通过记住我 cookie 自动登录
+type Filter struct {
+	Services Services
+	// 不为空时自动登录成功后保存到会话中
+	SessionStore session.Store
+	// 并发控制，自动登录创建的会话同样受最大会话数量限制
+	ConcurrentControl *coreSession.ConcurrentControl
+}
+
+// NewFilter 实例化
+func NewFilter(services Services, store session.Store) *Filter {
+	return &Filter{
+		Services:     services,
+		SessionStore: store,
+	}
+}
+
+// Name 名字
+func (f *Filter) Name() string {
+	return "RememberMeAuthenticationFilter"
+}
+
+// Order 过滤器排序
+func (f *Filter) Order() int {
+	return constants.OrderFilterRememberMe
+}
+
+// DoFilter 执行过滤器
+func (f *Filter) DoFilter(context *ingot.Context, chain filter.Chain) error {
+	if context.GetAuthentication() != nil {
+		return chain.DoFilter(context)
+	}
+
+	auth, err := f.Services.AutoLogin(context)
+	if err != nil {
+		// 自动登录失败时按匿名请求继续处理
+		log.Debugf("Remember-me login failed: %s", err.Error())
+		return chain.DoFilter(context)
+	}
+	if auth != nil {
+		if err := f.saveAuthentication(context, auth); err != nil {
+			// 超出最大会话数量时按匿名请求继续处理
+			log.Debugf("Remember-me session rejected: %s", err.Error())
+			return chain.DoFilter(context)
+		}
+		context.SetAuthentication(auth)
+	}
+	return chain.DoFilter(context)
+}
+
+func (f *Filter) saveAuthentication(context *ingot.Context, auth core.Authentication) error {
+	if f.SessionStore == nil {
+		return nil
+	}
+	current, err := f.SessionStore.GetSession(context, true)
+	if err != nil {
+		return err
+	}
+	err = f.ConcurrentControl.OnAuthentication(&coreSession.Information{
+		SessionID: current.ID,
+		Principal: coreSession.PrincipalName(auth),
+		ExpiresAt: current.ExpireAt(),
+	})
+	if err != nil {
+		f.SessionStore.Invalidate(context, current)
+		return err
+	}
+	// 会话中不保存凭证信息
+	if container, ok := auth.(core.CredentialsContainer); ok {
+		container.EraseCredentials()
+	}
+	session.SetAuthentication(current, auth)
+	return f.SessionStore.Save(context, current)
+}
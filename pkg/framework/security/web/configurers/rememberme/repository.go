@@ -0,0 +1,180 @@
+package rememberme
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
+)
+
+// NewTokenRepository 根据配置创建 token 存储
+func NewTokenRepository(config Config, redisClient *store.RedisClient) TokenRepository {
+	if config.Repository == RepositoryRedis {
+		return NewRedisTokenRepository(redisClient, config.tokenValidity())
+	}
+	return NewMemoryTokenRepository()
+}
+
+// MemoryTokenRepository 内存 token 存储，只适用于单实例
+type MemoryTokenRepository struct {
+	lock   sync.RWMutex
+	series map[string]PersistentToken
+}
+
+// NewMemoryTokenRepository 实例化
+func NewMemoryTokenRepository() *MemoryTokenRepository {
+	return &MemoryTokenRepository{
+		series: make(map[string]PersistentToken),
+	}
+}
+
+// CreateNewToken 保存新的 token
+func (r *MemoryTokenRepository) CreateNewToken(token *PersistentToken) error {
+	r.lock.Lock()
+	r.series[token.Series] = *token
+	r.lock.Unlock()
+	return nil
+}
+
+// UpdateToken 更新 token
+func (r *MemoryTokenRepository) UpdateToken(series, tokenValue string, lastUsed time.Time) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	token, ok := r.series[series]
+	if !ok {
+		return nil
+	}
+	token.Token = tokenValue
+	token.LastUsed = lastUsed
+	r.series[series] = token
+	return nil
+}
+
+// GetTokenForSeries 获取 token
+func (r *MemoryTokenRepository) GetTokenForSeries(series string) (*PersistentToken, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	token, ok := r.series[series]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+// RemoveSeries 删除 series
+func (r *MemoryTokenRepository) RemoveSeries(series string) error {
+	r.lock.Lock()
+	delete(r.series, series)
+	r.lock.Unlock()
+	return nil
+}
+
+// RemoveUserTokens 删除指定租户中用户的所有 token
+func (r *MemoryTokenRepository) RemoveUserTokens(username, tenantID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for series, token := range r.series {
+		if token.Username == username && token.TenantID == tenantID {
+			delete(r.series, series)
+		}
+	}
+	return nil
+}
+
+// RedisTokenRepository redis token 存储
+// series 保存 token 数据，同时按租户和用户维护 series 集合用于批量删除
+type RedisTokenRepository struct {
+	client        *store.RedisClient
+	tokenValidity time.Duration
+}
+
+// NewRedisTokenRepository 实例化
+func NewRedisTokenRepository(client *store.RedisClient, tokenValidity time.Duration) *RedisTokenRepository {
+	return &RedisTokenRepository{
+		client:        client,
+		tokenValidity: tokenValidity,
+	}
+}
+
+// CreateNewToken 保存新的 token
+func (r *RedisTokenRepository) CreateNewToken(token *PersistentToken) error {
+	if err := r.save(token); err != nil {
+		return err
+	}
+	userKey := r.userKey(token.Username, token.TenantID)
+	if err := r.client.Cli.SAdd(userKey, token.Series).Err(); err != nil {
+		return err
+	}
+	return r.client.Cli.Expire(userKey, r.tokenValidity).Err()
+}
+
+// UpdateToken 更新 token
+func (r *RedisTokenRepository) UpdateToken(series, tokenValue string, lastUsed time.Time) error {
+	token, err := r.GetTokenForSeries(series)
+	if err != nil || token == nil {
+		return err
+	}
+	token.Token = tokenValue
+	token.LastUsed = lastUsed
+	return r.save(token)
+}
+
+// GetTokenForSeries 获取 token
+func (r *RedisTokenRepository) GetTokenForSeries(series string) (*PersistentToken, error) {
+	data, err := r.client.Cli.Get(r.seriesKey(series)).Bytes()
+	if err == store.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var token PersistentToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RemoveSeries 删除 series
+func (r *RedisTokenRepository) RemoveSeries(series string) error {
+	token, err := r.GetTokenForSeries(series)
+	if err != nil || token == nil {
+		return err
+	}
+	if err := r.client.Cli.SRem(r.userKey(token.Username, token.TenantID), series).Err(); err != nil {
+		return err
+	}
+	return r.client.Cli.Del(r.seriesKey(series)).Err()
+}
+
+// RemoveUserTokens 删除指定租户中用户的所有 token
+func (r *RedisTokenRepository) RemoveUserTokens(username, tenantID string) error {
+	userKey := r.userKey(username, tenantID)
+	seriesList, err := r.client.Cli.SMembers(userKey).Result()
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(seriesList)+1)
+	for _, series := range seriesList {
+		keys = append(keys, r.seriesKey(series))
+	}
+	keys = append(keys, userKey)
+	return r.client.Cli.Del(keys...).Err()
+}
+
+func (r *RedisTokenRepository) save(token *PersistentToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	return r.client.Cli.Set(r.seriesKey(token.Series), data, r.tokenValidity).Err()
+}
+
+func (r *RedisTokenRepository) seriesKey(series string) string {
+	return r.client.KeyPrefix + "remember-me:series:" + series
+}
+
+func (r *RedisTokenRepository) userKey(username, tenantID string) string {
+	return r.client.KeyPrefix + "remember-me:user:" + tenantID + ":" + username
+}
@@ -0,0 +1,237 @@
+package rememberme
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+)
+
+// Services 记住我服务
+type Services interface {
+	// 根据记住我 cookie 自动登录，cookie 不存在时返回 nil
+	AutoLogin(ctx *ingot.Context) (core.Authentication, error)
+	// 登录成功
+	LoginSuccess(ctx *ingot.Context, auth core.Authentication) error
+	// 登录失败
+	LoginFail(ctx *ingot.Context)
+}
+
+// NullServices 未开启记住我时使用
+type NullServices struct {
+}
+
+// AutoLogin 自动登录
+func (*NullServices) AutoLogin(ctx *ingot.Context) (core.Authentication, error) {
+	return nil, nil
+}
+
+// LoginSuccess 登录成功
+func (*NullServices) LoginSuccess(ctx *ingot.Context, auth core.Authentication) error {
+	return nil
+}
+
+// LoginFail 登录失败
+func (*NullServices) LoginFail(ctx *ingot.Context) {}
+
+// PersistentTokenServices 基于持久化 token 的记住我服务
+// cookie 中保存 series 和 token，每次自动登录后刷新 token，
+// 如果 series 存在但 token 不一致，说明 cookie 被盗用，删除该用户所有 token
+type PersistentTokenServices struct {
+	Config             Config
+	TokenRepository    TokenRepository
+	UserDetailsService userdetails.Service
+	UserDetailsChecker userdetails.Checker
+}
+
+// NewPersistentTokenServices 实例化
+func NewPersistentTokenServices(config Config, repository TokenRepository, userDetailsService userdetails.Service, checker userdetails.Checker) *PersistentTokenServices {
+	return &PersistentTokenServices{
+		Config:             config,
+		TokenRepository:    repository,
+		UserDetailsService: userDetailsService,
+		UserDetailsChecker: checker,
+	}
+}
+
+// AutoLogin 自动登录，cookie 无效时清除 cookie 并返回异常
+func (s *PersistentTokenServices) AutoLogin(ctx *ingot.Context) (core.Authentication, error) {
+	value, err := ctx.Cookie(s.Config.cookieName())
+	if err != nil || value == "" {
+		return nil, nil
+	}
+
+	auth, err := s.processAutoLoginCookie(ctx, value)
+	if err != nil {
+		s.cancelCookie(ctx)
+		return nil, err
+	}
+	return auth, nil
+}
+
+// LoginSuccess 登录成功，请求需要记住我时生成新的 series
+func (s *PersistentTokenServices) LoginSuccess(ctx *ingot.Context, auth core.Authentication) error {
+	if !s.rememberMeRequested(ctx) {
+		return nil
+	}
+	token := &PersistentToken{
+		Username: auth.GetName(auth),
+		TenantID: authentication.PrincipalTenantID(auth),
+		Series:   generateValue(),
+		Token:    generateValue(),
+		LastUsed: time.Now(),
+	}
+	if err := s.TokenRepository.CreateNewToken(token); err != nil {
+		log.Errorf("Failed to save persistent token: %s", err.Error())
+		return err
+	}
+	s.setCookie(ctx, token)
+	return nil
+}
+
+// LoginFail 登录失败，清除 cookie
+func (s *PersistentTokenServices) LoginFail(ctx *ingot.Context) {
+	s.cancelCookie(ctx)
+}
+
+// Logout 注销时删除当前 series
+func (s *PersistentTokenServices) Logout(ctx *ingot.Context) error {
+	value, err := ctx.Cookie(s.Config.cookieName())
+	s.cancelCookie(ctx)
+	if err != nil || value == "" {
+		return nil
+	}
+	series, _, ok := decodeCookie(value)
+	if !ok {
+		return nil
+	}
+	return s.TokenRepository.RemoveSeries(series)
+}
+
+func (s *PersistentTokenServices) processAutoLoginCookie(ctx *ingot.Context, value string) (core.Authentication, error) {
+	series, tokenValue, ok := decodeCookie(value)
+	if !ok {
+		return nil, errors.Unauthorized("Invalid remember-me cookie")
+	}
+
+	token, err := s.TokenRepository.GetTokenForSeries(series)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, errors.Unauthorized("No persistent token found for series")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token.Token), []byte(tokenValue)) != 1 {
+		// series 正确但 token 不一致，token 已经被他人使用
+		if err := s.TokenRepository.RemoveUserTokens(token.Username, token.TenantID); err != nil {
+			log.Errorf("Failed to remove persistent tokens: %s", err.Error())
+		}
+		log.Warnf("Remember-me series/token mismatch for user %s, implies previous cookie theft attack", token.Username)
+		return nil, errors.Unauthorized("Invalid remember-me token, implies previous cookie theft attack")
+	}
+
+	if token.LastUsed.Add(s.Config.tokenValidity()).Before(time.Now()) {
+		return nil, errors.Unauthorized("Remember-me login has expired")
+	}
+
+	// 刷新 token，series 保持不变
+	token.Token = generateValue()
+	token.LastUsed = time.Now()
+	if err := s.TokenRepository.UpdateToken(token.Series, token.Token, token.LastUsed); err != nil {
+		return nil, err
+	}
+	s.setCookie(ctx, token)
+
+	user, err := s.loadUser(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.Unauthorized("Remember-me user not found")
+	}
+	if s.UserDetailsChecker != nil {
+		if err := s.UserDetailsChecker.Check(user); err != nil {
+			return nil, err
+		}
+	}
+	return NewAuthenticationToken(user, user.GetAuthorities()), nil
+}
+
+// 多租户时用户名只在租户内唯一，在登录时的租户中加载用户
+func (s *PersistentTokenServices) loadUser(ctx *ingot.Context, token *PersistentToken) (userdetails.UserDetails, error) {
+	service, ok := s.UserDetailsService.(userdetails.DetailsService)
+	if !ok {
+		return s.UserDetailsService.LoadUserByUsername(token.Username)
+	}
+	details, err := authentication.WithTenantID(authentication.NewWebAuthenticationDetails(ctx), token.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	return service.LoadUserByUsernameAndDetails(token.Username, details)
+}
+
+func (s *PersistentTokenServices) rememberMeRequested(ctx *ingot.Context) bool {
+	if s.Config.AlwaysRemember {
+		return true
+	}
+	switch strings.ToLower(ctx.PostForm(s.Config.parameter())) {
+	case "true", "on", "yes", "1":
+		return true
+	}
+	return false
+}
+
+func (s *PersistentTokenServices) setCookie(ctx *ingot.Context, token *PersistentToken) {
+	s.writeCookie(ctx, encodeCookie(token.Series, token.Token), int(s.Config.tokenValidity().Seconds()))
+}
+
+func (s *PersistentTokenServices) cancelCookie(ctx *ingot.Context) {
+	s.writeCookie(ctx, "", -1)
+}
+
+func (s *PersistentTokenServices) writeCookie(ctx *ingot.Context, value string, maxAge int) {
+	http.SetCookie(ctx.Writer, &http.Cookie{
+		Name:     s.Config.cookieName(),
+		Value:    value,
+		Path:     "/",
+		Domain:   s.Config.Domain,
+		MaxAge:   maxAge,
+		Secure:   s.Config.Secure || ctx.Request.TLS != nil,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func generateValue() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func encodeCookie(series, token string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(series + ":" + token))
+}
+
+func decodeCookie(value string) (series, token string, ok bool) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return "", "", false
+	}
+	parts := strings.SplitN(string(data), ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
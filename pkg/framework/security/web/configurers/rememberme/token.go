@@ -0,0 +1,64 @@
+package rememberme
+
+import (
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+)
+
+// PersistentToken 持久化的记住我 token，series 在登录时生成且保持不变，token 每次使用后刷新
+type PersistentToken struct {
+	Username string
+	// 用户所属租户，自动登录时在该租户中加载用户
+	TenantID string
+	Series   string
+	Token    string
+	LastUsed time.Time
+}
+
+// TokenRepository 持久化 token 存储
+type TokenRepository interface {
+	// 保存新的 token
+	CreateNewToken(token *PersistentToken) error
+	// 更新 series 对应的 token
+	UpdateToken(series, tokenValue string, lastUsed time.Time) error
+	// 获取 series 对应的 token，不存在时返回 nil
+	GetTokenForSeries(series string) (*PersistentToken, error)
+	// 删除 series
+	RemoveSeries(series string) error
+	// 删除指定租户中用户的所有 token，不同租户中的用户名可能相同
+	RemoveUserTokens(username, tenantID string) error
+}
+
+// AuthenticationToken 记住我身份验证令牌
+type AuthenticationToken struct {
+	*authentication.AbstractAuthenticationToken
+	Principal interface{}
+}
+
+// NewAuthenticationToken 实例化，已通过身份验证
+func NewAuthenticationToken(principal interface{}, authorities []core.GrantedAuthority) *AuthenticationToken {
+	token := &AuthenticationToken{
+		AbstractAuthenticationToken: authentication.NewAbstractAuthenticationToken(authorities),
+		Principal:                   principal,
+	}
+	token.SetAuthenticated(true)
+	return token
+}
+
+// GetCredentials 凭证信息
+func (token *AuthenticationToken) GetCredentials() string {
+	return ""
+}
+
+// GetPrincipal 身份验证的主体
+func (token *AuthenticationToken) GetPrincipal() interface{} {
+	return token.Principal
+}
+
+// EraseCredentials 擦除敏感数据
+func (token *AuthenticationToken) EraseCredentials() {
+	token.EraseSecret(token.Principal)
+	token.EraseSecret(token.GetDetails())
+}
@@ -0,0 +1,28 @@
+package securitycontext
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security"
+	coreSession "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/session"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/session"
+)
+
+// SecurityConfigurer 会话身份信息配置
+type SecurityConfigurer struct {
+	SessionStore      session.Store
+	ConcurrentControl *coreSession.ConcurrentControl
+}
+
+// NewSecurityConfigurer 配置
+func NewSecurityConfigurer(store session.Store) *SecurityConfigurer {
+	return &SecurityConfigurer{
+		SessionStore: store,
+	}
+}
+
+// HTTPConfigure 配置
+func (c *SecurityConfigurer) HTTPConfigure(http security.HTTPSecurityBuilder) error {
+	f := NewFilter(c.SessionStore)
+	f.ConcurrentControl = c.ConcurrentControl
+	http.AddFilter(f)
+	return nil
+}
@@ -0,0 +1,69 @@
+package securitycontext
+
+import (
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/web/filter"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/constants"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	coreSession "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/session"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/session"
+)
+
+// Filter 从会话中恢复身份信息到 ingot.Context
+type Filter struct {
+	SessionStore session.Store
+	// 并发控制，会话被标记失效时销毁会话
+	ConcurrentControl *coreSession.ConcurrentControl
+}
+
+// NewFilter 实例化
+func NewFilter(store session.Store) *Filter {
+	return &Filter{
+		SessionStore: store,
+	}
+}
+
+// Name 名字
+func (f *Filter) Name() string {
+	return "SecurityContextPersistenceFilter"
+}
+
+// Order 过滤器排序
+func (f *Filter) Order() int {
+	return constants.OrderFilterSecurityContext
+}
+
+// DoFilter 执行过滤器
+func (f *Filter) DoFilter(context *ingot.Context, chain filter.Chain) error {
+	current, err := f.SessionStore.GetSession(context, false)
+	if err != nil {
+		// 会话存储异常时视为未登录
+		log.Errorf("Load session error: %s", err.Error())
+	}
+	if current != nil && f.ConcurrentControl.Enabled() {
+		current, err = f.checkConcurrent(context, current)
+		if err != nil {
+			return err
+		}
+	}
+	if auth := session.GetAuthentication(current); auth != nil {
+		context.SetAuthentication(auth)
+	}
+	return chain.DoFilter(context)
+}
+
+// 会话被并发控制标记失效时销毁会话，按未登录继续处理，否则刷新注册表中的会话
+func (f *Filter) checkConcurrent(context *ingot.Context, current *session.Session) (*session.Session, error) {
+	expired, err := f.ConcurrentControl.IsExpired(current.ID)
+	if err != nil {
+		return nil, err
+	}
+	if expired {
+		log.Debugf("Session %s has been expired by concurrent session control", current.ID)
+		if err := f.ConcurrentControl.Remove(current.ID); err != nil {
+			return nil, err
+		}
+		return nil, f.SessionStore.Invalidate(context, current)
+	}
+	return current, f.ConcurrentControl.Refresh(current.ID, current.ExpireAt())
+}
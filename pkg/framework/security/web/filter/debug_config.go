@@ -0,0 +1,7 @@
+package filter
+
+// DebugConfig 过滤器链调试端点配置
+type DebugConfig struct {
+	// 是否开启调试端点，开启后端点同样需要认证
+	Enable bool `yaml:"enable"`
+}
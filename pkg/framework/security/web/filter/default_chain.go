@@ -14,10 +14,15 @@ type DefaultSecurityFilterChain struct {
 
 // Matches 匹配请求
 func (c *DefaultSecurityFilterChain) Matches(context *ingot.Context) bool {
-	return c.RequestMatcher(context)
+	return c.RequestMatcher.Matches(context)
 }
 
 // GetFilters 待执行的过滤器
 func (c *DefaultSecurityFilterChain) GetFilters() filter.Filters {
 	return c.Filters
 }
+
+// GetRequestMatcher 请求匹配器
+func (c *DefaultSecurityFilterChain) GetRequestMatcher() utils.RequestMatcher {
+	return c.RequestMatcher
+}
@@ -43,12 +43,38 @@ func (p *ChainProxy) DoFilter(context *ingot.Context, chain filter.Chain) error
 func (p *ChainProxy) GetFilters(context *ingot.Context) filter.Filters {
 	for _, chain := range p.FilterChains {
 		if chain.Matches(context) {
+			log.Debugf("Request %s matched filter chain %s", context.Request.URL.Path, chain.GetRequestMatcher())
 			return chain.GetFilters()
 		}
 	}
 	return nil
 }
 
+// ChainDescription 过滤器链描述
+type ChainDescription struct {
+	Index   int      `json:"index"`
+	Matcher string   `json:"matcher"`
+	Filters []string `json:"filters"`
+}
+
+// Describe 按匹配顺序描述所有过滤器链
+func (p *ChainProxy) Describe() []ChainDescription {
+	result := make([]ChainDescription, 0, len(p.FilterChains))
+	for index, chain := range p.FilterChains {
+		filters := chain.GetFilters()
+		names := make([]string, 0, len(filters))
+		for _, f := range filters {
+			names = append(names, f.Name())
+		}
+		result = append(result, ChainDescription{
+			Index:   index,
+			Matcher: chain.GetRequestMatcher().String(),
+			Filters: names,
+		})
+	}
+	return result
+}
+
 // 内部虚拟过滤器链
 type virtualFilterChain struct {
 	context           *ingot.Context
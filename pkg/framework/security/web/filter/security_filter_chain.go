@@ -3,6 +3,7 @@ package filter
 import (
 	"github.com/ingot-cloud/ingot-go/pkg/framework/core/web/filter"
 	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/utils"
 )
 
 // SecurityFilterChain 和请求匹配后执行的过滤器链
@@ -11,4 +12,6 @@ type SecurityFilterChain interface {
 	Matches(context *ingot.Context) bool
 	// 待执行的过滤器
 	GetFilters() filter.Filters
+	// 请求匹配器
+	GetRequestMatcher() utils.RequestMatcher
 }
@@ -0,0 +1,49 @@
+package session
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/authority"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+)
+
+func init() {
+	RegisterType(&SecurityContext{})
+	RegisterType(&userdetails.User{})
+	RegisterType(&authority.SimpleGrantedAuthority{})
+}
+
+// RegisterType 注册会话属性中保存的具体类型，
+// 使用 cookie 或 redis 存储会话时，属性中的接口类型需要先注册
+func RegisterType(value interface{}) {
+	gob.Register(value)
+}
+
+// Codec 会话编解码
+type Codec interface {
+	Encode(*Session) ([]byte, error)
+	Decode([]byte) (*Session, error)
+}
+
+// GobCodec 使用 gob 编解码
+type GobCodec struct {
+}
+
+// Encode 编码
+func (GobCodec) Encode(session *Session) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(session); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode 解码
+func (GobCodec) Decode(data []byte) (*Session, error) {
+	var session Session
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
@@ -0,0 +1,110 @@
+package session
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/log"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+)
+
+// CookieStore 会话数据签名后保存在 cookie 中，数据未加密，不要保存敏感信息
+type CookieStore struct {
+	Config Config
+	Codec  Codec
+	secret []byte
+}
+
+// MinSecretLength cookie 签名密钥最小长度
+const MinSecretLength = 32
+
+// NewCookieStore 实例化，签名密钥为空或过短时返回错误，避免会话被伪造
+func NewCookieStore(config Config, codec Codec) (*CookieStore, error) {
+	if len(config.Secret) < MinSecretLength {
+		return nil, errors.IllegalArgument(fmt.Sprintf("Cookie session store secret must be at least %d characters", MinSecretLength))
+	}
+	return &CookieStore{
+		Config: config,
+		Codec:  codec,
+		secret: []byte(config.Secret),
+	}, nil
+}
+
+// GetSession 获取当前请求的会话
+func (s *CookieStore) GetSession(ctx *ingot.Context, create bool) (*Session, error) {
+	session, ok := currentSession(ctx)
+	if !ok {
+		session = s.load(ctx)
+		if session != nil {
+			// 刷新最后访问时间
+			session.LastAccessedAt = time.Now()
+			if err := s.Save(ctx, session); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if session == nil && create {
+		session = NewSession(s.Config.timeout())
+		if err := s.Save(ctx, session); err != nil {
+			return nil, err
+		}
+	}
+	return session, nil
+}
+
+// Save 保存会话
+func (s *CookieStore) Save(ctx *ingot.Context, session *Session) error {
+	data, err := s.Codec.Encode(session)
+	if err != nil {
+		return err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	cacheSession(ctx, session)
+	s.Config.writeCookie(ctx, payload+"."+s.sign(payload), int(session.MaxInactiveInterval/time.Second))
+	return nil
+}
+
+// Invalidate 销毁会话
+func (s *CookieStore) Invalidate(ctx *ingot.Context, session *Session) error {
+	invalidateCache(ctx)
+	s.Config.writeCookie(ctx, "", -1)
+	return nil
+}
+
+// 校验签名并解码会话，签名错误或会话过期时返回 nil
+func (s *CookieStore) load(ctx *ingot.Context) *Session {
+	value := s.Config.readCookie(ctx)
+	index := strings.LastIndex(value, ".")
+	if index <= 0 {
+		return nil
+	}
+	payload, signature := value[:index], value[index+1:]
+	if !hmac.Equal([]byte(signature), []byte(s.sign(payload))) {
+		log.Debugf("Cookie session signature mismatch")
+		return nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil
+	}
+	session, err := s.Codec.Decode(data)
+	if err != nil {
+		log.Debugf("Cookie session decode error: %s", err.Error())
+		return nil
+	}
+	if session.IsExpired(time.Now()) {
+		return nil
+	}
+	return session
+}
+
+func (s *CookieStore) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
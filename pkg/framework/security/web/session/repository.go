@@ -0,0 +1,124 @@
+package session
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
+)
+
+// Repository 以会话ID保存会话
+type Repository interface {
+	// 查询会话，不存在或已过期时返回 nil
+	FindByID(id string) (*Session, error)
+	Save(session *Session) error
+	DeleteByID(id string) error
+}
+
+// 内存会话仓库清理过期会话的间隔
+const memorySweepInterval = time.Minute
+
+// MemoryRepository 内存会话仓库，查询时清理过期会话，保存时按间隔清理所有过期会话
+type MemoryRepository struct {
+	sessions  map[string]*Session
+	lock      sync.RWMutex
+	lastSweep time.Time
+}
+
+// NewMemoryRepository 实例化
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		sessions: make(map[string]*Session),
+	}
+}
+
+// FindByID 查询会话
+func (r *MemoryRepository) FindByID(id string) (*Session, error) {
+	r.lock.RLock()
+	session, ok := r.sessions[id]
+	r.lock.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+	if session.IsExpired(time.Now()) {
+		_ = r.DeleteByID(id)
+		return nil, nil
+	}
+	return session.copy(), nil
+}
+
+// Save 保存会话
+func (r *MemoryRepository) Save(session *Session) error {
+	now := time.Now()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if now.Sub(r.lastSweep) >= memorySweepInterval {
+		r.lastSweep = now
+		for id, s := range r.sessions {
+			if s.IsExpired(now) {
+				delete(r.sessions, id)
+			}
+		}
+	}
+	r.sessions[session.ID] = session.copy()
+	return nil
+}
+
+// DeleteByID 删除会话
+func (r *MemoryRepository) DeleteByID(id string) error {
+	r.lock.Lock()
+	delete(r.sessions, id)
+	r.lock.Unlock()
+	return nil
+}
+
+// RedisRepository redis 会话仓库
+type RedisRepository struct {
+	client *store.RedisClient
+	codec  Codec
+}
+
+// NewRedisRepository 实例化
+func NewRedisRepository(client *store.RedisClient, codec Codec) *RedisRepository {
+	return &RedisRepository{
+		client: client,
+		codec:  codec,
+	}
+}
+
+// FindByID 查询会话
+func (r *RedisRepository) FindByID(id string) (*Session, error) {
+	data, err := r.client.Cli.Get(r.key(id)).Bytes()
+	if err == store.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	session, err := r.codec.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if session.IsExpired(time.Now()) {
+		return nil, r.DeleteByID(id)
+	}
+	return session, nil
+}
+
+// Save 保存会话
+func (r *RedisRepository) Save(session *Session) error {
+	data, err := r.codec.Encode(session)
+	if err != nil {
+		return err
+	}
+	return r.client.Cli.Set(r.key(session.ID), data, session.MaxInactiveInterval).Err()
+}
+
+// DeleteByID 删除会话
+func (r *RedisRepository) DeleteByID(id string) error {
+	return r.client.Cli.Del(r.key(id)).Err()
+}
+
+func (r *RedisRepository) key(id string) string {
+	return r.client.KeyPrefix + "session:" + id
+}
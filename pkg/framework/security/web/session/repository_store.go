@@ -0,0 +1,78 @@
+package session
+
+import (
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+)
+
+// RepositoryStore cookie 中只保存会话ID，会话数据保存在 Repository 中
+type RepositoryStore struct {
+	Repository Repository
+	Config     Config
+}
+
+// NewRepositoryStore 实例化
+func NewRepositoryStore(repository Repository, config Config) *RepositoryStore {
+	return &RepositoryStore{
+		Repository: repository,
+		Config:     config,
+	}
+}
+
+// GetSession 获取当前请求的会话
+func (s *RepositoryStore) GetSession(ctx *ingot.Context, create bool) (*Session, error) {
+	session, ok := currentSession(ctx)
+	if !ok {
+		var err error
+		session, err = s.load(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if session == nil && create {
+		session = NewSession(s.Config.timeout())
+		if err := s.Save(ctx, session); err != nil {
+			return nil, err
+		}
+	}
+	return session, nil
+}
+
+// Save 保存会话
+func (s *RepositoryStore) Save(ctx *ingot.Context, session *Session) error {
+	if err := s.Repository.Save(session); err != nil {
+		return err
+	}
+	cacheSession(ctx, session)
+	s.Config.writeCookie(ctx, session.ID, 0)
+	return nil
+}
+
+// Invalidate 销毁会话
+func (s *RepositoryStore) Invalidate(ctx *ingot.Context, session *Session) error {
+	invalidateCache(ctx)
+	s.Config.writeCookie(ctx, "", -1)
+	if session == nil {
+		return nil
+	}
+	return s.Repository.DeleteByID(session.ID)
+}
+
+// 通过 cookie 中的会话ID加载会话，并刷新最后访问时间
+func (s *RepositoryStore) load(ctx *ingot.Context) (*Session, error) {
+	id := s.Config.readCookie(ctx)
+	if id == "" {
+		return nil, nil
+	}
+	session, err := s.Repository.FindByID(id)
+	if err != nil || session == nil {
+		return nil, err
+	}
+	session.LastAccessedAt = time.Now()
+	if err := s.Repository.Save(session); err != nil {
+		return nil, err
+	}
+	cacheSession(ctx, session)
+	return session, nil
+}
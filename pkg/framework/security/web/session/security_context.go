@@ -0,0 +1,45 @@
+package session
+
+import (
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/authority"
+)
+
+// SecurityContextKey 会话中保存身份信息的属性名
+const SecurityContextKey = "INGOT_SECURITY_CONTEXT"
+
+// SecurityContext 会话中保存的身份信息，只保存已认证的主体和权限，不保存凭证
+type SecurityContext struct {
+	Principal       interface{}
+	Authorities     []string
+	AuthenticatedAt time.Time
+}
+
+// SetAuthentication 在会话中保存身份信息
+func SetAuthentication(session *Session, auth core.Authentication) {
+	if auth == nil {
+		session.RemoveAttribute(SecurityContextKey)
+		return
+	}
+	session.SetAttribute(SecurityContextKey, &SecurityContext{
+		Principal:       auth.GetPrincipal(),
+		Authorities:     authority.ToStringArray(auth.GetAuthorities()),
+		AuthenticatedAt: time.Now(),
+	})
+}
+
+// GetAuthentication 从会话中获取身份信息
+func GetAuthentication(session *Session) core.Authentication {
+	if session == nil {
+		return nil
+	}
+	securityContext, ok := session.GetAttribute(SecurityContextKey).(*SecurityContext)
+	if !ok || securityContext.Principal == nil {
+		return nil
+	}
+	return authentication.NewAuthenticatedUsernamePasswordAuthToken(
+		securityContext.Principal, "", authority.CreateAuthorityList(securityContext.Authorities))
+}
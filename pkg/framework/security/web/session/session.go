@@ -0,0 +1,70 @@
+package session
+
+import (
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/utils/uuid"
+)
+
+// Session 服务端会话
+type Session struct {
+	ID             string
+	Attributes     map[string]interface{}
+	CreatedAt      time.Time
+	LastAccessedAt time.Time
+	// 最大不活跃时间
+	MaxInactiveInterval time.Duration
+}
+
+// NewSession 创建会话
+func NewSession(maxInactiveInterval time.Duration) *Session {
+	now := time.Now()
+	return &Session{
+		ID:                  uuid.MustString(),
+		Attributes:          make(map[string]interface{}),
+		CreatedAt:           now,
+		LastAccessedAt:      now,
+		MaxInactiveInterval: maxInactiveInterval,
+	}
+}
+
+// GetAttribute 获取属性
+func (s *Session) GetAttribute(name string) interface{} {
+	return s.Attributes[name]
+}
+
+// SetAttribute 设置属性
+func (s *Session) SetAttribute(name string, value interface{}) {
+	if s.Attributes == nil {
+		s.Attributes = make(map[string]interface{})
+	}
+	s.Attributes[name] = value
+}
+
+// RemoveAttribute 移除属性
+func (s *Session) RemoveAttribute(name string) {
+	delete(s.Attributes, name)
+}
+
+// IsExpired 是否已经过期
+func (s *Session) IsExpired(now time.Time) bool {
+	if s.MaxInactiveInterval <= 0 {
+		return false
+	}
+	return now.Sub(s.LastAccessedAt) >= s.MaxInactiveInterval
+}
+
+// ExpireAt 过期时间
+func (s *Session) ExpireAt() time.Time {
+	return s.LastAccessedAt.Add(s.MaxInactiveInterval)
+}
+
+// 浅拷贝会话，避免并发请求共享同一个属性集合
+func (s *Session) copy() *Session {
+	result := *s
+	result.Attributes = make(map[string]interface{}, len(s.Attributes))
+	for k, v := range s.Attributes {
+		result.Attributes[k] = v
+	}
+	return &result
+}
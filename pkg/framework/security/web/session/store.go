@@ -0,0 +1,140 @@
+package session
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
+)
+
+// 会话存储方式
+const (
+	StoreCookie = "cookie"
+	StoreMemory = "memory"
+	StoreRedis  = "redis"
+)
+
+// 当前请求中缓存会话的 key
+const (
+	contextSessionKey     = "ingot.session"
+	contextInvalidatedKey = "ingot.session.invalidated"
+)
+
+// Store 会话存储
+type Store interface {
+	// 获取当前请求的会话，create 为 true 时不存在则创建新会话，否则返回 nil
+	GetSession(ctx *ingot.Context, create bool) (*Session, error)
+	// 保存会话并写入 cookie
+	Save(ctx *ingot.Context, session *Session) error
+	// 销毁会话并清除 cookie
+	Invalidate(ctx *ingot.Context, session *Session) error
+}
+
+// Config 会话配置
+type Config struct {
+	// 存储方式 cookie, memory, redis
+	Store string `yaml:"store"`
+	// cookie 名称
+	CookieName string `yaml:"cookieName"`
+	// cookie 存储时的签名密钥，至少 32 个字符
+	Secret string `yaml:"secret"`
+	// 会话超时时间，单位秒
+	Timeout int64  `yaml:"timeout"`
+	Domain  string `yaml:"domain"`
+	Path    string `yaml:"path"`
+	Secure  bool   `yaml:"secure"`
+	// Lax, Strict, None
+	SameSite string `yaml:"sameSite"`
+}
+
+// NewStore 根据配置创建会话存储，cookie 存储未配置有效的签名密钥时 panic，拒绝启动
+func NewStore(config Config, redisClient *store.RedisClient) Store {
+	switch config.Store {
+	case StoreRedis:
+		return NewRepositoryStore(NewRedisRepository(redisClient, GobCodec{}), config)
+	case StoreCookie:
+		cookieStore, err := NewCookieStore(config, GobCodec{})
+		if err != nil {
+			panic(err)
+		}
+		return cookieStore
+	default:
+		return NewRepositoryStore(NewMemoryRepository(), config)
+	}
+}
+
+func (c Config) cookieName() string {
+	if c.CookieName == "" {
+		return "INGOTSESSION"
+	}
+	return c.CookieName
+}
+
+func (c Config) timeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 30 * time.Minute
+	}
+	return time.Duration(c.Timeout) * time.Second
+}
+
+func (c Config) sameSite() http.SameSite {
+	switch strings.ToLower(c.SameSite) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
+
+// 写入 cookie，maxAge 小于 0 时删除 cookie
+func (c Config) writeCookie(ctx *ingot.Context, value string, maxAge int) {
+	path := c.Path
+	if path == "" {
+		path = "/"
+	}
+	http.SetCookie(ctx.Writer, &http.Cookie{
+		Name:     c.cookieName(),
+		Value:    value,
+		Path:     path,
+		Domain:   c.Domain,
+		MaxAge:   maxAge,
+		Secure:   c.Secure,
+		HttpOnly: true,
+		SameSite: c.sameSite(),
+	})
+}
+
+func (c Config) readCookie(ctx *ingot.Context) string {
+	value, err := ctx.Cookie(c.cookieName())
+	if err != nil {
+		return ""
+	}
+	return value
+}
+
+// 获取当前请求中缓存的会话
+func currentSession(ctx *ingot.Context) (*Session, bool) {
+	if _, ok := ctx.Get(contextInvalidatedKey); ok {
+		return nil, true
+	}
+	if value, ok := ctx.Get(contextSessionKey); ok {
+		return value.(*Session), true
+	}
+	return nil, false
+}
+
+func cacheSession(ctx *ingot.Context, session *Session) {
+	ctx.Set(contextSessionKey, session)
+	if _, ok := ctx.Get(contextInvalidatedKey); ok {
+		delete(ctx.Keys, contextInvalidatedKey)
+	}
+}
+
+func invalidateCache(ctx *ingot.Context) {
+	delete(ctx.Keys, contextSessionKey)
+	ctx.Set(contextInvalidatedKey, true)
+}
@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+)
+
+// NewHeaderRequestMatcher 请求头匹配，value 为空时只校验请求头是否存在
+func NewHeaderRequestMatcher(name string, value ...string) RequestMatcher {
+	return &headerRequestMatcher{
+		name:   name,
+		values: value,
+	}
+}
+
+type headerRequestMatcher struct {
+	name   string
+	values []string
+}
+
+func (m *headerRequestMatcher) Matches(ctx *ingot.Context) bool {
+	actual := ctx.GetHeader(m.name)
+	if actual == "" {
+		return false
+	}
+	return matchValue(m.values, actual)
+}
+
+func (m *headerRequestMatcher) String() string {
+	return fmt.Sprintf("Header [name='%s', values=%v]", m.name, m.values)
+}
+
+// NewParameterRequestMatcher 请求参数匹配，包括 query 和 form 参数，value 为空时只校验参数是否存在
+func NewParameterRequestMatcher(name string, value ...string) RequestMatcher {
+	return &parameterRequestMatcher{
+		name:   name,
+		values: value,
+	}
+}
+
+type parameterRequestMatcher struct {
+	name   string
+	values []string
+}
+
+func (m *parameterRequestMatcher) Matches(ctx *ingot.Context) bool {
+	actual, ok := ctx.GetQuery(m.name)
+	if !ok {
+		actual, ok = ctx.GetPostForm(m.name)
+	}
+	if !ok {
+		return false
+	}
+	return matchValue(m.values, actual)
+}
+
+func (m *parameterRequestMatcher) String() string {
+	return fmt.Sprintf("Parameter [name='%s', values=%v]", m.name, m.values)
+}
+
+func matchValue(values []string, actual string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == actual {
+			return true
+		}
+	}
+	return false
+}
+
+// NewMediaTypeRequestMatcher 根据 Accept 请求头匹配媒体类型，支持通配符，未设置 Accept 时视为 */*
+func NewMediaTypeRequestMatcher(mediaTypes ...string) RequestMatcher {
+	return &mediaTypeRequestMatcher{
+		mediaTypes: mediaTypes,
+	}
+}
+
+type mediaTypeRequestMatcher struct {
+	mediaTypes []string
+}
+
+func (m *mediaTypeRequestMatcher) Matches(ctx *ingot.Context) bool {
+	accept := ctx.GetHeader("Accept")
+	if strings.TrimSpace(accept) == "" {
+		accept = "*/*"
+	}
+	for _, item := range strings.Split(accept, ",") {
+		accepted, _, err := mime.ParseMediaType(strings.TrimSpace(item))
+		if err != nil {
+			continue
+		}
+		for _, mediaType := range m.mediaTypes {
+			if compatibleMediaType(accepted, strings.ToLower(mediaType)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m *mediaTypeRequestMatcher) String() string {
+	return fmt.Sprintf("MediaType [mediaTypes=%v]", m.mediaTypes)
+}
+
+// 判断两个媒体类型是否兼容，支持 * 通配
+func compatibleMediaType(a, b string) bool {
+	aType, aSub := splitMediaType(a)
+	bType, bSub := splitMediaType(b)
+	if aType != "*" && bType != "*" && aType != bType {
+		return false
+	}
+	return aSub == "*" || bSub == "*" || aSub == bSub
+}
+
+func splitMediaType(mediaType string) (string, string) {
+	parts := strings.SplitN(mediaType, "/", 2)
+	if len(parts) != 2 {
+		return parts[0], "*"
+	}
+	return parts[0], parts[1]
+}
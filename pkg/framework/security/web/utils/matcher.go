@@ -1,11 +1,183 @@
 package utils
 
-import "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+)
 
 // RequestMatcher 请求匹配器
-type RequestMatcher func(*ingot.Context) bool
+type RequestMatcher interface {
+	// 是否匹配当前请求
+	Matches(*ingot.Context) bool
+	// 匹配器描述，用于日志输出
+	String() string
+}
 
 // AnyRequestMatcher 匹配所有请求
-func AnyRequestMatcher(context *ingot.Context) bool {
+var AnyRequestMatcher RequestMatcher = &anyRequestMatcher{}
+
+type anyRequestMatcher struct {
+}
+
+func (*anyRequestMatcher) Matches(*ingot.Context) bool {
+	return true
+}
+
+func (*anyRequestMatcher) String() string {
+	return "any request"
+}
+
+// anyRequestPattern 路径匹配器在不限制请求方法且模式匹配所有路径时实现该接口
+type anyRequestPattern interface {
+	matchesAnyRequest() bool
+}
+
+// IsAnyRequestMatcher 是否为匹配所有请求的匹配器，包括 /** 等匹配所有路径的路径匹配器
+func IsAnyRequestMatcher(matcher RequestMatcher) bool {
+	switch m := matcher.(type) {
+	case *anyRequestMatcher:
+		return true
+	case anyRequestPattern:
+		return m.matchesAnyRequest()
+	case *orRequestMatcher:
+		for _, item := range m.matchers {
+			if IsAnyRequestMatcher(item) {
+				return true
+			}
+		}
+		return false
+	case *andRequestMatcher:
+		if len(m.matchers) == 0 {
+			return false
+		}
+		for _, item := range m.matchers {
+			if !IsAnyRequestMatcher(item) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// NewRequestMatcher 通过方法构建请求匹配器
+func NewRequestMatcher(description string, fn func(*ingot.Context) bool) RequestMatcher {
+	return &funcRequestMatcher{
+		description: description,
+		fn:          fn,
+	}
+}
+
+type funcRequestMatcher struct {
+	description string
+	fn          func(*ingot.Context) bool
+}
+
+func (m *funcRequestMatcher) Matches(ctx *ingot.Context) bool {
+	return m.fn(ctx)
+}
+
+func (m *funcRequestMatcher) String() string {
+	return m.description
+}
+
+// And 所有匹配器都匹配时才匹配
+func And(matchers ...RequestMatcher) RequestMatcher {
+	return &andRequestMatcher{
+		matchers: matchers,
+	}
+}
+
+type andRequestMatcher struct {
+	matchers []RequestMatcher
+}
+
+func (m *andRequestMatcher) Matches(ctx *ingot.Context) bool {
+	if len(m.matchers) == 0 {
+		return false
+	}
+	for _, matcher := range m.matchers {
+		if !matcher.Matches(ctx) {
+			return false
+		}
+	}
 	return true
 }
+
+func (m *andRequestMatcher) String() string {
+	return fmt.Sprintf("And %s", joinMatchers(m.matchers))
+}
+
+// Or 任意一个匹配器匹配时即匹配
+func Or(matchers ...RequestMatcher) RequestMatcher {
+	return &orRequestMatcher{
+		matchers: matchers,
+	}
+}
+
+type orRequestMatcher struct {
+	matchers []RequestMatcher
+}
+
+func (m *orRequestMatcher) Matches(ctx *ingot.Context) bool {
+	for _, matcher := range m.matchers {
+		if matcher.Matches(ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *orRequestMatcher) String() string {
+	return fmt.Sprintf("Or %s", joinMatchers(m.matchers))
+}
+
+// Not 对匹配结果取反
+func Not(matcher RequestMatcher) RequestMatcher {
+	return &notRequestMatcher{
+		matcher: matcher,
+	}
+}
+
+type notRequestMatcher struct {
+	matcher RequestMatcher
+}
+
+func (m *notRequestMatcher) Matches(ctx *ingot.Context) bool {
+	return !m.matcher.Matches(ctx)
+}
+
+func (m *notRequestMatcher) String() string {
+	return fmt.Sprintf("Not [%s]", m.matcher)
+}
+
+func joinMatchers(matchers []RequestMatcher) string {
+	descriptions := make([]string, 0, len(matchers))
+	for _, matcher := range matchers {
+		descriptions = append(descriptions, matcher.String())
+	}
+	return "[" + strings.Join(descriptions, ", ") + "]"
+}
+
+// 请求方法是否匹配，methods 为空时匹配所有方法
+func matchMethod(methods []string, ctx *ingot.Context) bool {
+	if len(methods) == 0 {
+		return true
+	}
+	for _, method := range methods {
+		if strings.EqualFold(method, ctx.Request.Method) {
+			return true
+		}
+	}
+	return false
+}
+
+func describeMethods(methods []string) string {
+	if len(methods) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(", methods=%v", methods)
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/utils/pathmatcher"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+)
+
+var antPathMatcher = pathmatcher.NewAntPathMatcher()
+
+// NewAntPathRequestMatcher Ant 风格路径匹配，methods 为空时匹配所有请求方法
+func NewAntPathRequestMatcher(pattern string, methods ...string) RequestMatcher {
+	return &antPathRequestMatcher{
+		pattern: pattern,
+		methods: methods,
+	}
+}
+
+// NewAntPathRequestMatchers 匹配任意一个 Ant 风格路径
+func NewAntPathRequestMatchers(patterns ...string) RequestMatcher {
+	matchers := make([]RequestMatcher, 0, len(patterns))
+	for _, pattern := range patterns {
+		matchers = append(matchers, NewAntPathRequestMatcher(pattern))
+	}
+	return Or(matchers...)
+}
+
+type antPathRequestMatcher struct {
+	pattern string
+	methods []string
+}
+
+func (m *antPathRequestMatcher) Matches(ctx *ingot.Context) bool {
+	if !matchMethod(m.methods, ctx) {
+		return false
+	}
+	return antPathMatcher.Match(m.pattern, ctx.Request.URL.Path)
+}
+
+func (m *antPathRequestMatcher) matchesAnyRequest() bool {
+	// 只包含 / 和 * 且包含 ** 的模式匹配所有路径，如 /**、/**/*
+	return len(m.methods) == 0 && strings.Contains(m.pattern, "**") && strings.Trim(m.pattern, "/*") == ""
+}
+
+func (m *antPathRequestMatcher) String() string {
+	return fmt.Sprintf("Ant [pattern='%s'%s]", m.pattern, describeMethods(m.methods))
+}
+
+// NewRegexRequestMatcher 正则路径匹配，pattern 需要匹配整个路径，pattern 非法时 panic
+func NewRegexRequestMatcher(pattern string, methods ...string) RequestMatcher {
+	return &regexRequestMatcher{
+		source:  pattern,
+		pattern: regexp.MustCompile("^(?:" + pattern + ")$"),
+		methods: methods,
+	}
+}
+
+type regexRequestMatcher struct {
+	source  string
+	pattern *regexp.Regexp
+	methods []string
+}
+
+func (m *regexRequestMatcher) Matches(ctx *ingot.Context) bool {
+	if !matchMethod(m.methods, ctx) {
+		return false
+	}
+	return m.pattern.MatchString(ctx.Request.URL.Path)
+}
+
+func (m *regexRequestMatcher) matchesAnyRequest() bool {
+	if len(m.methods) != 0 {
+		return false
+	}
+	pattern := strings.TrimSuffix(strings.TrimPrefix(m.source, "^"), "$")
+	switch pattern {
+	case ".*", ".+", "/.*":
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *regexRequestMatcher) String() string {
+	return fmt.Sprintf("Regex [pattern='%s'%s]", m.source, describeMethods(m.methods))
+}
@@ -6,6 +6,9 @@ import (
 	"github.com/go-redis/redis"
 )
 
+// Nil redis 返回值为空
+const Nil = redis.Nil
+
 // RedisParams for create
 type RedisParams struct {
 	Address   string
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// 内存存储清理过期数据的间隔
+const memorySweepInterval = time.Minute
+
+// TTLStore 带过期时间的键值存储，值使用 json 编码
+type TTLStore interface {
+	// 保存，已存在时覆盖，ttl 小于等于 0 时删除
+	Set(key string, value interface{}, ttl time.Duration) error
+	// 获取并解码到 value，不存在或已过期时返回 false
+	Get(key string, value interface{}) (bool, error)
+	// 删除
+	Delete(key string) error
+}
+
+type memoryEntry struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+// MemoryTTLStore 内存存储，只适用于单实例，获取时清理过期数据，保存时按间隔清理所有过期数据
+type MemoryTTLStore struct {
+	lock      sync.Mutex
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+}
+
+// NewMemoryTTLStore 实例化
+func NewMemoryTTLStore() *MemoryTTLStore {
+	return &MemoryTTLStore{
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+// Set 保存
+func (s *MemoryTTLStore) Set(key string, value interface{}, ttl time.Duration) error {
+	if ttl <= 0 {
+		return s.Delete(key)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if now.Sub(s.lastSweep) >= memorySweepInterval {
+		s.lastSweep = now
+		for k, entry := range s.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(s.entries, k)
+			}
+		}
+	}
+	s.entries[key] = memoryEntry{
+		data:      data,
+		expiresAt: now.Add(ttl),
+	}
+	return nil
+}
+
+// Get 获取
+func (s *MemoryTTLStore) Get(key string, value interface{}) (bool, error) {
+	s.lock.Lock()
+	entry, ok := s.entries[key]
+	if ok && !time.Now().Before(entry.expiresAt) {
+		delete(s.entries, key)
+		ok = false
+	}
+	s.lock.Unlock()
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(entry.data, value)
+}
+
+// Delete 删除
+func (s *MemoryTTLStore) Delete(key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// RedisTTLStore redis 存储，适用于多实例部署
+type RedisTTLStore struct {
+	client *RedisClient
+}
+
+// NewRedisTTLStore 实例化
+func NewRedisTTLStore(client *RedisClient) *RedisTTLStore {
+	return &RedisTTLStore{
+		client: client,
+	}
+}
+
+// Set 保存
+func (s *RedisTTLStore) Set(key string, value interface{}, ttl time.Duration) error {
+	if ttl <= 0 {
+		return s.Delete(key)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return s.client.Cli.Set(s.key(key), data, ttl).Err()
+}
+
+// Get 获取
+func (s *RedisTTLStore) Get(key string, value interface{}) (bool, error) {
+	data, err := s.client.Cli.Get(s.key(key)).Bytes()
+	if err == Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(data, value)
+}
+
+// Delete 删除
+func (s *RedisTTLStore) Delete(key string) error {
+	return s.client.Cli.Del(s.key(key)).Err()
+}
+
+func (s *RedisTTLStore) key(key string) string {
+	return s.client.KeyPrefix + key
+}
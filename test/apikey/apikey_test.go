@@ -0,0 +1,95 @@
+package apikey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/apikey"
+	coreApiKey "github.com/ingot-cloud/ingot-go/pkg/framework/security/core/apikey"
+)
+
+type service struct {
+	keys map[string]*coreApiKey.Details
+}
+
+func (s *service) LoadByPrefix(prefix string) (*coreApiKey.Details, error) {
+	return s.keys[prefix], nil
+}
+
+func (s *service) UpdateLastUsed(id string, lastUsed time.Time) error {
+	return nil
+}
+
+func TestGenerate(t *testing.T) {
+	key, prefix, hashed, err := coreApiKey.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prefix) < 24 {
+		t.Errorf("expected prefix of at least 12 random bytes, got %q", prefix)
+	}
+	p, secret, ok := coreApiKey.Split(key)
+	if !ok || p != prefix {
+		t.Fatalf("unexpected split result for %q", key)
+	}
+	if !coreApiKey.Matches(secret, hashed) {
+		t.Error("expected secret to match hash")
+	}
+	if coreApiKey.Matches(secret+"x", hashed) {
+		t.Error("expected modified secret not to match")
+	}
+
+	_, other, _, err := coreApiKey.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == prefix {
+		t.Error("expected unique prefix")
+	}
+}
+
+func TestProvider(t *testing.T) {
+	key, prefix, hashed, err := coreApiKey.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	details := &coreApiKey.Details{
+		ID:        "1",
+		Prefix:    prefix,
+		HashedKey: hashed,
+		Principal: "ingot",
+		Scopes:    []string{"read"},
+		Enabled:   true,
+	}
+	provider := apikey.NewProvider(&service{keys: map[string]*coreApiKey.Details{prefix: details}})
+
+	result, err := provider.Authenticate(authentication.NewUnauthenticatedApiKeyAuthToken(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.GetName(result) != "ingot" || len(result.GetAuthorities()) != 1 {
+		t.Error("unexpected authentication result")
+	}
+	if _, ok := result.GetDetails().(*coreApiKey.Details); !ok {
+		t.Error("expected api key details")
+	} else if result.GetDetails().(*coreApiKey.Details).HashedKey != "" {
+		t.Error("expected hashed key not to be exposed")
+	}
+
+	for _, invalid := range []string{"", prefix, prefix + ".invalid", "unknown.secret"} {
+		if _, err := provider.Authenticate(authentication.NewUnauthenticatedApiKeyAuthToken(invalid)); err == nil {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+
+	details.Enabled = false
+	if _, err := provider.Authenticate(authentication.NewUnauthenticatedApiKeyAuthToken(key)); err == nil {
+		t.Error("expected disabled key to be rejected")
+	}
+	details.Enabled = true
+	details.ExpiresAt = time.Now().Add(-time.Minute)
+	if _, err := provider.Authenticate(authentication.NewUnauthenticatedApiKeyAuthToken(key)); err == nil {
+		t.Error("expected expired key to be rejected")
+	}
+}
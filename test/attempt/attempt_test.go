@@ -0,0 +1,154 @@
+package attempt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/dao"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/attempt"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails/cache"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/crypto/password"
+	securityErrors "github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
+)
+
+type userService struct{}
+
+func (userService) LoadUserByUsername(username string) (userdetails.UserDetails, error) {
+	return userdetails.NewUser(username, "secret", nil), nil
+}
+
+func newProvider(tracker *attempt.Tracker) *dao.AuthenticationProvider {
+	return dao.NewProvider(&password.NoopEncoder{}, userService{}, cache.NewNilUserCache(), dao.NewPreChecker(), dao.NewPostChecker(), tracker)
+}
+
+type idUser struct {
+	*userdetails.User
+}
+
+func (u idUser) GetUserID() string {
+	return "1"
+}
+
+// 不同的登录名解析到同一个用户ID
+type idUserService struct{}
+
+func (idUserService) LoadUserByUsername(username string) (userdetails.UserDetails, error) {
+	return idUser{userdetails.NewUser("admin", "secret", nil)}, nil
+}
+
+func login(provider *dao.AuthenticationProvider, pwd, remoteAddress string) error {
+	return loginAs(provider, "admin", pwd, remoteAddress)
+}
+
+func loginAs(provider *dao.AuthenticationProvider, username, pwd, remoteAddress string) error {
+	token := authentication.NewUnauthenticatedUsernamePasswordAuthToken(username, pwd)
+	token.SetDetails(&authentication.WebAuthenticationDetails{RemoteAddress: remoteAddress})
+	_, err := provider.Authenticate(token)
+	return err
+}
+
+func code(err error) string {
+	if err == nil {
+		return ""
+	}
+	return errors.Unpack(err).Code
+}
+
+func TestLockout(t *testing.T) {
+	config := attempt.Config{Enable: true, MaxAttempts: 3, LockSeconds: 60}
+	provider := newProvider(attempt.NewTracker(config, attempt.NewMemoryStore()))
+
+	for i := 0; i < 3; i++ {
+		if c := code(login(provider, "wrong", "10.0.0.1")); c != securityErrors.BadCredentialsCode {
+			t.Fatalf("attempt %d, expected bad credentials, actual=%s", i, c)
+		}
+	}
+	// 锁定后正确的密码也无法登录
+	if c := code(login(provider, "secret", "10.0.0.1")); c != securityErrors.AccountLockCode {
+		t.Fatalf("expected account lock, actual=%s", c)
+	}
+	// 其他客户端地址不受影响
+	if err := login(provider, "secret", "10.0.0.2"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResetOnSuccess(t *testing.T) {
+	config := attempt.Config{Enable: true, MaxAttempts: 3}
+	provider := newProvider(attempt.NewTracker(config, attempt.NewMemoryStore()))
+
+	for i := 0; i < 5; i++ {
+		_ = login(provider, "wrong", "10.0.0.1")
+		_ = login(provider, "wrong", "10.0.0.1")
+		if err := login(provider, "secret", "10.0.0.1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	config := attempt.Config{Enable: true, MaxAttempts: 1, LockSeconds: 60, MaxLockSeconds: 180}
+	store := attempt.NewMemoryStore()
+	tracker := attempt.NewTracker(config, store)
+
+	expected := []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute}
+	for i, duration := range expected {
+		now := time.Now()
+		if err := tracker.LoginFailed("admin", "10.0.0.1"); err != nil {
+			t.Fatal(err)
+		}
+		record, _ := store.Get("admin:10.0.0.1")
+		actual := record.LockedUntil.Sub(now).Round(time.Second)
+		if actual != duration {
+			t.Errorf("lock %d, expected=%s, actual=%s", i+1, duration, actual)
+		}
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	provider := newProvider(nil)
+	for i := 0; i < 10; i++ {
+		_ = login(provider, "wrong", "10.0.0.1")
+	}
+	if err := login(provider, "secret", "10.0.0.1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLockoutByIdentity(t *testing.T) {
+	config := attempt.Config{Enable: true, MaxAttempts: 3, LockSeconds: 60}
+	provider := newProvider(attempt.NewTracker(config, attempt.NewMemoryStore()))
+
+	// 用户名大小写和首尾空白不同也计入同一个用户
+	for i, username := range []string{"admin", "Admin", " ADMIN "} {
+		if c := code(loginAs(provider, username, "wrong", "10.0.0.1")); c != securityErrors.BadCredentialsCode {
+			t.Fatalf("attempt %d, expected bad credentials, actual=%s", i, c)
+		}
+	}
+	if c := code(loginAs(provider, "aDmIn", "secret", "10.0.0.1")); c != securityErrors.AccountLockCode {
+		t.Fatalf("expected account lock, actual=%s", c)
+	}
+
+	// 存在用户ID时按用户ID记录
+	provider = dao.NewProvider(&password.NoopEncoder{}, idUserService{}, cache.NewNilUserCache(), dao.NewPreChecker(), dao.NewPostChecker(), attempt.NewTracker(config, attempt.NewMemoryStore()))
+	for i, username := range []string{"admin", "admin@example.com", "13800000000"} {
+		if c := code(loginAs(provider, username, "wrong", "10.0.0.1")); c != securityErrors.BadCredentialsCode {
+			t.Fatalf("attempt %d, expected bad credentials, actual=%s", i, c)
+		}
+	}
+	if c := code(loginAs(provider, "admin", "secret", "10.0.0.1")); c != securityErrors.AccountLockCode {
+		t.Fatalf("expected account lock, actual=%s", c)
+	}
+}
+
+func TestIdentity(t *testing.T) {
+	if id := attempt.Identity(userdetails.NewUser(" Admin ", "", nil)); id != "admin" {
+		t.Fatalf("unexpected identity %s", id)
+	}
+	if id := attempt.Identity(idUser{userdetails.NewUser("admin", "", nil)}); id != "id:1" {
+		t.Fatalf("unexpected identity %s", id)
+	}
+}
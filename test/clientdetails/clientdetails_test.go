@@ -0,0 +1,238 @@
+package clientdetails
+
+import (
+	"testing"
+	"time"
+
+	coreErrors "github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
+)
+
+type service struct {
+	clients map[string]*clientdetails.BaseClientDetails
+	secrets map[string]string
+}
+
+func (s *service) LoadClientByClientID(clientID string) (clientdetails.ClientDetails, error) {
+	client, ok := s.clients[clientID]
+	if !ok {
+		return nil, errors.NoSuchClient("No client with requested id: ", clientID)
+	}
+	return client, nil
+}
+
+func (s *service) UpdateClientSecret(clientID string, tenantID types.ID, secret string) error {
+	s.secrets[tenantID.String()+":"+clientID] = secret
+	return nil
+}
+
+type tenantService struct {
+	service
+	tenants map[string]types.ID
+}
+
+func (s *tenantService) LoadClientByClientIDAndTenant(clientID string, tenantID types.ID) (clientdetails.ClientDetails, error) {
+	if s.tenants[clientID] != tenantID {
+		return nil, errors.NoSuchClient("No client with requested id: ", clientID)
+	}
+	return s.LoadClientByClientID(clientID)
+}
+
+func TestCache(t *testing.T) {
+	c := clientdetails.NewCache(time.Minute)
+	c.Put(types.Zero, &clientdetails.BaseClientDetails{ClientID: "web", ClientSecret: "a"})
+
+	client := c.Get("web", types.Zero)
+	if client == nil || client.ClientSecret != "a" {
+		t.Fatalf("expected cached client, got %+v", client)
+	}
+	// 修改返回的客户端不影响缓存
+	client.ClientSecret = "b"
+	if c.Get("web", types.Zero).ClientSecret != "a" {
+		t.Error("cached client was modified")
+	}
+	if c.Get("web", types.ID(1)) != nil {
+		t.Error("expected cache miss for other tenant")
+	}
+
+	c.Evict("web")
+	if c.Get("web", types.Zero) != nil {
+		t.Error("expected client to be evicted")
+	}
+
+	disabled := clientdetails.NewCache(0)
+	disabled.Put(types.Zero, &clientdetails.BaseClientDetails{ClientID: "web"})
+	if disabled.Get("web", types.Zero) != nil {
+		t.Error("expected cache disabled when ttl is 0")
+	}
+
+	var nilCache *clientdetails.Cache
+	nilCache.Put(types.Zero, &clientdetails.BaseClientDetails{ClientID: "web"})
+	nilCache.Evict("web")
+	if nilCache.Get("web", types.Zero) != nil {
+		t.Error("expected nil cache miss")
+	}
+}
+
+func TestUserDetailsService(t *testing.T) {
+	s := &service{
+		clients: map[string]*clientdetails.BaseClientDetails{
+			"web": {ClientID: "web", ClientSecret: "{noop}secret", Authorities: "client"},
+		},
+		secrets: map[string]string{},
+	}
+	userDetailsService := clientdetails.NewClientDetailsUserDetailsService(s)
+
+	user, err := userDetailsService.LoadUserByUsername("web")
+	if err != nil || user == nil {
+		t.Fatalf("expected client user, err=%v", err)
+	}
+	if user.GetPassword() != "{noop}secret" {
+		t.Errorf("unexpected secret %s", user.GetPassword())
+	}
+
+	// 客户端不存在时不返回错误，由认证提供者统一返回认证失败
+	user, err = userDetailsService.LoadUserByUsername("unknown")
+	if err != nil || user != nil {
+		t.Errorf("expected nil user and nil error, got %v %v", user, err)
+	}
+}
+
+func TestLoadClientInTenant(t *testing.T) {
+	s := &tenantService{
+		service: service{
+			clients: map[string]*clientdetails.BaseClientDetails{
+				"web": {ClientID: "web", ClientSecret: "{noop}secret"},
+			},
+		},
+		tenants: map[string]types.ID{"web": types.ID(2)},
+	}
+
+	if _, err := clientdetails.LoadClient(s, "web", "2"); err != nil {
+		t.Errorf("expected client in tenant 2, err=%v", err)
+	}
+	if _, err := clientdetails.LoadClient(s, "web", "3"); err == nil {
+		t.Error("expected no client in tenant 3")
+	}
+	// 未指定租户时按客户端ID加载
+	if _, err := clientdetails.LoadClient(s, "web", ""); err != nil {
+		t.Errorf("expected client without tenant, err=%v", err)
+	}
+
+	userDetailsService := clientdetails.NewClientDetailsUserDetailsService(s)
+	user, err := userDetailsService.LoadUserByUsernameAndDetails("web", &authentication.WebAuthenticationDetails{TenantID: "2"})
+	if err != nil || user == nil {
+		t.Errorf("expected client user in tenant 2, err=%v", err)
+	}
+	user, err = userDetailsService.LoadUserByUsernameAndDetails("web", &authentication.WebAuthenticationDetails{TenantID: "3"})
+	if err != nil || user != nil {
+		t.Errorf("expected nil user in tenant 3, got %v %v", user, err)
+	}
+}
+
+func TestPasswordService(t *testing.T) {
+	s := &service{secrets: map[string]string{}}
+	passwordService := clientdetails.NewPasswordService(s)
+	user := userdetails.NewUser("web", "{noop}secret", nil)
+
+	updated, err := passwordService.UpdatePasswordWithDetails(user, "{bcrypt}hash", &authentication.WebAuthenticationDetails{TenantID: "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.secrets["2:web"] != "{bcrypt}hash" || updated.GetPassword() != "{bcrypt}hash" {
+		t.Errorf("expected secret upgraded in tenant 2, got %v", s.secrets)
+	}
+
+	// 未知租户时不升级密钥，避免更新其他租户中相同ID的客户端
+	s.secrets = map[string]string{}
+	for _, details := range []interface{}{nil, &authentication.WebAuthenticationDetails{}} {
+		updated, err = passwordService.UpdatePasswordWithDetails(user, "{bcrypt}hash", details)
+		if err != nil || updated.GetPassword() != "{noop}secret" {
+			t.Errorf("expected secret not upgraded, got %v %v", updated, err)
+		}
+	}
+	if updated, err = passwordService.UpdatePassword(user, "{bcrypt}hash"); err != nil || updated.GetPassword() != "{noop}secret" {
+		t.Errorf("expected secret not upgraded, got %v %v", updated, err)
+	}
+	if len(s.secrets) != 0 {
+		t.Errorf("expected no secret saved, got %v", s.secrets)
+	}
+}
+
+func TestInMemoryClientDetailsService(t *testing.T) {
+	s := clientdetails.NewInMemoryClientDetailsService([]clientdetails.ClientConfig{
+		{
+			ClientID:                   "web",
+			ClientSecret:               "{noop}secret",
+			Scope:                      []string{"read", "write"},
+			AuthorizedGrantTypes:       []string{"password", "refresh_token"},
+			Authorities:                []string{"client"},
+			AccessTokenValiditySeconds: 3600,
+			AutoApproveScopes:          []string{"read"},
+		},
+		{ClientID: "app", AutoApprove: true},
+	})
+
+	client, err := s.LoadClientByClientID("web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.GetClientSecret() != "{noop}secret" || len(client.GetScope()) != 2 || client.GetAuthorizedGrantTypes()[1] != "refresh_token" {
+		t.Errorf("unexpected client %+v", client)
+	}
+	if client.GetAuthorities()[0].GetAuthority() != "client" || client.GetAccessTokenValiditySeconds() != 3600 {
+		t.Errorf("unexpected client %+v", client)
+	}
+	if !client.IsAutoApprove("read") || client.IsAutoApprove("write") {
+		t.Error("expected only read scope auto approved")
+	}
+
+	app, _ := s.LoadClientByClientID("app")
+	if !app.IsAutoApprove("write") || app.IsScoped() || app.IsSecretRequired() {
+		t.Errorf("unexpected client %+v", app)
+	}
+
+	_, err = s.LoadClientByClientID("unknown")
+	if e, ok := err.(*coreErrors.E); !ok || e.Code != errors.NoSuchClientCode {
+		t.Errorf("expected no such client, got %v", err)
+	}
+}
+
+func TestInMemoryUpdateClientDetails(t *testing.T) {
+	s := clientdetails.NewInMemoryClientDetailsService([]clientdetails.ClientConfig{
+		{
+			ClientID:                   "web",
+			ClientSecret:               "{noop}secret",
+			Scope:                      []string{"read"},
+			Authorities:                []string{"client"},
+			AccessTokenValiditySeconds: 3600,
+		},
+	})
+
+	err := s.UpdateClientDetails(&clientdetails.BaseClientDetails{
+		ClientID:            "web",
+		Scope:               "read,write",
+		Authorities:         "admin",
+		AccessTokenValidity: 60,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, _ := s.LoadClientByClientID("web")
+	if len(client.GetScope()) != 2 {
+		t.Errorf("expected scope updated, got %v", client.GetScope())
+	}
+	// 只更新元数据，密钥、权限和令牌有效时间保持不变
+	if client.GetClientSecret() != "{noop}secret" || client.GetAuthorities()[0].GetAuthority() != "client" || client.GetAccessTokenValiditySeconds() != 3600 {
+		t.Errorf("unexpected client %+v", client)
+	}
+
+	err = s.UpdateClientDetails(&clientdetails.BaseClientDetails{ClientID: "unknown"})
+	if e, ok := err.(*coreErrors.E); !ok || e.Code != errors.NoSuchClientCode {
+		t.Errorf("expected no such client, got %v", err)
+	}
+}
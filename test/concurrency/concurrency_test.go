@@ -0,0 +1,155 @@
+package concurrency
+
+import (
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	securityAuthentication "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/session"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/request"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token/store"
+)
+
+const clientID = "ingot"
+
+var signingKey = []byte("0123456789abcdef0123456789abcdef")
+
+func newTokenServices(control *session.ConcurrentControl) *token.DefaultTokenServices {
+	converter := store.NewJwtAccessTokenConverter(
+		token.NewDefaultAccessTokenConverter(token.NewDefaultUserAuthenticationConverter()),
+		jwt.SigningMethodHS256,
+		signingKey,
+		func(t *jwt.Token) (interface{}, error) {
+			return signingKey, nil
+		},
+	)
+	services := token.NewDefaultTokenServices(store.NewJwtTokenStore(converter))
+	services.SupportRefreshToken = true
+	services.TokenEnhancer = converter
+	services.ClientDetailsService = clientdetails.NewInMemoryClientDetailsService([]clientdetails.ClientConfig{{
+		ClientID:             clientID,
+		Scope:                []string{"read"},
+		AuthorizedGrantTypes: []string{"password", "refresh_token"},
+	}})
+	services.ConcurrentControl = control
+	return services
+}
+
+func newControl(strategy string) *session.ConcurrentControl {
+	return session.NewConcurrentControl(session.Config{
+		Enable:          true,
+		MaximumSessions: 1,
+		Strategy:        strategy,
+	}, session.NewMemoryRegistry())
+}
+
+func newAuthentication() *authentication.OAuth2Authentication {
+	user := userdetails.NewUser("admin", "", nil)
+	userAuth := securityAuthentication.NewAuthenticatedUsernamePasswordAuthToken(user, "", nil)
+	return authentication.NewOAuth2Authentication(request.NewOAuth2Request(nil, clientID, []string{"read"}), userAuth)
+}
+
+type tenantUser struct {
+	userdetails.UserDetails
+	tenantID string
+}
+
+func (u *tenantUser) GetTenantID() string {
+	return u.tenantID
+}
+
+func newTenantAuthentication(tenantID string) *authentication.OAuth2Authentication {
+	user := &tenantUser{UserDetails: userdetails.NewUser("admin", "", nil), tenantID: tenantID}
+	userAuth := securityAuthentication.NewAuthenticatedUsernamePasswordAuthToken(user, "", nil)
+	return authentication.NewOAuth2Authentication(request.NewOAuth2Request(nil, clientID, []string{"read"}), userAuth)
+}
+
+func TestRejectNewSession(t *testing.T) {
+	services := newTokenServices(newControl(session.StrategyRejectNew))
+	if _, err := services.CreateAccessToken(newAuthentication()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := services.CreateAccessToken(newAuthentication()); err == nil {
+		t.Error("expected maximum sessions exceeded")
+	}
+}
+
+func TestRefreshReplacesSession(t *testing.T) {
+	control := newControl(session.StrategyRejectNew)
+	services := newTokenServices(control)
+	accessToken, err := services.CreateAccessToken(newAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refreshed, err := services.RefreshAccessToken(accessToken.GetRefreshToken().GetRefreshTokenValue(),
+		request.NewTokenRequest(nil, clientID, nil, "refresh_token"))
+	if err != nil {
+		t.Fatalf("expected refresh to replace the old session, err = %v", err)
+	}
+	sessions, err := control.Registry.GetAllSessions("admin", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(sessions))
+	}
+
+	if _, err := services.LoadAuthentication(refreshed.GetValue()); err != nil {
+		t.Errorf("expected refreshed token to be valid, err = %v", err)
+	}
+}
+
+func TestRevokeRemovesSession(t *testing.T) {
+	control := newControl(session.StrategyRejectNew)
+	services := newTokenServices(control)
+	accessToken, err := services.CreateAccessToken(newAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+	services.RevokeToken(accessToken.GetValue())
+	if _, err := services.CreateAccessToken(newAuthentication()); err != nil {
+		t.Errorf("expected new session after revoke, err = %v", err)
+	}
+}
+
+func TestExpireOldestSession(t *testing.T) {
+	services := newTokenServices(newControl(session.StrategyExpireOldest))
+	first, err := services.CreateAccessToken(newAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := services.CreateAccessToken(newAuthentication()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := services.LoadAuthentication(first.GetValue()); err == nil {
+		t.Error("expected oldest token to be expired")
+	}
+}
+
+func TestSessionsPerTenant(t *testing.T) {
+	control := newControl(session.StrategyExpireOldest)
+	services := newTokenServices(control)
+	first, err := services.CreateAccessToken(newTenantAuthentication("1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 其他租户中相同用户名的用户登录不影响该用户的会话
+	if _, err := services.CreateAccessToken(newTenantAuthentication("2")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := services.LoadAuthentication(first.GetValue()); err != nil {
+		t.Errorf("expected token of tenant 1 to be valid, err = %v", err)
+	}
+	sessions, err := control.Registry.GetAllSessions("1:admin", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 {
+		t.Errorf("expected 1 session in tenant 1, got %d", len(sessions))
+	}
+}
@@ -0,0 +1,109 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/cors"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+type chain struct {
+	called bool
+}
+
+func (c *chain) DoFilter(*ingot.Context) error {
+	c.called = true
+	return nil
+}
+
+func newContext(method, origin string, header map[string]string) (*ingot.Context, *httptest.ResponseRecorder) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(method, "http://api.ingot.cloud/api/user", nil)
+	ctx.Request.Header.Set(cors.HeaderOrigin, origin)
+	for k, v := range header {
+		ctx.Request.Header.Set(k, v)
+	}
+	return ingot.NewContext(ctx), recorder
+}
+
+func newSource(configuration cors.Configuration) *cors.URLBasedConfigurationSource {
+	source := cors.NewURLBasedConfigurationSource()
+	source.RegisterCorsConfiguration("/api/**", &configuration)
+	return source
+}
+
+func TestCredentialsWithWildcardOrigin(t *testing.T) {
+	invalid := []cors.Configuration{
+		{AllowedOrigins: []string{cors.All}, AllowCredentials: true},
+		{AllowedOriginPatterns: []string{"*"}, AllowCredentials: true},
+	}
+	for _, configuration := range invalid {
+		if err := newSource(configuration).Validate(); err == nil {
+			t.Errorf("expected invalid configuration %+v", configuration)
+		}
+	}
+	// 携带凭证时 * 来源无效，不会反射请求来源
+	credentials := cors.Configuration{AllowedOrigins: []string{cors.All}, AllowCredentials: true}
+	if credentials.CheckOrigin("https://evil.example.com") != "" {
+		t.Error("expected origin rejected")
+	}
+
+	valid := cors.Configuration{AllowedOriginPatterns: []string{"https://*.ingot.cloud"}, AllowCredentials: true}
+	if err := newSource(valid).Validate(); err != nil {
+		t.Errorf("expected valid configuration, got %v", err)
+	}
+	if valid.CheckOrigin("https://app.ingot.cloud") != "https://app.ingot.cloud" {
+		t.Error("expected pattern origin allowed")
+	}
+	wildcard := cors.Configuration{AllowedOrigins: []string{cors.All}}
+	if wildcard.CheckOrigin("https://app.ingot.cloud") != cors.All {
+		t.Error("expected * without credentials")
+	}
+}
+
+func TestPreFlightRequest(t *testing.T) {
+	filter := cors.NewFilter(newSource(cors.Configuration{
+		AllowedOrigins:   []string{"https://app.ingot.cloud"},
+		AllowedMethods:   []string{http.MethodGet, http.MethodPut},
+		AllowedHeaders:   []string{"Authorization"},
+		AllowCredentials: true,
+		MaxAge:           1800,
+	}))
+
+	ctx, recorder := newContext(http.MethodOptions, "https://app.ingot.cloud", map[string]string{
+		cors.HeaderAccessControlRequestMethod:  http.MethodPut,
+		cors.HeaderAccessControlRequestHeaders: "authorization",
+	})
+	next := &chain{}
+	if err := filter.DoFilter(ctx, next); err != nil {
+		t.Fatal(err)
+	}
+	if next.called {
+		t.Error("pre-flight request should not continue the chain")
+	}
+	header := recorder.Header()
+	if header.Get(cors.HeaderAccessControlAllowOrigin) != "https://app.ingot.cloud" || header.Get(cors.HeaderAccessControlAllowCredentials) != "true" {
+		t.Errorf("unexpected headers %v", header)
+	}
+
+	ctx, _ = newContext(http.MethodOptions, "https://evil.example.com", map[string]string{
+		cors.HeaderAccessControlRequestMethod: http.MethodPut,
+	})
+	if err := filter.DoFilter(ctx, &chain{}); err == nil {
+		t.Error("expected forbidden origin rejected")
+	}
+
+	ctx, _ = newContext(http.MethodGet, "https://app.ingot.cloud", nil)
+	next = &chain{}
+	if err := filter.DoFilter(ctx, next); err != nil || !next.called {
+		t.Errorf("expected simple request to continue, err=%v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package csrf
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/csrf"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/session"
+)
+
+const headerName = "X-CSRF-TOKEN"
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+type chain struct {
+	called bool
+}
+
+func (c *chain) DoFilter(ctx *ingot.Context) error {
+	c.called = true
+	return nil
+}
+
+func newFilter() *csrf.Filter {
+	store := session.NewRepositoryStore(session.NewMemoryRepository(), session.Config{Timeout: 60})
+	return csrf.NewFilter(csrf.NewSessionTokenRepository(csrf.Config{HeaderName: headerName}, store))
+}
+
+func newContext(method string, header string, cookies ...*http.Cookie) (*ingot.Context, *httptest.ResponseRecorder) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(method, "http://ingot.cloud/", nil)
+	if header != "" {
+		ctx.Request.Header.Set(headerName, header)
+	}
+	for _, cookie := range cookies {
+		ctx.Request.AddCookie(cookie)
+	}
+	return ingot.NewContext(ctx), recorder
+}
+
+func TestAnonymousGetCreatesNoSession(t *testing.T) {
+	f := newFilter()
+	ctx, recorder := newContext(http.MethodGet, "")
+	c := &chain{}
+	if err := f.DoFilter(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+	if !c.called {
+		t.Fatal("expected chain to be called")
+	}
+	if len(recorder.Result().Cookies()) != 0 {
+		t.Error("expected no session cookie")
+	}
+	if recorder.Header().Get(headerName) != "" {
+		t.Error("expected no token header")
+	}
+}
+
+func TestFetchAndSubmitToken(t *testing.T) {
+	f := newFilter()
+	ctx, recorder := newContext(http.MethodGet, csrf.FetchTokenValue)
+	if err := f.DoFilter(ctx, &chain{}); err != nil {
+		t.Fatal(err)
+	}
+	token := recorder.Header().Get(headerName)
+	cookies := recorder.Result().Cookies()
+	if token == "" || len(cookies) == 0 {
+		t.Fatal("expected token and session cookie")
+	}
+
+	ctx, _ = newContext(http.MethodPost, "", cookies...)
+	c := &chain{}
+	if err := f.DoFilter(ctx, c); err == nil || c.called {
+		t.Error("expected request without token to be rejected")
+	}
+
+	ctx, _ = newContext(http.MethodPost, "invalid", cookies...)
+	if err := f.DoFilter(ctx, &chain{}); err == nil {
+		t.Error("expected request with invalid token to be rejected")
+	}
+
+	ctx, _ = newContext(http.MethodPost, token, cookies...)
+	c = &chain{}
+	if err := f.DoFilter(ctx, c); err != nil || !c.called {
+		t.Errorf("expected valid token to pass, err = %v", err)
+	}
+}
+
+func TestGetTokenIssuesToken(t *testing.T) {
+	f := newFilter()
+	ctx, recorder := newContext(http.MethodGet, "")
+	if err := f.DoFilter(ctx, &chain{}); err != nil {
+		t.Fatal(err)
+	}
+	token := csrf.GetToken(ctx.Context)
+	if token == nil || recorder.Header().Get(headerName) != token.Token {
+		t.Fatal("expected token to be issued")
+	}
+	if len(recorder.Result().Cookies()) == 0 {
+		t.Error("expected session cookie")
+	}
+}
+
+func TestPostWithoutSessionRejected(t *testing.T) {
+	f := newFilter()
+	ctx, recorder := newContext(http.MethodPost, "")
+	if err := f.DoFilter(ctx, &chain{}); err == nil {
+		t.Fatal("expected request to be rejected")
+	}
+	if len(recorder.Result().Cookies()) != 0 {
+		t.Error("expected no session cookie")
+	}
+}
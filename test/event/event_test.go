@@ -0,0 +1,132 @@
+package event
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/dao"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/attempt"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/event"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails/cache"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/crypto/password"
+	oauth2Authentication "github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/errors"
+)
+
+const bearerToken = "secret-bearer-token"
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+type userService struct{}
+
+func (userService) LoadUserByUsername(username string) (userdetails.UserDetails, error) {
+	return userdetails.NewUser(username, "secret", nil), nil
+}
+
+type providers struct {
+	provider authentication.Provider
+}
+
+func (p *providers) Add(authentication.Provider) {}
+
+func (p *providers) Get() []authentication.Provider {
+	return []authentication.Provider{p.provider}
+}
+
+type recorder struct {
+	events []*event.Event
+}
+
+func (r *recorder) OnEvent(e *event.Event) {
+	r.events = append(r.events, e)
+}
+
+func TestProviderManagerEvents(t *testing.T) {
+	config := attempt.Config{Enable: true, MaxAttempts: 1}
+	provider := dao.NewProvider(&password.NoopEncoder{}, userService{}, cache.NewNilUserCache(), dao.NewPreChecker(), dao.NewPostChecker(), attempt.NewTracker(config, attempt.NewMemoryStore()))
+	listener := &recorder{}
+	manager := authentication.NewProviderManager(&providers{provider: provider}, event.NewPublisher(listener))
+
+	login := func(pwd string) {
+		token := authentication.NewUnauthenticatedUsernamePasswordAuthToken("admin", pwd)
+		token.SetDetails(map[string]string{
+			event.ParameterClientID:      "web",
+			event.ParameterRemoteAddress: "10.0.0.1",
+			event.ParameterTraceID:       "trace",
+		})
+		_, _ = manager.Authenticate(token)
+	}
+	login("wrong")
+	login("secret")
+
+	expected := []event.Type{event.BadCredentials, event.AccountLocked}
+	if len(listener.events) != len(expected) {
+		t.Fatalf("expected %d events, actual=%d", len(expected), len(listener.events))
+	}
+	for i, e := range listener.events {
+		if e.Type != expected[i] {
+			t.Errorf("event %d, expected=%s, actual=%s", i, expected[i], e.Type)
+		}
+		if e.Principal != "admin" || e.ClientID != "web" || e.RemoteAddress != "10.0.0.1" || e.TraceID != "trace" {
+			t.Errorf("event %d, unexpected audit fields %+v", i, e)
+		}
+	}
+}
+
+type chain struct{}
+
+func (*chain) DoFilter(ctx *ingot.Context) error {
+	return nil
+}
+
+type manager struct {
+	err error
+}
+
+func (m *manager) Authenticate(auth core.Authentication) (core.Authentication, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return authentication.NewAuthenticatedUsernamePasswordAuthToken("admin", "", nil), nil
+}
+
+func TestOAuth2ProcessingFilterEvents(t *testing.T) {
+	doFilter := func(err error) *event.Event {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodGet, "http://ingot.cloud/", nil)
+		ctx.Request.Header.Set("Authorization", "Bearer "+bearerToken)
+		listener := &recorder{}
+		filter := oauth2Authentication.NewOAuth2ProcessingFilter(oauth2Authentication.NewBearerTokenExtractor(), &manager{err: err}, event.NewPublisher(listener))
+		_ = filter.DoFilter(ingot.NewContext(ctx), &chain{})
+		if len(listener.events) != 1 {
+			t.Fatalf("expected 1 event, actual=%d", len(listener.events))
+		}
+		return listener.events[0]
+	}
+
+	e := doFilter(errors.InvalidToken("Invalid access token"))
+	if e.Type != event.AuthenticationFailure {
+		t.Errorf("expected=%s, actual=%s", event.AuthenticationFailure, e.Type)
+	}
+	// 失败事件不包含令牌原文
+	if e.Authentication != nil || strings.Contains(e.Principal, bearerToken) {
+		t.Errorf("failure event contains bearer token %+v", e)
+	}
+	if e.Principal != event.TokenFingerprint(bearerToken) || e.RemoteAddress == "" {
+		t.Errorf("unexpected audit fields %+v", e)
+	}
+
+	e = doFilter(nil)
+	if e.Type != event.TokenAuthenticated || e.Principal != "admin" {
+		t.Errorf("unexpected success event %+v", e)
+	}
+}
@@ -0,0 +1,36 @@
+package filterchain
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/builders"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/utils"
+)
+
+func TestShadowedFilterChain(t *testing.T) {
+	cases := map[string]utils.RequestMatcher{
+		"any":   utils.AnyRequestMatcher,
+		"ant":   utils.NewAntPathRequestMatcher("/**"),
+		"regex": utils.NewRegexRequestMatcher(".*"),
+		"or":    utils.NewAntPathRequestMatchers("/public/**", "/**"),
+	}
+	for name, catchAll := range cases {
+		web := builders.NewWebSecurity()
+		web.AddIgnoreRequestMatcher(catchAll)
+		web.AddIgnoreRequestMatcher(utils.NewAntPathRequestMatcher("/api/**"))
+		if _, err := web.Build(); err == nil {
+			t.Errorf("%s: expected shadowed filter chain error", name)
+		}
+	}
+}
+
+func TestFilterChainOrder(t *testing.T) {
+	web := builders.NewWebSecurity()
+	web.AddIgnoreRequestMatcher(utils.NewAntPathRequestMatcher("/**", http.MethodOptions))
+	web.AddIgnoreRequestMatcher(utils.NewAntPathRequestMatcher("/public/*"))
+	web.AddIgnoreRequestMatcher(utils.NewAntPathRequestMatcher("/**"))
+	if _, err := web.Build(); err != nil {
+		t.Errorf("expected catch-all chain allowed at last position, got %v", err)
+	}
+}
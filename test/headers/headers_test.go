@@ -0,0 +1,69 @@
+package headers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/headers"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+type chain struct{}
+
+func (chain) DoFilter(*ingot.Context) error {
+	return nil
+}
+
+func doFilter(t *testing.T, config headers.Config, target string) http.Header {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	if err := headers.NewFilter(config).DoFilter(ingot.NewContext(ctx), chain{}); err != nil {
+		t.Fatal(err)
+	}
+	return recorder.Header()
+}
+
+func TestDefaultHeaders(t *testing.T) {
+	header := doFilter(t, headers.DefaultConfig(), "https://ingot.cloud/api")
+	if header.Get(headers.HeaderContentTypeOptions) != "nosniff" || header.Get(headers.HeaderFrameOptions) != "DENY" {
+		t.Errorf("unexpected headers %v", header)
+	}
+	if header.Get(headers.HeaderStrictTransportSecurity) == "" {
+		t.Error("expected hsts on https request")
+	}
+	if header.Get(headers.HeaderCacheControl) != "" {
+		t.Error("cache control should not be written by default")
+	}
+
+	header = doFilter(t, headers.DefaultConfig(), "http://ingot.cloud/api")
+	if header.Get(headers.HeaderStrictTransportSecurity) != "" {
+		t.Error("hsts should only be written on https request")
+	}
+}
+
+func TestWithCacheControl(t *testing.T) {
+	disabled := headers.DefaultConfig()
+	disabled.Enable = false
+	config := disabled.WithCacheControl()
+	header := doFilter(t, config, "https://ingot.cloud/oauth/token")
+	if header.Get(headers.HeaderCacheControl) != "no-store" || header.Get(headers.HeaderPragma) != "no-cache" {
+		t.Errorf("expected cache control, got %v", header)
+	}
+	for _, name := range []string{headers.HeaderContentTypeOptions, headers.HeaderFrameOptions, headers.HeaderStrictTransportSecurity, headers.HeaderReferrerPolicy} {
+		if header.Get(name) != "" {
+			t.Errorf("%s should stay disabled", name)
+		}
+	}
+
+	header = doFilter(t, headers.DefaultConfig().WithCacheControl(), "https://ingot.cloud/oauth/token")
+	if header.Get(headers.HeaderCacheControl) != "no-store" || header.Get(headers.HeaderFrameOptions) != "DENY" {
+		t.Errorf("expected configured headers kept, got %v", header)
+	}
+}
@@ -0,0 +1,108 @@
+package matcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/utils"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func newContext(method, target string, header map[string]string) *ingot.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(method, target, nil)
+	for k, v := range header {
+		ctx.Request.Header.Set(k, v)
+	}
+	return ingot.NewContext(ctx)
+}
+
+func TestAntPathRequestMatcher(t *testing.T) {
+	matcher := utils.NewAntPathRequestMatcher("/oauth/**", http.MethodPost)
+
+	if !matcher.Matches(newContext(http.MethodPost, "/oauth/token?grant_type=password", nil)) {
+		t.Error("query string should be ignored")
+	}
+	if matcher.Matches(newContext(http.MethodGet, "/oauth/token", nil)) {
+		t.Error("method should not match")
+	}
+	if matcher.Matches(newContext(http.MethodPost, "/api/user", nil)) {
+		t.Error("path should not match")
+	}
+	t.Log(matcher)
+}
+
+func TestRegexRequestMatcher(t *testing.T) {
+	matcher := utils.NewRegexRequestMatcher(`^/api/v\d+/user$`)
+	if !matcher.Matches(newContext(http.MethodGet, "/api/v1/user?id=1", nil)) {
+		t.Error("regex should match")
+	}
+	if matcher.Matches(newContext(http.MethodGet, "/api/v1/user/1", nil)) {
+		t.Error("regex should not match")
+	}
+}
+
+func TestRegexRequestMatcherAnchored(t *testing.T) {
+	matcher := utils.NewRegexRequestMatcher(`/admin`)
+	if !matcher.Matches(newContext(http.MethodGet, "/admin", nil)) {
+		t.Error("regex should match whole path")
+	}
+	for _, path := range []string{"/public/x/admin", "/admin-anything", "/admin/user"} {
+		if matcher.Matches(newContext(http.MethodGet, path, nil)) {
+			t.Errorf("regex should not match %s", path)
+		}
+	}
+}
+
+func TestHeaderAndParameterRequestMatcher(t *testing.T) {
+	header := utils.NewHeaderRequestMatcher("X-Requested-With", "XMLHttpRequest")
+	param := utils.NewParameterRequestMatcher("debug")
+
+	ctx := newContext(http.MethodGet, "/a?debug=1", map[string]string{"X-Requested-With": "XMLHttpRequest"})
+	if !header.Matches(ctx) || !param.Matches(ctx) {
+		t.Error("header and parameter should match")
+	}
+	ctx = newContext(http.MethodGet, "/a", nil)
+	if header.Matches(ctx) || param.Matches(ctx) {
+		t.Error("header and parameter should not match")
+	}
+}
+
+func TestMediaTypeRequestMatcher(t *testing.T) {
+	matcher := utils.NewMediaTypeRequestMatcher("application/json")
+	if !matcher.Matches(newContext(http.MethodGet, "/", map[string]string{"Accept": "text/html, application/*;q=0.8"})) {
+		t.Error("wildcard subtype should match")
+	}
+	if matcher.Matches(newContext(http.MethodGet, "/", map[string]string{"Accept": "text/html"})) {
+		t.Error("text/html should not match")
+	}
+	if !matcher.Matches(newContext(http.MethodGet, "/", nil)) {
+		t.Error("missing accept should match")
+	}
+}
+
+func TestCompositeRequestMatcher(t *testing.T) {
+	token := utils.NewAntPathRequestMatcher("/oauth/token")
+	api := utils.NewAntPathRequestMatcher("/api/**")
+	ctx := newContext(http.MethodGet, "/api/user", nil)
+
+	if !utils.Or(token, api).Matches(ctx) {
+		t.Error("or should match")
+	}
+	if utils.And(token, api).Matches(ctx) {
+		t.Error("and should not match")
+	}
+	if !utils.Not(token).Matches(ctx) {
+		t.Error("not should match")
+	}
+	if utils.Or().Matches(ctx) {
+		t.Error("empty or should not match")
+	}
+	t.Log(utils.Not(utils.Or(token, api)))
+}
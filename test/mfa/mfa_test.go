@@ -0,0 +1,168 @@
+package mfa
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/attempt"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/mfa"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	securityErrors "github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
+)
+
+// RFC 6238 附录 B 测试向量，取后 6 位
+func TestGenerateCode(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	cases := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1111111111: "050471",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, expected := range cases {
+		code, err := mfa.GenerateCode(secret, time.Unix(unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != expected {
+			t.Errorf("time=%d, expected=%s, actual=%s", unix, expected, code)
+		}
+	}
+}
+
+func TestValidateCode(t *testing.T) {
+	secret, err := mfa.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	previous, _ := mfa.GenerateCode(secret, now.Add(-mfa.Period*time.Second))
+	if !mfa.ValidateCode(secret, previous, now) {
+		t.Error("code of previous period should be valid")
+	}
+	expired, _ := mfa.GenerateCode(secret, now.Add(-3*mfa.Period*time.Second))
+	if mfa.ValidateCode(secret, expired, now) {
+		t.Error("code of expired period should be invalid")
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := mfa.GenerateRecoveryCodes(mfa.RecoveryCodeCount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hashed []string
+	for _, code := range codes {
+		hashed = append(hashed, mfa.HashRecoveryCode(code))
+	}
+	if index := mfa.MatchRecoveryCode(codes[3], hashed); index != 3 {
+		t.Errorf("expected=3, actual=%d", index)
+	}
+	if index := mfa.MatchRecoveryCode("00000-00000", hashed); index != -1 {
+		t.Errorf("expected=-1, actual=%d", index)
+	}
+}
+
+type service struct {
+	code string
+}
+
+func (s *service) IsRequired(user userdetails.UserDetails) (string, bool, error) {
+	return "id-" + user.GetUsername(), user.GetUsername() == "admin", nil
+}
+
+func (s *service) Verify(subject string, code string) (bool, error) {
+	return subject == "id-admin" && code == s.code, nil
+}
+
+func newAuthentication(username string) core.Authentication {
+	return authentication.NewAuthenticatedUsernamePasswordAuthToken(userdetails.NewUser(username, "", nil), "", nil)
+}
+
+func challenge(t *testing.T, manager *mfa.Manager) string {
+	e := errors.Unpack(manager.Challenge(newAuthentication("admin"), "web"))
+	if e.Code != securityErrors.MfaRequiredCode {
+		t.Fatalf("expected mfa_required, actual=%s", e.Code)
+	}
+	return e.Data.(map[string]string)["mfa_token"]
+}
+
+func TestManager(t *testing.T) {
+	config := mfa.Config{Enable: true, MaxAttempts: 2}
+	manager := mfa.NewManager(config, &service{code: "123456"}, mfa.NewMemoryStore())
+
+	if err := manager.Challenge(newAuthentication("guest"), "web"); err != nil {
+		t.Fatal(err)
+	}
+
+	token := challenge(t, manager)
+	if _, err := manager.Verify(token, "other", "123456", ""); err == nil {
+		t.Error("mfa_token of other client should be invalid")
+	}
+	verified, err := manager.Verify(token, "web", "123456", "")
+	if err != nil || verified.Username != "admin" {
+		t.Fatalf("challenge=%+v, err=%v", verified, err)
+	}
+	if _, err := manager.Verify(token, "web", "123456", ""); err == nil {
+		t.Error("mfa_token should be used only once")
+	}
+
+	// 超出最大尝试次数后 mfa_token 失效
+	token = challenge(t, manager)
+	for i := 0; i < config.MaxAttempts; i++ {
+		if _, err := manager.Verify(token, "web", "000000", ""); err == nil {
+			t.Fatal("wrong code should be rejected")
+		}
+	}
+	if _, err := manager.Verify(token, "web", "123456", ""); err == nil {
+		t.Error("mfa_token should be removed after too many attempts")
+	}
+}
+
+func TestManagerLockout(t *testing.T) {
+	const remoteAddress = "127.0.0.1"
+	manager := mfa.NewManager(mfa.Config{Enable: true, MaxAttempts: 2}, &service{code: "123456"}, mfa.NewMemoryStore())
+	tracker := attempt.NewTracker(attempt.Config{Enable: true, MaxAttempts: 3}, attempt.NewMemoryStore())
+	manager.LoginAttemptTracker = tracker
+
+	// 每个 mfa_token 失败一次后重新登录，密码登录成功不清除多因素认证的失败记录
+	for i := 0; i < 3; i++ {
+		if err := tracker.LoginSucceeded("admin", remoteAddress); err != nil {
+			t.Fatal(err)
+		}
+		token := challenge(t, manager)
+		if _, err := manager.Verify(token, "web", "000000", remoteAddress); err == nil {
+			t.Fatal("wrong code should be rejected")
+		}
+	}
+	locked, err := tracker.IsLocked("admin", remoteAddress)
+	if err != nil || !locked {
+		t.Fatalf("expected user to be locked, err=%v", err)
+	}
+	token := challenge(t, manager)
+	if _, err := manager.Verify(token, "web", "123456", remoteAddress); err == nil {
+		t.Error("locked user should be rejected")
+	}
+}
+
+func TestMatchCodeStep(t *testing.T) {
+	secret, err := mfa.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	code, _ := mfa.GenerateCode(secret, now)
+	step, ok := mfa.MatchCode(secret, code, now)
+	if !ok || step != now.Unix()/mfa.Period {
+		t.Errorf("unexpected step %d", step)
+	}
+	previous, _ := mfa.GenerateCode(secret, now.Add(-mfa.Period*time.Second))
+	if previousStep, ok := mfa.MatchCode(secret, previous, now); !ok || previousStep >= step {
+		t.Errorf("expected previous step, actual=%d", previousStep)
+	}
+}
@@ -0,0 +1,129 @@
+package mtls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	securityAuthentication "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/preauth"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	oauth2Authentication "github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/mtls"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/request"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/token/store"
+)
+
+var signingKey = []byte("0123456789abcdef0123456789abcdef")
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func newCertificate(t *testing.T, cn string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func newTokenServices() *token.DefaultTokenServices {
+	converter := store.NewJwtAccessTokenConverter(
+		token.NewDefaultAccessTokenConverter(token.NewDefaultUserAuthenticationConverter()),
+		jwt.SigningMethodHS256,
+		signingKey,
+		func(t *jwt.Token) (interface{}, error) {
+			return signingKey, nil
+		},
+	)
+	chain := token.NewEnhancerChain()
+	chain.SetTokenEnhancers([]token.Enhancer{mtls.NewCertificateBoundEnhancer(), converter})
+	services := token.NewDefaultTokenServices(store.NewJwtTokenStore(converter))
+	services.TokenEnhancer = chain
+	return services
+}
+
+func issueToken(t *testing.T, services *token.DefaultTokenServices, thumbprint string) string {
+	params := map[string]string{}
+	if thumbprint != "" {
+		params[mtls.RequestParameterThumbprint] = thumbprint
+	}
+	user := securityAuthentication.NewAuthenticatedUsernamePasswordAuthToken(userdetails.NewUser("admin", "", nil), "", nil)
+	accessToken, err := services.CreateAccessToken(authentication.NewOAuth2Authentication(request.NewOAuth2Request(params, "ingot", []string{"read"}), user))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return accessToken.GetValue()
+}
+
+func authenticate(manager *oauth2Authentication.OAuth2AuthenticationManager, tokenValue string, cert *x509.Certificate) error {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "https://ingot.cloud/", nil)
+	if cert != nil {
+		ctx.Request.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	}
+	auth := preauth.NewAuthenticationToken(tokenValue, "", nil)
+	auth.SetDetails(oauth2Authentication.NewOAuth2AuthenticationDetails(ingot.NewContext(ctx), tokenValue))
+	_, err := manager.Authenticate(auth)
+	return err
+}
+
+func TestCertificateBoundToken(t *testing.T) {
+	services := newTokenServices()
+	manager := oauth2Authentication.NewOAuth2AuthenticationManager(services)
+	cert := newCertificate(t, "client")
+	other := newCertificate(t, "other")
+
+	bound := issueToken(t, services, mtls.Thumbprint(cert))
+	if err := authenticate(manager, bound, cert); err != nil {
+		t.Errorf("expected bound certificate to be accepted, err = %v", err)
+	}
+	if err := authenticate(manager, bound, other); err == nil {
+		t.Error("expected other certificate to be rejected")
+	}
+	if err := authenticate(manager, bound, nil); err == nil {
+		t.Error("expected missing certificate to be rejected")
+	}
+
+	unbound := issueToken(t, services, "")
+	if err := authenticate(manager, unbound, nil); err != nil {
+		t.Errorf("expected unbound token to be accepted, err = %v", err)
+	}
+}
+
+func TestRegisteredThumbprints(t *testing.T) {
+	thumbprints := mtls.RegisteredThumbprints(map[string]interface{}{
+		mtls.TLSClientCertificateThumbprints: "a,b",
+	})
+	if len(thumbprints) != 2 || thumbprints[0] != "a" || thumbprints[1] != "b" {
+		t.Errorf("unexpected thumbprints %v", thumbprints)
+	}
+}
@@ -0,0 +1,117 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ingot-cloud/ingot-go/internal/app/config"
+	"github.com/ingot-cloud/ingot-go/internal/app/container/provider"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/crypto/factory"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/crypto/password"
+)
+
+func encoders() map[string]password.Encoder {
+	return map[string]password.Encoder{
+		"bcrypt": password.NewBcryptEncoder(4),
+		"pbkdf2": password.NewPbkdf2Encoder(1000),
+		"scrypt": password.NewScryptEncoder(1024, 8, 1),
+		"argon2": password.NewArgon2idEncoder(1024, 1, 1),
+	}
+}
+
+func TestEncoders(t *testing.T) {
+	for name, encoder := range encoders() {
+		encoded, err := encoder.Encode("secret")
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		again, _ := encoder.Encode("secret")
+		if encoded == again {
+			t.Errorf("%s: encoded password is not salted", name)
+		}
+		if ok, err := encoder.Matches("secret", encoded); !ok || err != nil {
+			t.Errorf("%s: expected match, err=%v", name, err)
+		}
+		if ok, _ := encoder.Matches("wrong", encoded); ok {
+			t.Errorf("%s: wrong password matched", name)
+		}
+		if ok, _ := encoder.Matches("secret", "malformed"); ok {
+			t.Errorf("%s: malformed password matched", name)
+		}
+	}
+}
+
+func TestEncodedFormat(t *testing.T) {
+	prefixes := map[string]string{
+		"bcrypt": "$2a$04$",
+		"pbkdf2": "$pbkdf2-sha256$i=1000$",
+		"scrypt": "$scrypt$n=1024,r=8,p=1$",
+		"argon2": "$argon2id$v=19$m=1024,t=1,p=1$",
+	}
+	for name, encoder := range encoders() {
+		encoded, _ := encoder.Encode("secret")
+		if !strings.HasPrefix(encoded, prefixes[name]) {
+			t.Errorf("%s: unexpected format %s", name, encoded)
+		}
+	}
+}
+
+func TestDelegatingEncoder(t *testing.T) {
+	encoder := factory.CreateDelegatingPasswordEncoderWithConfig(factory.Config{
+		EncodeID: factory.EncoderPbkdf2,
+		Pbkdf2:   factory.Pbkdf2Config{Iterations: 1000},
+	})
+	encoded, err := encoder.Encode("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(encoded, "{pbkdf2}$pbkdf2-sha256$") {
+		t.Errorf("unexpected encoded password %s", encoded)
+	}
+	if ok, _ := encoder.Matches("secret", encoded); !ok {
+		t.Error("expected match")
+	}
+	// 已存储的旧格式密码仍然可以验证
+	if ok, _ := encoder.Matches("admin", "{noop}admin"); !ok {
+		t.Error("expected legacy noop match")
+	}
+}
+
+func TestDefaultEncodeID(t *testing.T) {
+	encoded, err := factory.CreateDelegatingPasswordEncoder().Encode("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(encoded, "{bcrypt}$2a$") {
+		t.Errorf("unexpected encoded password %s", encoded)
+	}
+}
+
+func TestUpgradeEncoding(t *testing.T) {
+	encoder := factory.CreateDelegatingPasswordEncoderWithConfig(factory.Config{
+		Bcrypt: factory.BcryptConfig{Cost: 5},
+	})
+	current, _ := encoder.Encode("secret")
+	if encoder.UpgradeEncoding(current) {
+		t.Error("current encoding should not be upgraded")
+	}
+	if !encoder.UpgradeEncoding("{noop}secret") {
+		t.Error("noop encoding should be upgraded")
+	}
+	weaker, _ := password.NewBcryptEncoder(4).Encode("secret")
+	if !encoder.UpgradeEncoding("{bcrypt}" + weaker) {
+		t.Error("lower bcrypt cost should be upgraded")
+	}
+}
+
+func TestScryptConfig(t *testing.T) {
+	// 使用与应用相同的方式加载配置文件，确认 scrypt 参数没有被解析为布尔值
+	c, err := provider.NewConfig(&config.Options{ConfigFile: "../../configs/config.yml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	scrypt := c.Security.PasswordEncoder.Scrypt
+	if scrypt.CPUCost != 32768 || scrypt.BlockSize != 8 || scrypt.Parallelism != 1 {
+		t.Fatalf("unexpected scrypt config %+v", scrypt)
+	}
+}
@@ -0,0 +1,213 @@
+package preauth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	authPreauth "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/preauth"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/dao"
+	preauthProvider "github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/preauth"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/preauth"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+type manager struct {
+	principal interface{}
+}
+
+func (m *manager) Authenticate(auth core.Authentication) (core.Authentication, error) {
+	m.principal = auth.GetPrincipal()
+	auth.SetAuthenticated(true)
+	return auth, nil
+}
+
+type chain struct {
+}
+
+func (*chain) DoFilter(ctx *ingot.Context) error {
+	return nil
+}
+
+func newContext(remoteAddr string, header string) *ingot.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://ingot.cloud/", nil)
+	ctx.Request.RemoteAddr = remoteAddr
+	if header != "" {
+		ctx.Request.Header.Set(preauth.DefaultPrincipalHeader, header)
+		// 伪造的转发地址不影响可信代理判断
+		ctx.Request.Header.Set("X-Forwarded-For", "10.0.0.1")
+	}
+	return ingot.NewContext(ctx)
+}
+
+func TestHeaderFilter(t *testing.T) {
+	extractor, err := preauth.NewHeaderPrincipalExtractor(preauth.HeaderConfig{TrustedProxies: []string{"10.0.0.0/8"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &manager{}
+	f := preauth.NewRequestHeaderFilter(extractor, m)
+
+	ctx := newContext("10.1.2.3:1234", "admin")
+	if err := f.DoFilter(ctx, &chain{}); err != nil {
+		t.Fatal(err)
+	}
+	if m.principal != "admin" || ctx.GetAuthentication() == nil {
+		t.Error("expected header from trusted proxy to authenticate")
+	}
+
+	m = &manager{}
+	f = preauth.NewRequestHeaderFilter(extractor, m)
+	ctx = newContext("192.168.1.1:1234", "admin")
+	if err := f.DoFilter(ctx, &chain{}); err != nil {
+		t.Fatal(err)
+	}
+	if m.principal != nil || ctx.GetAuthentication() != nil {
+		t.Error("expected header from untrusted address to be ignored")
+	}
+}
+
+func TestHeaderExtractorInvalidProxy(t *testing.T) {
+	if _, err := preauth.NewHeaderPrincipalExtractor(preauth.HeaderConfig{TrustedProxies: []string{"invalid"}}); err == nil {
+		t.Error("expected invalid trusted proxy to be rejected")
+	}
+}
+
+func newX509Context(subject pkix.Name, verified bool) *ingot.Context {
+	ctx := newContext("192.168.1.1:1234", "")
+	cert := &x509.Certificate{Subject: subject, SerialNumber: big.NewInt(1)}
+	state := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	if verified {
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+	ctx.Request.TLS = state
+	return ctx
+}
+
+func TestX509Filter(t *testing.T) {
+	extractor, err := preauth.NewX509PrincipalExtractor(preauth.X509Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &manager{}
+	f := preauth.NewX509Filter(extractor, m)
+
+	ctx := newX509Context(pkix.Name{CommonName: "admin", Organization: []string{"ingot"}}, true)
+	if err := f.DoFilter(ctx, &chain{}); err != nil {
+		t.Fatal(err)
+	}
+	if m.principal != "admin" {
+		t.Errorf("expected principal admin, got %v", m.principal)
+	}
+
+	m = &manager{}
+	f = preauth.NewX509Filter(extractor, m)
+	ctx = newX509Context(pkix.Name{CommonName: "admin"}, false)
+	if err := f.DoFilter(ctx, &chain{}); err != nil {
+		t.Fatal(err)
+	}
+	if m.principal != nil {
+		t.Error("expected unverified certificate to be ignored")
+	}
+
+	ctx = newX509Context(pkix.Name{Organization: []string{"ingot"}}, true)
+	if err := f.DoFilter(ctx, &chain{}); err == nil {
+		t.Error("expected subject without principal to be rejected")
+	}
+}
+
+func TestX509ExtractorRegex(t *testing.T) {
+	if _, err := preauth.NewX509PrincipalExtractor(preauth.X509Config{SubjectPrincipalRegex: "CN=.*"}); err == nil {
+		t.Error("expected regex without group to be rejected")
+	}
+}
+
+type tenantUser struct {
+	*userdetails.User
+	tenantID string
+}
+
+func (u *tenantUser) GetTenantID() string {
+	return u.tenantID
+}
+
+// 按租户加载用户，记录加载时的租户
+type tenantUserService struct {
+	tenantID string
+}
+
+func (s *tenantUserService) LoadUserByUsername(username string) (userdetails.UserDetails, error) {
+	return s.LoadUserByUsernameAndDetails(username, nil)
+}
+
+func (s *tenantUserService) LoadUserByUsernameAndDetails(username string, details interface{}) (userdetails.UserDetails, error) {
+	s.tenantID = authentication.DetailsTenantID(details)
+	return &tenantUser{User: userdetails.NewUser(username, "", nil), tenantID: s.tenantID}, nil
+}
+
+func (s *tenantUserService) MatchesDetails(user userdetails.UserDetails, details interface{}) bool {
+	return true
+}
+
+func TestProviderTenant(t *testing.T) {
+	service := &tenantUserService{}
+	provider := preauthProvider.NewProvider(service, dao.NewPreChecker())
+
+	// 刷新令牌时主体为原始身份验证信息，使用原始用户所属租户
+	original := authentication.NewAuthenticatedUsernamePasswordAuthToken(&tenantUser{User: userdetails.NewUser("admin", "", nil), tenantID: "2"}, "", nil)
+	token := authPreauth.NewAuthenticationToken(original, "", nil)
+	token.SetDetails(map[string]string{"client_id": "web"})
+	if _, err := provider.Authenticate(token); err != nil {
+		t.Fatal(err)
+	}
+	if service.tenantID != "2" {
+		t.Errorf("expected tenant 2, actual=%s", service.tenantID)
+	}
+
+	// 请求中指定其他租户时拒绝
+	token = authPreauth.NewAuthenticationToken(original, "", nil)
+	token.SetDetails(map[string]string{authentication.ParameterTenantID: "3"})
+	if _, err := provider.Authenticate(token); err == nil {
+		t.Error("tenant mismatch should be rejected")
+	}
+
+	// 主体为用户名时使用认证详情中的租户
+	token = authPreauth.NewAuthenticationToken("admin", "", nil)
+	token.SetDetails(&authentication.WebAuthenticationDetails{TenantID: "4"})
+	if _, err := provider.Authenticate(token); err != nil {
+		t.Fatal(err)
+	}
+	if service.tenantID != "4" {
+		t.Errorf("expected tenant 4, actual=%s", service.tenantID)
+	}
+}
+
+func TestWithTenantID(t *testing.T) {
+	details := map[string]string{"client_id": "web"}
+	result, err := authentication.WithTenantID(details, "2")
+	if err != nil || authentication.DetailsTenantID(result) != "2" {
+		t.Fatalf("result=%v, err=%v", result, err)
+	}
+	if _, ok := details[authentication.ParameterTenantID]; ok {
+		t.Error("origin details should not be modified")
+	}
+	if _, err := authentication.WithTenantID(&authentication.WebAuthenticationDetails{TenantID: "3"}, "2"); err == nil {
+		t.Error("tenant mismatch should be rejected")
+	}
+	if result, _ := authentication.WithTenantID(nil, ""); result != nil {
+		t.Errorf("unexpected details %v", result)
+	}
+}
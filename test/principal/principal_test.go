@@ -0,0 +1,52 @@
+package principal
+
+import (
+	"testing"
+
+	"github.com/ingot-cloud/ingot-go/internal/app/core/security/principal"
+	"github.com/ingot-cloud/ingot-go/internal/app/model/enums"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	cases := map[string]string{
+		"18603243837":        "18603243837",
+		"186 0324 3837":      "18603243837",
+		"186-0324-3837":      "18603243837",
+		"+86 186 0324 3837":  "18603243837",
+		"0086 18603243837":   "18603243837",
+		"8618603243837":      "18603243837",
+		"+1 (415) 555-2671":  "+14155552671",
+		"001 (415) 555-2671": "+14155552671",
+	}
+	for input, expected := range cases {
+		if actual := principal.NormalizePhone(input); actual != expected {
+			t.Errorf("%s: expected %s, actual %s", input, expected, actual)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	cases := map[string]enums.PrincipalTypeEnum{
+		"admin":             enums.PrincipalUsername,
+		"12345":             enums.PrincipalUsername,
+		"+86 186 0324 3837": enums.PrincipalPhone,
+		"Admin@Ingot.com":   enums.PrincipalEmail,
+	}
+	for input, expected := range cases {
+		if actual := principal.Detect(input); actual != expected {
+			t.Errorf("%s: expected %s, actual %s", input, expected, actual)
+		}
+	}
+	if principal.Normalize(enums.PrincipalEmail, " Admin@Ingot.com ") != "admin@ingot.com" {
+		t.Error("email should be lower case")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	if principalType, detected := principal.Resolve("18603243837", "username"); principalType != enums.PrincipalUsername || detected {
+		t.Error("expected requested principal type")
+	}
+	if principalType, detected := principal.Resolve("18603243837", "unknown"); principalType != enums.PrincipalPhone || !detected {
+		t.Error("expected detected principal type")
+	}
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication/provider/dao"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails/cache"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/crypto/password"
+)
+
+// 用户名只在租户内唯一，不同租户的密码不同
+type tenantUserService struct {
+	loads int
+}
+
+func (s *tenantUserService) LoadUserByUsername(username string) (userdetails.UserDetails, error) {
+	return s.LoadUserByUsernameAndDetails(username, nil)
+}
+
+func (s *tenantUserService) LoadUserByUsernameAndDetails(username string, details interface{}) (userdetails.UserDetails, error) {
+	s.loads++
+	return userdetails.NewUser(username, tenant(details)+"-secret", nil), nil
+}
+
+func (s *tenantUserService) MatchesDetails(user userdetails.UserDetails, details interface{}) bool {
+	return user.GetPassword() == tenant(details)+"-secret"
+}
+
+func tenant(details interface{}) string {
+	params, _ := details.(map[string]string)
+	return params[authentication.ParameterTenantID]
+}
+
+func newTenantToken(tenantID, credentials string) *authentication.UsernamePasswordAuthenticationToken {
+	token := authentication.NewUnauthenticatedUsernamePasswordAuthToken("admin", credentials)
+	token.SetDetails(map[string]string{authentication.ParameterTenantID: tenantID})
+	return token
+}
+
+func TestDaoProviderDetailsService(t *testing.T) {
+	service := &tenantUserService{}
+	userCache := cache.NewLRUUserCache(10, time.Minute, cache.GobCodec{})
+	provider := dao.NewProvider(&password.NoopEncoder{}, service, userCache, dao.NewPreChecker(), dao.NewPostChecker(), nil)
+
+	if _, err := provider.Authenticate(newTenantToken("a", "a-secret")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := provider.Authenticate(newTenantToken("a", "a-secret")); err != nil {
+		t.Fatal(err)
+	}
+	if service.loads != 1 {
+		t.Errorf("expected cached user to be used, loads=%d", service.loads)
+	}
+
+	// 其他租户的同名用户不能使用缓存
+	if _, err := provider.Authenticate(newTenantToken("b", "a-secret")); err == nil {
+		t.Error("expected bad credentials for other tenant")
+	}
+	if _, err := provider.Authenticate(newTenantToken("b", "b-secret")); err != nil {
+		t.Fatal(err)
+	}
+}
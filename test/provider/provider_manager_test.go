@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/errors"
+)
+
+type providers []authentication.Provider
+
+func (p providers) Add(authentication.Provider) {}
+
+func (p providers) Get() []authentication.Provider {
+	return p
+}
+
+type provider struct {
+	calls int
+	err   error
+}
+
+func (p *provider) Authenticate(auth core.Authentication) (core.Authentication, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	user := userdetails.NewUser(auth.GetName(auth), "encoded", nil)
+	return authentication.NewAuthenticatedUsernamePasswordAuthToken(user, auth.GetCredentials(), nil), nil
+}
+
+func (p *provider) Supports(auth interface{}) bool {
+	_, ok := auth.(*authentication.UsernamePasswordAuthenticationToken)
+	return ok
+}
+
+func newToken() *authentication.UsernamePasswordAuthenticationToken {
+	return authentication.NewUnauthenticatedUsernamePasswordAuthToken("admin", "secret")
+}
+
+func TestFallthrough(t *testing.T) {
+	failing := &provider{err: errors.BadCredentials("Bad credentials")}
+	success := &provider{}
+	last := &provider{}
+	manager := authentication.NewProviderManager(providers{failing, success, last}, nil)
+
+	result, err := manager.Authenticate(newToken())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failing.calls != 1 || success.calls != 1 || last.calls != 0 {
+		t.Errorf("unexpected calls, failing=%d, success=%d, last=%d", failing.calls, success.calls, last.calls)
+	}
+	if result.GetCredentials() != "" {
+		t.Error("credentials not erased")
+	}
+	if user, ok := result.GetPrincipal().(*userdetails.User); !ok || user.GetPassword() != "" {
+		t.Error("principal password not erased")
+	}
+}
+
+func TestLastError(t *testing.T) {
+	first := &provider{err: errors.AccountLock("locked")}
+	second := &provider{err: errors.BadCredentials("Bad credentials")}
+	manager := authentication.NewProviderManager(providers{first, second}, nil)
+
+	_, err := manager.Authenticate(newToken())
+	if err == nil || err.Error() != "Bad credentials" {
+		t.Fatalf("expected last provider error, actual=%v", err)
+	}
+}
+
+func TestParent(t *testing.T) {
+	parent := authentication.NewProviderManager(providers{&provider{}}, nil)
+	manager := authentication.NewProviderManager(providers{}, nil)
+	manager.Parent = parent
+
+	result, err := manager.Authenticate(newToken())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.GetName(result) != "admin" {
+		t.Errorf("unexpected principal %s", result.GetName(result))
+	}
+}
@@ -0,0 +1,295 @@
+package registration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	coreErrors "github.com/ingot-cloud/ingot-go/pkg/framework/core/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/core/model/types"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/authentication"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/crypto/password"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/config"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/errors"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/clientdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/oauth2/provider/endpoint"
+)
+
+func newContext(method, clientID, token, body string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(method, "http://auth.example.com"+endpoint.APIOAuthRegister, strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		ctx.Request.Header.Set("Authorization", "Bearer "+token)
+	}
+	if clientID != "" {
+		ctx.Params = gin.Params{{Key: "clientId", Value: clientID}}
+	}
+	return ctx
+}
+
+func newEndpoint(registration config.Registration) (*endpoint.ClientRegistrationEndpoint, *clientdetails.InMemoryClientDetailsService) {
+	service := clientdetails.NewInMemoryClientDetailsService(nil)
+	return endpoint.NewClientRegistrationEndpoint(registration, service, password.NewBcryptEncoder(4)), service
+}
+
+// tenantService 支持租户的客户端服务
+type tenantService struct {
+	*clientdetails.InMemoryClientDetailsService
+}
+
+func (s *tenantService) LoadClientByClientIDAndTenant(clientID string, tenantID types.ID) (clientdetails.ClientDetails, error) {
+	client, err := s.LoadClientByClientID(clientID)
+	if err != nil {
+		return nil, err
+	}
+	if types.ID(client.(*clientdetails.BaseClientDetails).TenantID) != tenantID {
+		return nil, errors.NoSuchClient("No client with requested id: ", clientID)
+	}
+	return client, nil
+}
+
+func newConfig() config.Registration {
+	return config.Registration{
+		Enable:             true,
+		InitialAccessToken: "initial",
+		GrantTypes:         []string{"authorization_code", "client_credentials", "refresh_token"},
+		Scopes:             []string{"read", "write"},
+	}
+}
+
+func errorCode(err error) string {
+	if e, ok := err.(*coreErrors.E); ok {
+		return e.Code
+	}
+	return ""
+}
+
+func TestRegisterClient(t *testing.T) {
+	e, service := newEndpoint(newConfig())
+
+	info, err := e.Register(newContext(http.MethodPost, "", "initial", `{"redirect_uris":["https://app.example.com/callback"],"grant_types":["authorization_code","refresh_token"],"scope":"read write"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ClientID == "" || info.ClientSecret == "" || info.RegistrationAccessToken == "" {
+		t.Fatalf("expected credentials issued, got %+v", info)
+	}
+	if info.TokenEndpointAuthMethod != endpoint.AuthMethodClientSecretBasic {
+		t.Errorf("expected default auth method, got %s", info.TokenEndpointAuthMethod)
+	}
+	if info.RegistrationClientURI != "http://auth.example.com/oauth/register/"+info.ClientID {
+		t.Errorf("unexpected registration client uri %s", info.RegistrationClientURI)
+	}
+
+	client, err := service.LoadClientByClientID(info.ClientID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := password.NewBcryptEncoder(4).Matches(info.ClientSecret, client.GetClientSecret()); !ok {
+		t.Error("expected encoded client secret saved")
+	}
+	if len(client.GetScope()) != 2 || client.GetRegisteredRedirectURI()[0] != "https://app.example.com/callback" {
+		t.Errorf("unexpected client %+v", client)
+	}
+}
+
+func TestRegisterInvalidMetadata(t *testing.T) {
+	registration := newConfig()
+	registration.Scopes = []string{"read"}
+	e, _ := newEndpoint(registration)
+
+	cases := map[string]string{
+		`{"grant_types":["authorization_code"]}`:                                                            errors.InvalidRedirectURICode,
+		`{"redirect_uris":["/callback"]}`:                                                                   errors.InvalidRedirectURICode,
+		`{"redirect_uris":["https://app.example.com/cb#frag"]}`:                                             errors.InvalidRedirectURICode,
+		`{"grant_types":["password"]}`:                                                                      errors.InvalidClientMetadataCode,
+		`{"grant_types":["client_credentials"],"scope":"admin"}`:                                            errors.InvalidClientMetadataCode,
+		`{"grant_types":["client_credentials"],"token_endpoint_auth_method":"none"}`:                        errors.InvalidClientMetadataCode,
+		`{"grant_types":["client_credentials"],"token_endpoint_auth_method":"tls_client_auth"}`:             errors.InvalidClientMetadataCode,
+		`{"grant_types":["client_credentials"],"token_endpoint_auth_method":"self_signed_tls_client_auth"}`: errors.InvalidClientMetadataCode,
+	}
+	for body, code := range cases {
+		if _, err := e.Register(newContext(http.MethodPost, "", "initial", body)); errorCode(err) != code {
+			t.Errorf("%s: expected %s, got %v", body, code, err)
+		}
+	}
+}
+
+func TestRegisterInitialAccessToken(t *testing.T) {
+	e, _ := newEndpoint(newConfig())
+	body := `{"grant_types":["client_credentials"]}`
+
+	if _, err := e.Register(newContext(http.MethodPost, "", "wrong", body)); errorCode(err) != errors.InvalidTokenCode {
+		t.Errorf("expected invalid token, got %v", err)
+	}
+	if _, err := e.Register(newContext(http.MethodPost, "", "", body)); errorCode(err) != errors.InvalidTokenCode {
+		t.Errorf("expected invalid token without token, got %v", err)
+	}
+	if _, err := e.Register(newContext(http.MethodPost, "", "initial", body)); err != nil {
+		t.Errorf("expected registered, got %v", err)
+	}
+
+	disabled, _ := newEndpoint(config.Registration{})
+	if _, err := disabled.Register(newContext(http.MethodPost, "", "", body)); err == nil {
+		t.Error("expected registration disabled")
+	}
+
+	// 未配置初始访问令牌时不允许开放注册
+	open := newConfig()
+	open.InitialAccessToken = ""
+	e, _ = newEndpoint(open)
+	if _, err := e.Register(newContext(http.MethodPost, "", "", body)); errorCode(err) != errors.InvalidTokenCode {
+		t.Errorf("expected open registration rejected, got %v", err)
+	}
+}
+
+func TestRegisterAllowList(t *testing.T) {
+	registration := newConfig()
+	registration.GrantTypes = []string{"client_credentials"}
+	registration.Scopes = nil
+	e, _ := newEndpoint(registration)
+
+	cases := map[string]string{
+		`{"grant_types":["refresh_token"]}`:                  errors.InvalidClientMetadataCode,
+		`{"redirect_uris":["https://app.example.com/cb"]}`:   errors.InvalidClientMetadataCode,
+		`{"grant_types":["client_credentials"],"scope":"a"}`: errors.InvalidClientMetadataCode,
+	}
+	for body, code := range cases {
+		if _, err := e.Register(newContext(http.MethodPost, "", "initial", body)); errorCode(err) != code {
+			t.Errorf("%s: expected %s, got %v", body, code, err)
+		}
+	}
+	if _, err := e.Register(newContext(http.MethodPost, "", "initial", `{"grant_types":["client_credentials"]}`)); err != nil {
+		t.Errorf("expected registered, got %v", err)
+	}
+}
+
+func TestRegisterTenant(t *testing.T) {
+	service := &tenantService{clientdetails.NewInMemoryClientDetailsService(nil)}
+	e := endpoint.NewClientRegistrationEndpoint(newConfig(), service, password.NewBcryptEncoder(4))
+	body := `{"grant_types":["client_credentials"]}`
+
+	if _, err := e.Register(newContext(http.MethodPost, "", "initial", body)); errorCode(err) != errors.InvalidClientMetadataCode {
+		t.Errorf("expected tenant required, got %v", err)
+	}
+
+	ctx := newContext(http.MethodPost, "", "initial", body)
+	ctx.Request.Header.Set(authentication.TenantIDHeader, "2")
+	info, err := e.Register(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, _ := service.LoadClientByClientID(info.ClientID)
+	if client.(*clientdetails.BaseClientDetails).TenantID != 2 {
+		t.Errorf("expected client registered in tenant 2, got %+v", client)
+	}
+
+	ctx = newContext(http.MethodGet, info.ClientID, info.RegistrationAccessToken, "")
+	ctx.Request.Header.Set(authentication.TenantIDHeader, "3")
+	if _, err := e.Read(ctx); errorCode(err) != errors.InvalidTokenCode {
+		t.Errorf("expected client not found in other tenant, got %v", err)
+	}
+	ctx = newContext(http.MethodGet, info.ClientID, info.RegistrationAccessToken, "")
+	ctx.Request.Header.Set(authentication.TenantIDHeader, "2")
+	if _, err := e.Read(ctx); err != nil {
+		t.Errorf("expected client read in tenant 2, got %v", err)
+	}
+
+	// 只删除客户端所属租户中的客户端
+	if err := service.RemoveClientDetails(info.ClientID, types.ID(3)); errorCode(err) != errors.NoSuchClientCode {
+		t.Errorf("expected no client removed in tenant 3, got %v", err)
+	}
+	ctx = newContext(http.MethodDelete, info.ClientID, info.RegistrationAccessToken, "")
+	ctx.Request.Header.Set(authentication.TenantIDHeader, "2")
+	if err := e.Delete(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.LoadClientByClientID(info.ClientID); errorCode(err) != errors.NoSuchClientCode {
+		t.Errorf("expected client removed, got %v", err)
+	}
+}
+
+func TestRegistrationClientURI(t *testing.T) {
+	registration := newConfig()
+	registration.TrustedProxies = []string{"10.0.0.0/8"}
+	e, _ := newEndpoint(registration)
+	body := `{"grant_types":["client_credentials"]}`
+
+	ctx := newContext(http.MethodPost, "", "initial", body)
+	ctx.Request.RemoteAddr = "192.168.1.1:1234"
+	ctx.Request.Header.Set("X-Forwarded-Proto", "https")
+	info, err := e.Register(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(info.RegistrationClientURI, "http://") {
+		t.Errorf("expected untrusted forwarded proto ignored, got %s", info.RegistrationClientURI)
+	}
+
+	ctx = newContext(http.MethodPost, "", "initial", body)
+	ctx.Request.RemoteAddr = "10.0.0.1:1234"
+	ctx.Request.Header.Set("X-Forwarded-Proto", "https")
+	if info, err = e.Register(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(info.RegistrationClientURI, "https://") {
+		t.Errorf("expected trusted forwarded proto used, got %s", info.RegistrationClientURI)
+	}
+}
+
+func TestManageClient(t *testing.T) {
+	e, service := newEndpoint(newConfig())
+	info, err := e.Register(newContext(http.MethodPost, "", "initial", `{"grant_types":["client_credentials"],"scope":"read"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := info.RegistrationAccessToken
+
+	if _, err := e.Read(newContext(http.MethodGet, info.ClientID, "wrong", "")); errorCode(err) != errors.InvalidTokenCode {
+		t.Errorf("expected invalid token, got %v", err)
+	}
+	if _, err := e.Read(newContext(http.MethodGet, "unknown", token, "")); errorCode(err) != errors.InvalidTokenCode {
+		t.Errorf("expected invalid token for unknown client, got %v", err)
+	}
+	read, err := e.Read(newContext(http.MethodGet, info.ClientID, token, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Scope != "read" || read.ClientSecret != "" || read.TokenEndpointAuthMethod != endpoint.AuthMethodClientSecretBasic {
+		t.Errorf("unexpected client information %+v", read)
+	}
+
+	if _, err := e.Update(newContext(http.MethodPut, info.ClientID, token, `{"client_id":"other","grant_types":["client_credentials"]}`)); errorCode(err) != errors.InvalidClientMetadataCode {
+		t.Errorf("expected client id mismatch, got %v", err)
+	}
+	if _, err := e.Update(newContext(http.MethodPut, info.ClientID, token, `{"client_id":"`+info.ClientID+`","client_secret":"chosen","grant_types":["client_credentials"]}`)); errorCode(err) != errors.InvalidClientMetadataCode {
+		t.Errorf("expected client secret mismatch, got %v", err)
+	}
+	updated, err := e.Update(newContext(http.MethodPut, info.ClientID, token, `{"client_id":"`+info.ClientID+`","client_secret":"`+info.ClientSecret+`","grant_types":["client_credentials"],"scope":"read write"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.RegistrationAccessToken == "" || updated.RegistrationAccessToken == token {
+		t.Error("expected registration access token rotated")
+	}
+	client, _ := service.LoadClientByClientID(info.ClientID)
+	if len(client.GetScope()) != 2 {
+		t.Errorf("expected scope updated, got %v", client.GetScope())
+	}
+	if ok, _ := password.NewBcryptEncoder(4).Matches(info.ClientSecret, client.GetClientSecret()); !ok {
+		t.Error("expected client secret kept")
+	}
+	if _, err := e.Read(newContext(http.MethodGet, info.ClientID, token, "")); errorCode(err) != errors.InvalidTokenCode {
+		t.Errorf("expected old token rejected, got %v", err)
+	}
+
+	if err := e.Delete(newContext(http.MethodDelete, info.ClientID, updated.RegistrationAccessToken, "")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.LoadClientByClientID(info.ClientID); errorCode(err) != errors.NoSuchClientCode {
+		t.Errorf("expected client removed, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package rememberme
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/configurers/rememberme"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/session"
+)
+
+const sessionCookieName = "SESSION"
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+type userService struct {
+}
+
+func (*userService) LoadUserByUsername(username string) (userdetails.UserDetails, error) {
+	return userdetails.NewUser(username, "{noop}password", nil), nil
+}
+
+type chain struct {
+}
+
+func (*chain) DoFilter(ctx *ingot.Context) error {
+	return nil
+}
+
+func newContext(cookies ...*http.Cookie) (*ingot.Context, *httptest.ResponseRecorder) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://ingot.cloud/", nil)
+	for _, cookie := range cookies {
+		ctx.Request.AddCookie(cookie)
+	}
+	return ingot.NewContext(ctx), recorder
+}
+
+func findCookie(recorder *httptest.ResponseRecorder, name string) *http.Cookie {
+	var result *http.Cookie
+	for _, cookie := range recorder.Result().Cookies() {
+		if cookie.Name == name {
+			result = cookie
+		}
+	}
+	return result
+}
+
+func newServices(repository rememberme.TokenRepository) *rememberme.PersistentTokenServices {
+	return rememberme.NewPersistentTokenServices(rememberme.Config{AlwaysRemember: true}, repository, &userService{}, nil)
+}
+
+func login(t *testing.T, services *rememberme.PersistentTokenServices) *http.Cookie {
+	ctx, recorder := newContext()
+	if err := services.LoginSuccess(ctx, rememberme.NewAuthenticationToken(userdetails.NewUser("ingot", "", nil), nil)); err != nil {
+		t.Fatal(err)
+	}
+	cookie := findCookie(recorder, rememberme.DefaultCookieName)
+	if cookie == nil || cookie.Value == "" {
+		t.Fatal("expected remember-me cookie")
+	}
+	return cookie
+}
+
+func TestFilterErasesCredentials(t *testing.T) {
+	services := newServices(rememberme.NewMemoryTokenRepository())
+	cookie := login(t, services)
+
+	store := session.NewRepositoryStore(session.NewMemoryRepository(), session.Config{CookieName: sessionCookieName, Timeout: 60})
+	f := rememberme.NewFilter(services, store)
+
+	ctx, recorder := newContext(cookie)
+	if err := f.DoFilter(ctx, &chain{}); err != nil {
+		t.Fatal(err)
+	}
+	auth := ctx.GetAuthentication()
+	if auth == nil || auth.GetName(auth) != "ingot" {
+		t.Fatal("expected remember-me authentication")
+	}
+
+	sessionCookie := findCookie(recorder, sessionCookieName)
+	if sessionCookie == nil {
+		t.Fatal("expected session cookie")
+	}
+	ctx, _ = newContext(sessionCookie)
+	current, err := store.GetSession(ctx, false)
+	if err != nil || current == nil {
+		t.Fatal("expected saved session")
+	}
+	saved := session.GetAuthentication(current)
+	if saved == nil {
+		t.Fatal("expected authentication in session")
+	}
+	user, ok := saved.GetPrincipal().(userdetails.UserDetails)
+	if !ok || user.GetPassword() != "" {
+		t.Error("expected credentials to be erased before saving to session")
+	}
+}
+
+func TestCookieTheftRemovesUserTokens(t *testing.T) {
+	repository := rememberme.NewMemoryTokenRepository()
+	services := newServices(repository)
+	stolen := login(t, services)
+
+	// 合法用户先使用 cookie，token 被刷新
+	ctx, recorder := newContext(stolen)
+	if auth, err := services.AutoLogin(ctx); err != nil || auth == nil {
+		t.Fatalf("expected auto login, err = %v", err)
+	}
+	refreshed := findCookie(recorder, rememberme.DefaultCookieName)
+
+	// 旧 cookie 再次使用时视为盗用，删除该用户所有 token
+	ctx, _ = newContext(stolen)
+	if _, err := services.AutoLogin(ctx); err == nil {
+		t.Fatal("expected theft to be detected")
+	}
+	ctx, _ = newContext(refreshed)
+	if auth, _ := services.AutoLogin(ctx); auth != nil {
+		t.Error("expected user tokens to be removed")
+	}
+}
+
+func TestRemoveUserTokensInTenant(t *testing.T) {
+	repository := rememberme.NewMemoryTokenRepository()
+	now := time.Now()
+	_ = repository.CreateNewToken(&rememberme.PersistentToken{Username: "admin", TenantID: "1", Series: "a", Token: "a", LastUsed: now})
+	_ = repository.CreateNewToken(&rememberme.PersistentToken{Username: "admin", TenantID: "2", Series: "b", Token: "b", LastUsed: now})
+
+	if err := repository.RemoveUserTokens("admin", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if token, _ := repository.GetTokenForSeries("a"); token != nil {
+		t.Error("expected token of tenant 1 removed")
+	}
+	// 其他租户中相同用户名的 token 保持不变
+	if token, _ := repository.GetTokenForSeries("b"); token == nil {
+		t.Error("expected token of tenant 2 kept")
+	}
+}
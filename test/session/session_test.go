@@ -0,0 +1,119 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/ingot"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/web/session"
+)
+
+const secret = "0123456789abcdef0123456789abcdef"
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func newContext(cookies ...*http.Cookie) (*ingot.Context, *httptest.ResponseRecorder) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://ingot.cloud/", nil)
+	for _, cookie := range cookies {
+		ctx.Request.AddCookie(cookie)
+	}
+	return ingot.NewContext(ctx), recorder
+}
+
+func responseCookie(t *testing.T, recorder *httptest.ResponseRecorder) *http.Cookie {
+	cookies := recorder.Result().Cookies()
+	if len(cookies) == 0 {
+		t.Fatal("expected session cookie")
+	}
+	return cookies[len(cookies)-1]
+}
+
+func TestCookieStoreSecret(t *testing.T) {
+	for _, value := range []string{"", "short"} {
+		if _, err := session.NewCookieStore(session.Config{Secret: value}, session.GobCodec{}); err == nil {
+			t.Errorf("expected error for secret %q", value)
+		}
+	}
+}
+
+func TestCookieStore(t *testing.T) {
+	store, err := session.NewCookieStore(session.Config{Secret: secret, Timeout: 60}, session.GobCodec{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, recorder := newContext()
+	s, err := store.GetSession(ctx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetAttribute("name", "ingot")
+	if err = store.Save(ctx, s); err != nil {
+		t.Fatal(err)
+	}
+	cookie := responseCookie(t, recorder)
+	if !cookie.HttpOnly {
+		t.Error("session cookie should be http only")
+	}
+
+	ctx, _ = newContext(cookie)
+	loaded, err := store.GetSession(ctx, false)
+	if err != nil || loaded == nil || loaded.GetAttribute("name") != "ingot" {
+		t.Fatalf("expected session loaded, got %+v %v", loaded, err)
+	}
+
+	// 篡改数据后签名校验失败
+	forged := *cookie
+	forged.Value = strings.Replace(cookie.Value, cookie.Value[:4], "AAAA", 1)
+	ctx, _ = newContext(&forged)
+	if loaded, _ = store.GetSession(ctx, false); loaded != nil {
+		t.Error("expected forged session rejected")
+	}
+
+	// 其他密钥签名的会话无效
+	other, _ := session.NewCookieStore(session.Config{Secret: strings.Repeat("x", 32)}, session.GobCodec{})
+	ctx, _ = newContext(cookie)
+	if loaded, _ = other.GetSession(ctx, false); loaded != nil {
+		t.Error("expected session signed with other secret rejected")
+	}
+}
+
+func TestRepositoryStore(t *testing.T) {
+	store := session.NewRepositoryStore(session.NewMemoryRepository(), session.Config{Timeout: 60})
+
+	ctx, _ := newContext()
+	if s, _ := store.GetSession(ctx, false); s != nil {
+		t.Error("session should not be created")
+	}
+
+	ctx, recorder := newContext()
+	s, _ := store.GetSession(ctx, true)
+	s.SetAttribute("name", "ingot")
+	if err := store.Save(ctx, s); err != nil {
+		t.Fatal(err)
+	}
+	cookie := responseCookie(t, recorder)
+	if cookie.Value != s.ID {
+		t.Errorf("expected session id in cookie, got %s", cookie.Value)
+	}
+
+	ctx, _ = newContext(cookie)
+	loaded, _ := store.GetSession(ctx, false)
+	if loaded == nil || loaded.GetAttribute("name") != "ingot" {
+		t.Fatalf("expected session loaded, got %+v", loaded)
+	}
+	if err := store.Invalidate(ctx, loaded); err != nil {
+		t.Fatal(err)
+	}
+	ctx, _ = newContext(cookie)
+	if loaded, _ = store.GetSession(ctx, false); loaded != nil {
+		t.Error("expected session invalidated")
+	}
+}
@@ -0,0 +1,118 @@
+package sms
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/sms"
+)
+
+type recordSender struct {
+	codes map[string]string
+	err   error
+}
+
+func (s *recordSender) Send(phone, code string) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.codes[phone] = code
+	return nil
+}
+
+func newService(sender sms.Sender) *sms.CodeService {
+	config := sms.Config{Enable: true, MaxAttempts: 2, Store: sms.StoreMemory}
+	return sms.NewCodeService(config, sms.NewMemoryStore(), sender, sms.NewDefaultPhoneNormalizer())
+}
+
+func TestSendAndVerify(t *testing.T) {
+	sender := &recordSender{codes: map[string]string{}}
+	service := newService(sender)
+
+	if err := service.Send("13800000000"); err != nil {
+		t.Fatal(err)
+	}
+	code := sender.codes["13800000000"]
+	if len(code) != 6 {
+		t.Fatalf("unexpected code %q", code)
+	}
+	if err := service.Send("13800000000"); err == nil {
+		t.Error("expected resend to be rejected during cooldown")
+	}
+	if err := service.Verify("13800000000", code); err != nil {
+		t.Fatal(err)
+	}
+	// 验证码只能使用一次
+	if err := service.Verify("13800000000", code); err == nil {
+		t.Error("expected used code to be rejected")
+	}
+}
+
+func TestVerifyMaxAttempts(t *testing.T) {
+	sender := &recordSender{codes: map[string]string{}}
+	service := newService(sender)
+	service.Send("13800000000")
+	code := sender.codes["13800000000"]
+
+	for i := 0; i < 2; i++ {
+		if err := service.Verify("13800000000", "bad"); err == nil {
+			t.Fatal("expected bad code to be rejected")
+		}
+	}
+	if err := service.Verify("13800000000", code); err == nil {
+		t.Error("expected code to be invalid after max attempts")
+	}
+}
+
+func TestSendFailure(t *testing.T) {
+	service := newService(&recordSender{err: errors.New("gateway down")})
+	if err := service.Send("13800000000"); err == nil {
+		t.Fatal("expected send error")
+	}
+	// 发送失败不占用发送间隔
+	service.Sender = &recordSender{codes: map[string]string{}}
+	if err := service.Send("13800000000"); err != nil {
+		t.Error(err)
+	}
+}
+
+// 大陆区号按应用规则去除
+type cnPhoneNormalizer struct{}
+
+func (cnPhoneNormalizer) Normalize(phone string) string {
+	phone = sms.NewDefaultPhoneNormalizer().Normalize(phone)
+	return strings.TrimPrefix(phone, "+86")
+}
+
+func TestNormalizePhone(t *testing.T) {
+	sender := &recordSender{codes: map[string]string{}}
+	service := newService(sender)
+
+	if err := service.Send(" 138-0000-0000 "); err != nil {
+		t.Fatal(err)
+	}
+	code := sender.codes["13800000000"]
+	// 同一手机号的不同写法共用发送间隔和验证码
+	if err := service.Send("138 0000 0000"); err == nil {
+		t.Error("expected resend to be rejected during cooldown")
+	}
+	if err := service.Verify("(138)00000000", code); err != nil {
+		t.Fatal(err)
+	}
+
+	service.PhoneNormalizer = cnPhoneNormalizer{}
+	if err := service.Send("+86 139 0000 0000"); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Verify("13900000000", sender.codes["13900000000"]); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNilSender(t *testing.T) {
+	service := newService(&sms.NilSender{})
+	if err := service.Send("13800000000"); err == nil {
+		t.Error("expected send to fail without sender")
+	}
+}
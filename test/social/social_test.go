@@ -0,0 +1,88 @@
+package social
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/social"
+)
+
+// 模拟第三方平台的 token 和用户信息接口
+func newStubServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("client_secret") != "secret" {
+			t.Errorf("unexpected token request %v", r.PostForm)
+		}
+		if r.PostForm.Get("code") != "good" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant", "error_description": "bad code"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "at-1", "token_type": "bearer"})
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer at-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 12345678901234567, "login": "octocat", "email": "octo@example.com"}`))
+	})
+	return httptest.NewServer(mux)
+}
+
+func newConfig(server *httptest.Server) social.Config {
+	return social.Config{
+		Type:         "github",
+		ClientID:     "app",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/callback",
+		TokenURL:     server.URL + "/token",
+		UserInfoURL:  server.URL + "/user",
+	}
+}
+
+func TestOAuth2ProviderExchange(t *testing.T) {
+	server := newStubServer(t)
+	defer server.Close()
+
+	info, err := social.NewProvider(newConfig(server)).Exchange("good")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 数字 ID 不能丢失精度
+	if info.ID != "12345678901234567" || info.Name != "octocat" || info.Email != "octo@example.com" {
+		t.Errorf("unexpected user info %+v", info)
+	}
+}
+
+func TestOAuth2ProviderBadCode(t *testing.T) {
+	server := newStubServer(t)
+	defer server.Close()
+
+	if _, err := social.NewOAuth2Provider(newConfig(server)).Exchange("bad"); err == nil {
+		t.Error("expected exchange error")
+	}
+}
+
+type stubProvider struct{}
+
+func (stubProvider) Exchange(code string) (*social.UserInfo, error) {
+	return &social.UserInfo{ID: "stub-" + code}, nil
+}
+
+func TestRegisterProvider(t *testing.T) {
+	social.RegisterProvider("stub", func(social.Config) social.Provider {
+		return stubProvider{}
+	})
+	info, err := social.NewProvider(social.Config{Type: "stub"}).Exchange("x")
+	if err != nil || info.ID != "stub-x" {
+		t.Errorf("unexpected result %+v, %v", info, err)
+	}
+}
@@ -0,0 +1,53 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/store"
+)
+
+type value struct {
+	Name  string
+	Count int
+}
+
+func TestMemoryTTLStore(t *testing.T) {
+	s := store.NewMemoryTTLStore()
+
+	if err := s.Set("a", &value{Name: "a", Count: 1}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	var v value
+	ok, err := s.Get("a", &v)
+	if err != nil || !ok || v.Name != "a" || v.Count != 1 {
+		t.Fatalf("unexpected value %v, ok=%v, err=%v", v, ok, err)
+	}
+
+	if err := s.Delete("a"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := s.Get("a", &v); ok {
+		t.Fatal("expected deleted")
+	}
+}
+
+func TestMemoryTTLStoreExpired(t *testing.T) {
+	s := store.NewMemoryTTLStore()
+
+	if err := s.Set("a", &value{Name: "a"}, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	var v value
+	if ok, _ := s.Get("a", &v); ok {
+		t.Fatal("expected expired")
+	}
+
+	// ttl 小于等于 0 时删除
+	_ = s.Set("b", &value{Name: "b"}, time.Minute)
+	_ = s.Set("b", &value{Name: "b"}, 0)
+	if ok, _ := s.Get("b", &v); ok {
+		t.Fatal("expected deleted")
+	}
+}
@@ -0,0 +1,80 @@
+package usercache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/authority"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails"
+	"github.com/ingot-cloud/ingot-go/pkg/framework/security/core/userdetails/cache"
+)
+
+func newUser(username string) *userdetails.User {
+	return userdetails.NewUser(username, "{noop}secret", []core.GrantedAuthority{&authority.SimpleGrantedAuthority{Role: "admin"}})
+}
+
+func TestLRUUserCache(t *testing.T) {
+	c := cache.NewLRUUserCache(2, time.Minute, cache.GobCodec{})
+	c.PutUserInCache(newUser("a"))
+	c.PutUserInCache(newUser("b"))
+	// 访问 a 后 b 成为最久未使用
+	c.GetUserFromCache("a")
+	c.PutUserInCache(newUser("c"))
+
+	if user, _ := c.GetUserFromCache("b"); user != nil {
+		t.Error("expected b to be evicted")
+	}
+	user, err := c.GetUserFromCache("a")
+	if err != nil || user == nil {
+		t.Fatalf("expected a in cache, err=%v", err)
+	}
+	if user.GetPassword() != "{noop}secret" || user.GetAuthorities()[0].GetAuthority() != "admin" {
+		t.Errorf("unexpected cached user %+v", user)
+	}
+
+	// 修改返回的用户不影响缓存
+	user.(*userdetails.User).Password = ""
+	again, _ := c.GetUserFromCache("a")
+	if again.GetPassword() != "{noop}secret" {
+		t.Error("cached user was modified")
+	}
+}
+
+func TestLRUUserCacheTTL(t *testing.T) {
+	c := cache.NewLRUUserCache(10, time.Millisecond, cache.GobCodec{})
+	c.PutUserInCache(newUser("a"))
+	time.Sleep(5 * time.Millisecond)
+	if user, _ := c.GetUserFromCache("a"); user != nil {
+		t.Error("expected expired user to be removed")
+	}
+}
+
+func TestEvictor(t *testing.T) {
+	c := cache.NewLRUUserCache(10, time.Minute, cache.GobCodec{})
+	evictor := cache.NewEvictor(c)
+	c.PutUserInCache(newUser("a"))
+	c.PutUserInCache(newUser("b"))
+	c.PutUserInCache(newUser("c"))
+
+	evictor.EvictUser("a")
+	if user, _ := c.GetUserFromCache("a"); user != nil {
+		t.Error("expected a to be evicted")
+	}
+	if user, _ := c.GetUserFromCache("b"); user == nil {
+		t.Error("expected b in cache")
+	}
+	evictor.EvictAll()
+	if user, _ := c.GetUserFromCache("c"); user != nil {
+		t.Error("expected cache to be cleared")
+	}
+}
+
+func TestNewUserCache(t *testing.T) {
+	if _, ok := cache.NewUserCache(cache.Config{}, nil).(*cache.NilUserCache); !ok {
+		t.Error("expected nil user cache by default")
+	}
+	if _, ok := cache.NewUserCache(cache.Config{Type: cache.TypeMemory}, nil).(*cache.LRUUserCache); !ok {
+		t.Error("expected lru user cache")
+	}
+}